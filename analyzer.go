@@ -1,11 +1,22 @@
 package main
 
 import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"io/fs"
 	"log/slog"
+	"maps"
+	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
+	"unicode/utf16"
 
 	"github.com/bitfield/script"
 	"github.com/hashicorp/hcl/v2"
@@ -13,6 +24,7 @@ import (
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/samber/lo"
 	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/gocty"
 )
 
 // ============================================================================
@@ -20,35 +32,160 @@ import (
 // ============================================================================
 
 type BackendConfig struct {
-	Type   *string `json:"type"`
-	Region *string `json:"region"`
+	Type       *string           `json:"type"`
+	Region     *string           `json:"region"`
+	Attributes map[string]string `json:"attributes,omitempty"` // Literal string attributes, e.g. bucket and key; credentials are redacted
+	Security   *BackendSecurity  `json:"security,omitempty"`   // Set for s3 backends only
+	Workspace  *BackendWorkspace `json:"workspace,omitempty"`  // Set for cloud blocks and remote backends
+}
+
+// BackendWorkspace is the HCP Terraform organization and the workspaces a
+// cloud block or remote backend maps the configuration to
+type BackendWorkspace struct {
+	Organization string   `json:"organization,omitempty"`
+	Name         string   `json:"name,omitempty"`   // A single workspace
+	Prefix       string   `json:"prefix,omitempty"` // remote only: workspaces named <prefix><terraform workspace>
+	Tags         []string `json:"tags,omitempty"`   // cloud only: workspaces carrying every tag
+	Project      string   `json:"project,omitempty"`
+}
+
+// BackendSecurity records how an S3 backend protects the state file
+type BackendSecurity struct {
+	Encrypted    bool `json:"encrypted"`     // encrypt = true
+	StateLocking bool `json:"state_locking"` // dynamodb_table is set, or use_lockfile = true
+	KMSKeyID     bool `json:"kms_key_id"`    // kms_key_id selects a customer-managed key
 }
 
 type ProviderDetail struct {
 	Source  string   `json:"source"`
 	Version string   `json:"version"`
 	Regions []string `json:"regions"`
+	Aliases []string `json:"aliases,omitempty"`
+	// Set by --check-latest from the Terraform Registry
+	LatestVersion  string `json:"latest_version,omitempty"`
+	VersionsBehind int    `json:"versions_behind,omitempty"` // Releases newer than the newest one the constraint allows
 }
 
 type ProvidersAnalysis struct {
-	UniqueProviderCount int              `json:"unique_provider_count"`
-	ProviderDetails     []ProviderDetail `json:"provider_details"`
+	UniqueProviderCount int                    `json:"unique_provider_count"`
+	ProviderDetails     []ProviderDetail       `json:"provider_details"`
+	VersionIssues       []ProviderVersionIssue `json:"version_issues,omitempty"`
+}
+
+// Provider version constraint classifications
+const (
+	VersionPinned   = "pinned"
+	VersionRange    = "range"
+	VersionUnpinned = "unpinned"
+)
+
+// ProviderVersionIssue is a provider whose version constraint is missing or
+// too loose to protect against breaking upgrades
+type ProviderVersionIssue struct {
+	Source         string `json:"source"`
+	Version        string `json:"version"`
+	Classification string `json:"classification"`
+	Reason         string `json:"reason"`
 }
 
 type ModuleDetail struct {
-	Source string `json:"source"`
-	Count  int    `json:"count"`
+	Source     string `json:"source"`
+	Version    string `json:"version,omitempty"`
+	SourceKind string `json:"source_kind"`
+	Count      int    `json:"count"`             // Instances, with literal count and for_each resolved
+	Dynamic    bool   `json:"dynamic,omitempty"` // A call's count or for_each is not a literal, so Count is a lower bound
 }
 
 type ModulesAnalysis struct {
-	TotalModuleCalls  int            `json:"total_module_calls"`
-	UniqueModuleCount int            `json:"unique_module_count"`
-	UniqueModules     []ModuleDetail `json:"unique_modules"`
+	TotalModuleCalls    int            `json:"total_module_calls"`
+	UniqueModuleCount   int            `json:"unique_module_count"`
+	UniqueModules       []ModuleDetail `json:"unique_modules"`
+	SourceTypeBreakdown map[string]int `json:"source_type_breakdown"`
+	UnpinnedModules     []ModuleDetail `json:"unpinned_modules,omitempty"`
+	// Local module nesting: the longest chain of ./ and ../ module calls and every call in that tree
+	LocalModuleDepth int               `json:"local_module_depth,omitempty"`
+	LocalModuleTree  []LocalModuleNode `json:"local_module_tree,omitempty"`
+}
+
+// LocalModuleNode is one local module call in the flattened module tree, in
+// depth-first order. Paths are directories relative to the repository root.
+type LocalModuleNode struct {
+	Path       string `json:"path"`
+	CalledFrom string `json:"called_from"`
+	Source     string `json:"source"`
+	Depth      int    `json:"depth"`              // 1 for modules called by a root module
+	Missing    bool   `json:"missing,omitempty"`  // The source directory does not exist
+	Cycle      bool   `json:"cycle,omitempty"`    // The module is already being called higher up the chain
+	External   bool   `json:"external,omitempty"` // The source leaves the repository and is not followed
+}
+
+// Module source kinds, set on ModuleDetail.SourceKind and counted in SourceTypeBreakdown
+const (
+	ModuleSourceLocal    = "local"
+	ModuleSourceRegistry = "registry"
+	ModuleSourceGit      = "git"
+	ModuleSourceS3       = "s3"
+	ModuleSourceArchive  = "archive"
+	ModuleSourceOther    = "other"
+)
+
+// moduleArchiveExtensions are the archive formats Terraform unpacks from HTTP sources
+var moduleArchiveExtensions = []string{".zip", ".tar.gz", ".tgz", ".tar.bz2", ".tbz2", ".tar.xz", ".txz"}
+
+var (
+	// registryModulePattern matches [<HOST>/]<NAMESPACE>/<NAME>/<PROVIDER> with an optional //subdir
+	registryModulePattern = regexp.MustCompile(`^([a-zA-Z0-9.-]+\.[a-zA-Z]+(:[0-9]+)?/)?[a-zA-Z0-9_-]+/[a-zA-Z0-9_-]+/[a-zA-Z0-9]+(//.*)?$`)
+	s3ModuleHostPattern   = regexp.MustCompile(`^(https://)?s3[.-]([a-z0-9-]+\.)?amazonaws\.com/`)
+)
+
+// categorizeModuleSource classifies a module source address using Terraform's
+// source syntax: local paths, registry addresses, git and s3 forced getters
+// or their recognized hosts, archive downloads, and everything else as other
+func categorizeModuleSource(source string) string {
+	switch {
+	case strings.HasPrefix(source, "./") || strings.HasPrefix(source, "../"):
+		return ModuleSourceLocal
+	case strings.HasPrefix(source, "git::") || strings.HasPrefix(source, "git@") ||
+		strings.HasPrefix(source, "github.com/") || strings.HasPrefix(source, "bitbucket.org/"):
+		return ModuleSourceGit
+	case strings.HasPrefix(source, "s3::") || s3ModuleHostPattern.MatchString(source):
+		return ModuleSourceS3
+	case isArchiveModuleSource(source):
+		return ModuleSourceArchive
+	case registryModulePattern.MatchString(source):
+		return ModuleSourceRegistry
+	default:
+		return ModuleSourceOther
+	}
+}
+
+// isArchiveModuleSource reports whether a source downloads an archive, either
+// by file extension or an explicit archive= query parameter
+func isArchiveModuleSource(source string) bool {
+	address, query, _ := strings.Cut(source, "?")
+	if _, rest, found := strings.Cut(address, "://"); found {
+		address = rest
+	}
+	address, _, _ = strings.Cut(address, "//") // drop a //subdir suffix
+	return strings.Contains("&"+query, "&archive=") ||
+		lo.SomeBy(moduleArchiveExtensions, func(extension string) bool {
+			return strings.HasSuffix(address, extension)
+		})
+}
+
+// moduleSourceTypeBreakdown counts module calls per source category
+func moduleSourceTypeBreakdown(modules []ModuleDetail) map[string]int {
+	breakdown := make(map[string]int)
+	for _, module := range modules {
+		breakdown[categorizeModuleSource(module.Source)] += module.Count
+	}
+	return breakdown
 }
 
 type ResourceType struct {
-	Type  string `json:"type"`
-	Count int    `json:"count"`
+	Type          string `json:"type"`
+	Count         int    `json:"count"`          // resource blocks
+	InstanceCount int    `json:"instance_count"` // instances after static count/for_each expansion
 }
 
 type UntaggedResource struct {
@@ -57,35 +194,158 @@ type UntaggedResource struct {
 	MissingTags  []string `json:"missing_tags"`
 }
 
+// InvalidTagValue is a tag whose value does not match its tag_value_rules pattern
+type InvalidTagValue struct {
+	Tag     string `json:"tag"`
+	Value   string `json:"value"`
+	Pattern string `json:"pattern"`
+}
+
+type InvalidTagResource struct {
+	ResourceType string            `json:"resource_type"`
+	Name         string            `json:"name"`
+	InvalidTags  []InvalidTagValue `json:"invalid_tags"`
+}
+
+// ProvisionerUsage records a provisioner block nested in a resource; provisioners
+// are a last resort in Terraform, so teams track them to drive usage down
+type ProvisionerUsage struct {
+	ResourceType string `json:"resource_type"`
+	Name         string `json:"name"`
+	Provisioner  string `json:"provisioner"` // local-exec, remote-exec, file, ...
+}
+
+// HardcodedRegionFinding is a region or availability zone written as a literal
+// in a resource instead of coming from a variable, local or data source
+type HardcodedRegionFinding struct {
+	ResourceType string `json:"resource_type"`
+	Name         string `json:"name"`
+	Attribute    string `json:"attribute"` // Dotted for nested blocks, e.g. placement.availability_zone
+	Value        string `json:"value"`     // Literal list elements are joined with ", "
+}
+
+// DeprecatedResource is a resource whose type is listed in compliance.deprecated_resources
+type DeprecatedResource struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Recommended string `json:"recommended,omitempty"` // Replacement type; empty when the type should simply be removed
+}
+
 type ResourceAnalysis struct {
-	TotalResourceCount      int                `json:"total_resource_count"`
-	UniqueResourceTypeCount int                `json:"unique_resource_type_count"`
-	ResourceTypes           []ResourceType     `json:"resource_types"`
-	UntaggedResources       []UntaggedResource `json:"untagged_resources"`
+	TotalResourceCount      int                  `json:"total_resource_count"`
+	EffectiveResourceCount  int                  `json:"effective_resource_count"` // Instances after count/for_each; see resourceInstanceCount
+	UniqueResourceTypeCount int                  `json:"unique_resource_type_count"`
+	ResourceTypes           []ResourceType       `json:"resource_types"`
+	UntaggedResources       []UntaggedResource   `json:"untagged_resources"`
+	InvalidTagResources     []InvalidTagResource `json:"invalid_tag_resources,omitempty"`
+	Provisioners            []ProvisionerUsage   `json:"provisioners,omitempty"`
+	DeprecatedResources     []DeprecatedResource `json:"deprecated_resources,omitempty"`
+	TaggableResourceCount   int                  `json:"taggable_resource_count"` // Resources checked for tags; exempt types are left out
+	TagCoveragePct          float64              `json:"tag_coverage_pct"`        // Taggable resources carrying every required tag; see tagCoveragePct
+	// Literal regions and availability zones in resource attributes; see findHardcodedRegions
+	HardcodedRegionFindings []HardcodedRegionFinding `json:"hardcoded_region_findings,omitempty"`
 }
 
 type VariableDefinition struct {
 	Name       string `json:"name"`
 	HasDefault bool   `json:"has_default"`
+	Type       string `json:"type,omitempty"` // Type constraint as written, e.g. list(string)
+	Sensitive  bool   `json:"sensitive"`
 }
 
 type VariableAnalysis struct {
 	DefinedVariables []VariableDefinition `json:"defined_variables"`
 }
 
+type OutputDetail struct {
+	Name      string `json:"name"`
+	Sensitive bool   `json:"sensitive"`
+}
+
 type OutputAnalysis struct {
-	OutputCount int      `json:"output_count"`
-	Outputs     []string `json:"outputs"`
+	OutputCount      int      `json:"output_count"`
+	Outputs          []string `json:"outputs"`
+	SensitiveOutputs []string `json:"sensitive_outputs,omitempty"` // Outputs marked sensitive = true
+}
+
+type DataSourceDetail struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type DataSourceAnalysis struct {
+	TotalCount      int                `json:"total_count"`
+	DataSourceTypes []ResourceType     `json:"data_source_types"`
+	DataSources     []DataSourceDetail `json:"data_sources"`
+}
+
+// MovedBlock is a moved block recording a refactor, with the from and to
+// addresses as written in the source
+type MovedBlock struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	File string `json:"file"`
+	Line int    `json:"line"`
+}
+
+type MovedAnalysis struct {
+	TotalCount  int          `json:"total_count"`
+	MovedBlocks []MovedBlock `json:"moved_blocks"`
 }
 
+// Repository classifications based on what the configuration manages
+const (
+	ClassificationManaged  = "managed"   // Declares at least one managed resource
+	ClassificationReadOnly = "read-only" // Only looks up data sources or exposes outputs
+	ClassificationEmpty    = "empty"     // Neither resources, data sources nor outputs
+)
+
 type RepositoryAnalysis struct {
-	RepositoryPath   string            `json:"repository_path"`
-	BackendConfig    *BackendConfig    `json:"backend_config"`
-	Providers        ProvidersAnalysis `json:"providers"`
-	Modules          ModulesAnalysis   `json:"modules"`
-	ResourceAnalysis ResourceAnalysis  `json:"resource_analysis"`
-	VariableAnalysis VariableAnalysis  `json:"variable_analysis"`
-	OutputAnalysis   OutputAnalysis    `json:"output_analysis"`
+	RepositoryPath   string             `json:"repository_path"`
+	BackendConfig    *BackendConfig     `json:"backend_config"`
+	RequiredVersion  *string            `json:"required_version"`
+	Providers        ProvidersAnalysis  `json:"providers"`
+	Modules          ModulesAnalysis    `json:"modules"`
+	ResourceAnalysis ResourceAnalysis   `json:"resource_analysis"`
+	VariableAnalysis VariableAnalysis   `json:"variable_analysis"`
+	OutputAnalysis   OutputAnalysis     `json:"output_analysis"`
+	DataSources      DataSourceAnalysis `json:"data_source_analysis"`
+	MovedAnalysis    MovedAnalysis      `json:"moved_analysis"`
+	Classification   string             `json:"classification,omitempty"` // Unset when --analyze skips resources
+	IsEmpty          bool               `json:"is_empty"`                 // No Terraform file was analyzed, unlike a repository whose files failed to parse
+	ComplexityScore  int                `json:"complexity_score"`         // Weighted item count, 0 when --analyze skips resources; see computeComplexity
+	FileTypes        FileTypeBreakdown  `json:"file_types"`
+	// Compliance policy results, present only when a policy is configured
+	ComplianceViolations []ComplianceViolation `json:"compliance_violations,omitempty"`
+	// Secret scan results, present only when secret scanning is enabled
+	SecretFindings []SecretFinding `json:"secret_findings,omitempty"`
+	// Committed .terraform directories; their contents are never analyzed
+	CommittedTerraformDirs []CommittedTerraformDirFinding `json:"committed_terraform_dirs,omitempty"`
+	// Pre-0.12 files the HCL2 parser rejects; they are skipped by every section
+	LegacyHCLFiles []LegacyHCLFinding `json:"legacy_hcl_files,omitempty"`
+	// Files that could not be read or parsed, so they contributed nothing
+	// above; --validate-only runs report them as failures
+	FileErrors []FileError `json:"file_errors,omitempty"`
+}
+
+// FileError records a file skipped because it could not be read or parsed,
+// which tells "no findings" apart from "not analyzed"
+type FileError struct {
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+// LegacyHCLFinding flags a file that fails to parse as HCL2 but reads like
+// HCL1-era Terraform, so it needs migrating rather than fixing
+type LegacyHCLFinding struct {
+	File   string `json:"file"`
+	Reason string `json:"reason"`
+}
+
+// CommittedTerraformDirFinding flags a .terraform directory (downloaded
+// providers and modules) checked into the repository
+type CommittedTerraformDirFinding struct {
+	Path string `json:"path"`
 }
 
 type AnalysisResult struct {
@@ -93,65 +353,244 @@ type AnalysisResult struct {
 	Organization string
 	Analysis     RepositoryAnalysis
 	Error        error
+	ErrorKind    string   // Category of Error for triage; see classifyAnalysisError
+	Warnings     []string // Non-fatal problems, e.g. a timeout downgraded by --timeout-as-warning
+}
+
+// ErrRepositoryTimeout marks repositories whose analysis was cut short by the processing timeout
+var ErrRepositoryTimeout = errors.New("repository analysis timed out")
+
+// ErrAnalysisPanic marks repositories whose analysis panicked and was recovered
+var ErrAnalysisPanic = errors.New("panic during processing")
+
+// Error kinds recorded on failed analysis results
+const (
+	ErrorKindTimeout      = "timeout"
+	ErrorKindCancelled    = "cancelled"
+	ErrorKindPathNotFound = "path_not_found"
+	ErrorKindPanic        = "panic"
+	ErrorKindAnalysis     = "analysis_error"
+)
+
+// classifyAnalysisError returns the ErrorKind for a repository's error, or
+// "" when it has none. A walk cut short by an interrupt is cancelled, not a timeout.
+func classifyAnalysisError(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrAnalysisPanic):
+		return ErrorKindPanic
+	case errors.Is(err, context.Canceled):
+		return ErrorKindCancelled
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, ErrRepositoryTimeout):
+		return ErrorKindTimeout
+	case errors.Is(err, fs.ErrNotExist):
+		return ErrorKindPathNotFound
+	default:
+		return ErrorKindAnalysis
+	}
+}
+
+// withErrorKind sets result.ErrorKind from result.Error
+func withErrorKind(result AnalysisResult) AnalysisResult {
+	result.ErrorKind = classifyAnalysisError(result.Error)
+	return result
 }
 
 type RawAnalysisData struct {
 	Backend           *BackendConfig
+	RequiredVersion   *string
 	Providers         []ProviderDetail
 	Modules           []ModuleDetail
 	ResourceTypes     []ResourceType
 	UntaggedResources []UntaggedResource
+	TaggableResources int
+	InvalidTags       []InvalidTagResource
+	Provisioners      []ProvisionerUsage
+	Deprecated        []DeprecatedResource
+	HardcodedRegions  []HardcodedRegionFinding
 	Variables         []VariableDefinition
-	Outputs           []string
+	Outputs           []OutputDetail
+	DataSources       []DataSourceDetail
+	MovedBlocks       []MovedBlock
+	LocalModuleCalls  map[string][]string // Local module sources by calling directory, relative to the repository
+	FileTypes         FileTypeBreakdown
+	FilesProcessed    int
+	Violations        []ComplianceViolation
+	SecretFindings    []SecretFinding
+	TerraformDirs     []CommittedTerraformDirFinding
+	LegacyHCLFiles    []LegacyHCLFinding
+	FileErrors        []FileError
+}
+
+// FileTypeBreakdown counts Terraform-related files by extension
+type FileTypeBreakdown struct {
+	TF     int `json:"tf"`
+	TFVars int `json:"tfvars"`
+	HCL    int `json:"hcl"`
+	TFJSON int `json:"tf_json"`
+}
+
+func (b *FileTypeBreakdown) record(path string) {
+	lower := strings.ToLower(path)
+	switch {
+	case strings.HasSuffix(lower, ".tf.json"):
+		b.TFJSON++
+	case strings.HasSuffix(lower, ".tf"):
+		b.TF++
+	case strings.HasSuffix(lower, ".tfvars"):
+		b.TFVars++
+	case strings.HasSuffix(lower, ".hcl"):
+		b.HCL++
+	}
 }
 
 type FileProcessingStats struct {
 	FilesProcessed int
 	FilesSkipped   int
 	FilesErrored   int
+	FileTypes      FileTypeBreakdown
 }
 
 // FileProcessingContext reduces function parameters
 type FileProcessingContext struct {
-	Data   *RawAnalysisData
-	Stats  *FileProcessingStats
-	Logger *slog.Logger
+	RepoPath  string
+	Data      *RawAnalysisData
+	Stats     *FileProcessingStats
+	Options   AnalysisOptions
+	Logger    *slog.Logger
+	ReadAhead *fileReadAhead
+	Ignore    *pathIgnorer // --ignore patterns plus the repository's .tfanalyzerignore
+}
+
+// Analysis sections that can be enabled independently
+const (
+	SectionBackend     = "backend"
+	SectionProviders   = "providers"
+	SectionModules     = "modules"
+	SectionResources   = "resources"
+	SectionVariables   = "variables"
+	SectionOutputs     = "outputs"
+	SectionDataSources = "data_sources"
+	SectionMoved       = "moved"
+	SectionSecrets     = "secrets"
+	SectionTags        = "tags"
+)
+
+// analysisSections are the --analyze values, in documentation order
+var analysisSections = []string{
+	SectionBackend, SectionProviders, SectionModules, SectionResources, SectionVariables,
+	SectionOutputs, SectionDataSources, SectionMoved, SectionTags, SectionSecrets,
 }
 
-var mandatoryTags = []string{"Environment", "Owner", "Project", "CostCenter"}
+// normalizeAnalysisSections lower-cases and de-duplicates --analyze values,
+// rejecting unknown sections; no values means every section runs
+func normalizeAnalysisSections(sections []string) ([]string, error) {
+	normalized := make([]string, 0, len(sections))
+	for _, section := range sections {
+		trimmed := strings.ToLower(strings.TrimSpace(section))
+		if !slices.Contains(analysisSections, trimmed) {
+			return nil, fmt.Errorf("invalid --analyze section %q (valid: %s)", section, strings.Join(analysisSections, ", "))
+		}
+		if !slices.Contains(normalized, trimmed) {
+			normalized = append(normalized, trimmed)
+		}
+	}
+	return normalized, nil
+}
 
-func isRelevantFile(path string) bool {
-	lower := strings.ToLower(path)
-	return strings.HasSuffix(lower, ".tf") ||
-		strings.HasSuffix(lower, ".tfvars") ||
-		strings.HasSuffix(lower, ".hcl")
+// AnalysisOptions controls which parts of the analysis run for each repository
+type AnalysisOptions struct {
+	Sections            map[string]bool     // Enabled sections; empty means all sections run
+	Policy              CompliancePolicy    // Compliance rules applied to each repository
+	ScanSecrets         bool                // Scan resource attributes for embedded credentials
+	ValidateOnly        bool                // Only check that files parse; skip all analysis
+	MandatoryTags       []string            // Tags every resource must carry; see requiredTags
+	TagsCaseInsensitive bool                // Match tag keys against required tags ignoring case
+	TagRules            map[string][]string // Required tags per resource type glob; see requiredTagsFor
+	FileReadConcurrency int                 // Files read ahead of the parser; 0 or 1 reads serially
+	Cache               *AnalysisCache      // Reuses analyses of unchanged commits; nil disables caching
+	IgnorePatterns      []string            // gitignore-style paths skipped in every repository; see pathIgnorer
+	ProviderSources     map[string]string   // Canonical sources for bare provider names; see canonicalProviderSource
+	IncludeExtensions   []string            // Extensions analyzed alongside the defaults; see isRelevantFile
+	DeprecatedResources map[string]string   // Recommended replacements keyed by deprecated resource type
 }
 
-func shouldSkipPath(path string) bool {
-	// Security: Skip version control directories
-	if strings.Contains(path, "/.git/") || strings.HasPrefix(path, ".git/") {
-		return true
-	}
+func defaultAnalysisOptions() AnalysisOptions {
+	return AnalysisOptions{}
+}
 
-	// Security: Skip dependency directories that may contain malicious code
-	if strings.Contains(path, "/node_modules/") {
-		return true
+func (o AnalysisOptions) includes(section string) bool {
+	return len(o.Sections) == 0 || o.Sections[section]
+}
+
+// requiredTags resolves the mandatory tag set: --mandatory-tags wins over the
+// compliance policy's required_tags, which wins over defaultMandatoryTags
+func (o AnalysisOptions) requiredTags() []string {
+	if len(o.MandatoryTags) > 0 {
+		return o.MandatoryTags
 	}
+	if len(o.Policy.RequiredTags) > 0 {
+		return o.Policy.RequiredTags
+	}
+	return defaultMandatoryTags
+}
 
-	if strings.Contains(path, "/vendor/") {
-		return true
+// requiredTagsFor resolves the tags a resource type must carry. An exact
+// TagRules entry wins, then the longest matching glob; types without a rule
+// fall back to requiredTags. An empty rule exempts the type from tagging.
+func (o AnalysisOptions) requiredTagsFor(resourceType string) []string {
+	if tags, ok := o.TagRules[resourceType]; ok {
+		return tags
 	}
 
-	// Security: Skip cache and temporary directories
-	if strings.Contains(path, "/__pycache__/") || strings.HasPrefix(path, "__pycache__/") {
-		return true
+	matching := lo.Filter(lo.Keys(o.TagRules), func(pattern string, _ int) bool {
+		matched, err := path.Match(pattern, resourceType)
+		return err == nil && matched
+	})
+	if len(matching) == 0 {
+		return o.requiredTags()
 	}
+	slices.SortFunc(matching, func(a, b string) int {
+		return cmp.Or(cmp.Compare(len(b), len(a)), cmp.Compare(a, b))
+	})
+	return o.TagRules[matching[0]]
+}
 
-	if strings.Contains(path, "/tmp/") || strings.HasPrefix(path, "tmp/") {
-		return true
+func validateTagRules(rules map[string][]string) error {
+	for pattern := range rules {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid tag_rules pattern %q: %w", pattern, err)
+		}
 	}
+	return nil
+}
+
+var defaultMandatoryTags = []string{"Environment", "Owner", "Project", "CostCenter"}
+
+var defaultRelevantExtensions = []string{".tf", terraformJSONExtension, ".tfvars", ".hcl"}
+
+// isRelevantFile reports whether path has one of the default extensions or
+// one of the lower-cased extraExtensions from --include-ext
+func isRelevantFile(path string, extraExtensions ...string) bool {
+	lower := strings.ToLower(path)
+	return lo.SomeBy(slices.Concat(defaultRelevantExtensions, extraExtensions), func(extension string) bool {
+		return strings.HasSuffix(lower, extension)
+	})
+}
+
+// skippedDirectories are never analyzed, wherever they appear in a path.
+// Security: version control, dependency directories that may contain
+// malicious code, and cache or temporary directories.
+var skippedDirectories = []string{".git", ".terraform", "node_modules", "vendor", "__pycache__", "tmp"}
 
-	return false
+// terraformWorkingDir holds providers and modules downloaded by terraform init
+const terraformWorkingDir = ".terraform"
+
+func shouldSkipPath(path string) bool {
+	return lo.SomeBy(skippedDirectories, func(dir string) bool {
+		return strings.Contains(path, "/"+dir+"/") || strings.HasPrefix(path, dir+"/")
+	})
 }
 
 func parseBackend(content string, filename string) *BackendConfig {
@@ -164,11 +603,38 @@ func parseBackend(content string, filename string) *BackendConfig {
 }
 
 func findBackendConfig(body *hclsyntax.Body) *BackendConfig {
-	for _, block := range body.Blocks {
-		if block.Type == "terraform" {
-			if config := findBackendInTerraformBlock(block); config != nil {
-				return config
-			}
+	for _, block := range terraformBlocks(body) {
+		if config := findBackendInTerraformBlock(block); config != nil {
+			return config
+		}
+	}
+	return nil
+}
+
+func terraformBlocks(body *hclsyntax.Body) []*hclsyntax.Block {
+	return lo.Filter(body.Blocks, func(block *hclsyntax.Block, _ int) bool {
+		return block.Type == "terraform"
+	})
+}
+
+func parseRequiredVersion(content string, filename string) *string {
+	body := parseHCLBody(content, filename)
+	if body == nil {
+		return nil
+	}
+
+	return findRequiredVersion(body)
+}
+
+func findRequiredVersion(body *hclsyntax.Body) *string {
+	for _, block := range terraformBlocks(body) {
+		attr, exists := block.Body.Attributes["required_version"]
+		if !exists {
+			continue
+		}
+		if versionVal, diags := attr.Expr.Value(nil); !diags.HasErrors() && versionVal.Type() == cty.String {
+			version := versionVal.AsString()
+			return &version
 		}
 	}
 	return nil
@@ -179,6 +645,9 @@ func findBackendInTerraformBlock(terraformBlock *hclsyntax.Block) *BackendConfig
 		if isBackendBlock(innerBlock) {
 			return createBackendConfig(innerBlock)
 		}
+		if innerBlock.Type == "cloud" {
+			return createCloudBackendConfig(innerBlock)
+		}
 	}
 	return nil
 }
@@ -191,25 +660,132 @@ func createBackendConfig(backendBlock *hclsyntax.Block) *BackendConfig {
 	backendType := backendBlock.Labels[0]
 	config := &BackendConfig{Type: &backendType}
 
-	if region := extractRegionFromBackend(backendBlock.Body); region != "" {
+	if attributes := extractBackendAttributes(backendBlock.Body); len(attributes) > 0 {
+		config.Attributes = attributes
+	}
+	if region := config.Attributes["region"]; region != "" {
 		config.Region = &region
 	}
+	if backendType == "s3" {
+		config.Security = extractS3BackendSecurity(backendBlock.Body)
+	}
+	if backendType == "remote" {
+		config.Workspace = extractBackendWorkspace(backendBlock.Body)
+	}
 
 	return config
 }
 
-func extractRegionFromBackend(body *hclsyntax.Body) string {
-	attr, exists := body.Attributes["region"]
-	if !exists {
-		return ""
+// createCloudBackendConfig records a terraform { cloud {} } block, which
+// replaces the remote backend for HCP Terraform, as a backend of type cloud
+func createCloudBackendConfig(cloudBlock *hclsyntax.Block) *BackendConfig {
+	backendType := "cloud"
+	config := &BackendConfig{Type: &backendType, Workspace: extractBackendWorkspace(cloudBlock.Body)}
+	if attributes := extractBackendAttributes(cloudBlock.Body); len(attributes) > 0 {
+		config.Attributes = attributes
 	}
+	return config
+}
 
-	regionVal, diags := attr.Expr.Value(nil)
-	if diags.HasErrors() || regionVal.Type() != cty.String {
-		return ""
+// extractBackendWorkspace reads the organization and the workspaces block
+// shared by cloud blocks and the remote backend; only literals are recorded
+func extractBackendWorkspace(body *hclsyntax.Body) *BackendWorkspace {
+	workspace := &BackendWorkspace{Organization: getStringAttribute(body, "organization")}
+	for _, block := range body.Blocks {
+		if block.Type != "workspaces" {
+			continue
+		}
+		workspace.Name = getStringAttribute(block.Body, "name")
+		workspace.Prefix = getStringAttribute(block.Body, "prefix")
+		workspace.Project = getStringAttribute(block.Body, "project")
+		// cloud blocks also accept tags as a map of tag keys to values
+		if attr, exists := block.Body.Attributes["tags"]; exists {
+			if value, diags := attr.Expr.Value(nil); !diags.HasErrors() && value.IsWhollyKnown() && !value.IsNull() && value.CanIterateElements() {
+				keyed := value.Type().IsObjectType() || value.Type().IsMapType()
+				for it := value.ElementIterator(); it.Next(); {
+					key, element := it.Element()
+					switch {
+					case element.Type() != cty.String || element.IsNull():
+					case keyed:
+						workspace.Tags = append(workspace.Tags, key.AsString()+"="+element.AsString())
+					default:
+						workspace.Tags = append(workspace.Tags, element.AsString())
+					}
+				}
+			}
+		}
+	}
+	return workspace
+}
+
+func extractS3BackendSecurity(body *hclsyntax.Body) *BackendSecurity {
+	_, hasLockTable := body.Attributes["dynamodb_table"]
+	_, hasKMSKey := body.Attributes["kms_key_id"]
+	return &BackendSecurity{
+		Encrypted:    isLiteralTrue(body, "encrypt"),
+		StateLocking: hasLockTable || isLiteralTrue(body, "use_lockfile"),
+		KMSKeyID:     hasKMSKey,
 	}
+}
+
+func isLiteralTrue(body *hclsyntax.Body, name string) bool {
+	attr, exists := body.Attributes[name]
+	if !exists {
+		return false
+	}
+	value, diags := attr.Expr.Value(nil)
+	return !diags.HasErrors() && value.Type() == cty.Bool && value.True()
+}
 
-	return regionVal.AsString()
+// backendSecurityWarnings describes the risks of an insecure S3 backend;
+// other backends and unset configurations produce none
+func backendSecurityWarnings(config *BackendConfig) []string {
+	if config == nil || config.Security == nil {
+		return nil
+	}
+	var warnings []string
+	if !config.Security.Encrypted {
+		warnings = append(warnings, "state is not encrypted at rest (set encrypt = true)")
+	}
+	if !config.Security.StateLocking {
+		warnings = append(warnings, "no state locking (set dynamodb_table or use_lockfile = true)")
+	}
+	return warnings
+}
+
+// redactedBackendValue replaces credentials set directly in a backend block
+const redactedBackendValue = "****"
+
+// backendCredentialAttributes hold secrets across the s3, azurerm, gcs,
+// remote and http backends
+var backendCredentialAttributes = map[string]bool{
+	"access_key":     true,
+	"secret_key":     true,
+	"token":          true,
+	"sas_token":      true,
+	"client_secret":  true,
+	"credentials":    true,
+	"password":       true,
+	"access_token":   true,
+	"encryption_key": true,
+}
+
+// extractBackendAttributes collects the backend's literal string attributes;
+// references, numbers and booleans are skipped
+func extractBackendAttributes(body *hclsyntax.Body) map[string]string {
+	attributes := make(map[string]string)
+	for name, attr := range body.Attributes {
+		value, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() || value.Type() != cty.String || !value.IsKnown() || value.IsNull() {
+			continue
+		}
+		if backendCredentialAttributes[name] {
+			attributes[name] = redactedBackendValue
+			continue
+		}
+		attributes[name] = value.AsString()
+	}
+	return attributes
 }
 
 func parseProviders(content string, filename string) []ProviderDetail {
@@ -226,19 +802,42 @@ func parseProviders(content string, filename string) []ProviderDetail {
 }
 
 func parseHCLBody(content string, filename string) *hclsyntax.Body {
+	body, _ := parseHCLBodyWithDiagnostics(content, filename)
+	return body
+}
+
+// parseHCLBodyWithDiagnostics returns a nil body alongside the diagnostics
+// when the content cannot be parsed
+func parseHCLBodyWithDiagnostics(content string, filename string) (*hclsyntax.Body, hcl.Diagnostics) {
+	body, _, diags := parseHCLSource(content, filename)
+	return body, diags
+}
+
+// parseHCLSource also returns the source the body's ranges point into, which
+// for a .tf.json file is its native-syntax rewrite rather than the JSON
+func parseHCLSource(content string, filename string) (*hclsyntax.Body, []byte, hcl.Diagnostics) {
+	source := []byte(content)
+	if isTerraformJSONFile(filename) && !isNativeJSONRewrite(content) {
+		native, diags := terraformJSONToNative(content, filename)
+		if diags.HasErrors() {
+			return nil, nil, diags
+		}
+		source = []byte(native)
+	}
+
 	parser := hclparse.NewParser()
-	file, diags := parser.ParseHCL([]byte(content), filename)
+	file, diags := parser.ParseHCL(source, filename)
 
 	if diags.HasErrors() {
-		return nil
+		return nil, nil, diags
 	}
 
 	body, ok := file.Body.(*hclsyntax.Body)
 	if !ok {
-		return nil
+		return nil, nil, diags
 	}
 
-	return body
+	return body, source, diags
 }
 
 func parseProviderBlocks(body *hclsyntax.Body, providerMap map[string]ProviderDetail) {
@@ -252,16 +851,19 @@ func parseProviderBlocks(body *hclsyntax.Body, providerMap map[string]ProviderDe
 func addProviderFromBlock(block *hclsyntax.Block, providerMap map[string]ProviderDetail) {
 	providerName := block.Labels[0]
 	regions := extractRegionsFromBlock(block.Body)
+	aliases := extractAliasesFromBlock(block.Body)
 	key := fmt.Sprintf("%s@", providerName)
 
 	if existing, exists := providerMap[key]; exists {
 		existing.Regions = lo.Union(existing.Regions, regions)
+		existing.Aliases = lo.Union(existing.Aliases, aliases)
 		providerMap[key] = existing
 	} else {
 		providerMap[key] = ProviderDetail{
 			Source:  providerName,
 			Version: "",
 			Regions: regions,
+			Aliases: aliases,
 		}
 	}
 }
@@ -276,6 +878,16 @@ func extractRegionsFromBlock(body *hclsyntax.Body) []string {
 	return regions
 }
 
+func extractAliasesFromBlock(body *hclsyntax.Body) []string {
+	var aliases []string
+	if attr, exists := body.Attributes["alias"]; exists {
+		if aliasVal, diags := attr.Expr.Value(nil); !diags.HasErrors() && aliasVal.Type() == cty.String {
+			aliases = append(aliases, aliasVal.AsString())
+		}
+	}
+	return aliases
+}
+
 func parseRequiredProviders(body *hclsyntax.Body, providerMap map[string]ProviderDetail) {
 	for _, block := range body.Blocks {
 		if block.Type == "terraform" {
@@ -334,6 +946,7 @@ func extractValueFromObjectItem(item *hclsyntax.ObjectConsItem) string {
 }
 
 func addProviderToMap(name, source, version string, providerMap map[string]ProviderDetail) {
+	version = normalizeVersionConstraint(version)
 	if source != "" {
 		mapKey := fmt.Sprintf("%s@%s", source, version)
 		providerMap[mapKey] = ProviderDetail{
@@ -351,90 +964,383 @@ func addProviderToMap(name, source, version string, providerMap map[string]Provi
 	}
 }
 
+// Constraint operators, longest first so "~>" is matched before ">"
+var versionConstraintOperators = []string{"~>", ">=", "<=", "!=", ">", "<", "="}
+
+// normalizeVersionConstraint gives cosmetically different but equal
+// constraints one canonical form, e.g. "~>5.0" and "~> 5.0" both become
+// "~> 5.0", and multi-part constraints are sorted so ">= 5.0, < 6.0" and
+// "<6.0,>=5.0" both become "< 6.0, >= 5.0".
+func normalizeVersionConstraint(version string) string {
+	var parts []string
+	for _, part := range strings.Split(version, ",") {
+		part = strings.Join(strings.Fields(part), "")
+		if part == "" {
+			continue
+		}
+		for _, operator := range versionConstraintOperators {
+			if rest, found := strings.CutPrefix(part, operator); found {
+				part = operator + " " + rest
+				break
+			}
+		}
+		parts = append(parts, part)
+	}
+
+	slices.Sort(parts)
+	return strings.Join(parts, ", ")
+}
+
+// acceptsAnyVersion lists normalized constraint parts that match every release
+var acceptsAnyVersion = []string{"*", ">= 0", ">= 0.0", ">= 0.0.0", "> 0", "> 0.0", "> 0.0.0"}
+
+// classifyProviderVersion sorts a normalized constraint into pinned ("~>",
+// "=" or exact versions only), range (comparison operators) or unpinned
+// (empty or matching any version). The reason is set when the constraint
+// should be flagged, which includes ranges without an upper bound.
+func classifyProviderVersion(version string) (string, string) {
+	if version == "" {
+		return VersionUnpinned, "no version constraint"
+	}
+
+	parts := strings.Split(version, ", ")
+	if lo.Some(parts, acceptsAnyVersion) {
+		return VersionUnpinned, "constraint accepts any version"
+	}
+
+	operator := func(part string) string {
+		for _, op := range versionConstraintOperators {
+			if strings.HasPrefix(part, op) {
+				return op
+			}
+		}
+		return "="
+	}
+	operators := lo.Map(parts, func(part string, _ int) string { return operator(part) })
+	if lo.Every([]string{"~>", "="}, operators) {
+		return VersionPinned, ""
+	}
+	if !lo.ContainsBy(operators, func(op string) bool { return op == "<" || op == "<=" || op == "~>" }) {
+		return VersionRange, "range has no upper bound"
+	}
+	return VersionRange, ""
+}
+
+// providerVersionIssues flags unpinned and loosely constrained providers. A
+// provider block without a version is not flagged when another declaration of
+// the same provider carries a constraint.
+func providerVersionIssues(providers []ProviderDetail) []ProviderVersionIssue {
+	constrained := providerSourcesMatching(providers, hasVersionConstraint)
+
+	var issues []ProviderVersionIssue
+	for _, provider := range providers {
+		classification, reason := classifyProviderVersion(provider.Version)
+		if reason == "" || (provider.Version == "" && constrained[canonicalProviderSource(provider.Source, nil)]) {
+			continue
+		}
+		issues = append(issues, ProviderVersionIssue{
+			Source:         provider.Source,
+			Version:        provider.Version,
+			Classification: classification,
+			Reason:         reason,
+		})
+	}
+	return issues
+}
+
 func parseModules(content string, filename string) []ModuleDetail {
 	body := parseHCLBody(content, filename)
 	if body == nil {
 		return []ModuleDetail{}
 	}
 
-	moduleMap := extractModuleSources(body)
-	return lo.MapToSlice(moduleMap, func(source string, count int) ModuleDetail {
-		return ModuleDetail{Source: source, Count: count}
-	})
+	return mergeModuleDetails(extractModules(body))
 }
 
-func extractModuleSources(body *hclsyntax.Body) map[string]int {
-	moduleMap := make(map[string]int)
+func extractModules(body *hclsyntax.Body) []ModuleDetail {
+	var modules []ModuleDetail
 	for _, block := range body.Blocks {
 		if block.Type == "module" && len(block.Labels) > 0 {
 			if source := getModuleSource(block.Body); source != "" {
-				moduleMap[source]++
+				count, known := staticInstanceCount(block.Body)
+				modules = append(modules, ModuleDetail{Source: source, Version: getModuleVersion(block.Body), Count: count, Dynamic: !known})
 			}
 		}
 	}
-	return moduleMap
+	return modules
+}
+
+// mergeModuleDetails sums calls of the same source and version constraint
+func mergeModuleDetails(modules []ModuleDetail) []ModuleDetail {
+	type moduleKey struct{ source, version string }
+	merged := make([]ModuleDetail, 0, len(modules))
+	indexByKey := make(map[moduleKey]int)
+	for _, module := range modules {
+		key := moduleKey{module.Source, module.Version}
+		if i, exists := indexByKey[key]; exists {
+			merged[i].Count += module.Count
+			merged[i].Dynamic = merged[i].Dynamic || module.Dynamic
+			continue
+		}
+		indexByKey[key] = len(merged)
+		module.SourceKind = categorizeModuleSource(module.Source)
+		merged = append(merged, module)
+	}
+	return merged
 }
 
 func getModuleSource(body *hclsyntax.Body) string {
-	if attr, exists := body.Attributes["source"]; exists {
-		if sourceVal, diags := attr.Expr.Value(nil); !diags.HasErrors() && sourceVal.Type() == cty.String {
-			return sourceVal.AsString()
+	return getStringAttribute(body, "source")
+}
+
+func getModuleVersion(body *hclsyntax.Body) string {
+	return getStringAttribute(body, "version")
+}
+
+func getStringAttribute(body *hclsyntax.Body, name string) string {
+	if attr, exists := body.Attributes[name]; exists {
+		if val, diags := attr.Expr.Value(nil); !diags.HasErrors() && val.Type() == cty.String {
+			return val.AsString()
 		}
 	}
 	return ""
 }
 
+// isModulePinned reports whether a registry module declares a version or a
+// git source pins a ref; other source kinds are not version-pinned this way
+func isModulePinned(module ModuleDetail) bool {
+	switch module.SourceKind {
+	case ModuleSourceRegistry:
+		return module.Version != ""
+	case ModuleSourceGit:
+		_, query, _ := strings.Cut(module.Source, "?")
+		return strings.Contains("&"+query, "&ref=")
+	default:
+		return true
+	}
+}
+
+// ResourceParseResult holds everything extracted from the resource blocks of a file
+type ResourceParseResult struct {
+	ResourceTypes       []ResourceType
+	UntaggedResources   []UntaggedResource
+	TaggableResources   int // Resources whose type requires at least one tag
+	InvalidTagResources []InvalidTagResource
+	Violations          []ComplianceViolation
+	Provisioners        []ProvisionerUsage
+	DeprecatedResources []DeprecatedResource
+	HardcodedRegions    []HardcodedRegionFinding
+}
+
 func parseResources(content string, filename string) ([]ResourceType, []UntaggedResource) {
+	result := parseResourcesWithOptions(content, filename, defaultAnalysisOptions())
+	return result.ResourceTypes, result.UntaggedResources
+}
+
+func parseResourcesWithOptions(content string, filename string, options AnalysisOptions) ResourceParseResult {
 	body := parseHCLBody(content, filename)
 	if body == nil {
-		return []ResourceType{}, []UntaggedResource{}
+		return ResourceParseResult{ResourceTypes: []ResourceType{}, UntaggedResources: []UntaggedResource{}}
 	}
 
-	resourceTypeMap, untaggedResources := processResourceBlocks(body)
-	resourceTypes := lo.MapToSlice(resourceTypeMap, func(resType string, count int) ResourceType {
-		return ResourceType{Type: resType, Count: count}
-	})
+	resourceTypeMap, result := processResourceBlocks(body, options)
+	result.ResourceTypes = lo.Values(resourceTypeMap)
 
-	return resourceTypes, untaggedResources
+	return result
 }
 
-func processResourceBlocks(body *hclsyntax.Body) (map[string]int, []UntaggedResource) {
-	resourceTypeMap := make(map[string]int)
-	var untaggedResources []UntaggedResource
+func processResourceBlocks(body *hclsyntax.Body, options AnalysisOptions) (map[string]ResourceType, ResourceParseResult) {
+	resourceTypeMap := make(map[string]ResourceType)
+	var result ResourceParseResult
+	checkTags, analyzeResources := options.includes(SectionTags), options.includes(SectionResources)
 
 	for _, block := range body.Blocks {
 		if block.Type == "resource" && len(block.Labels) >= 2 {
 			resourceType := block.Labels[0]
 			resourceName := block.Labels[1]
-			resourceTypeMap[resourceType]++
 
-			if untagged := checkResourceTags(block.Body, resourceType, resourceName); untagged != nil {
-				untaggedResources = append(untaggedResources, *untagged)
+			if checkTags {
+				if len(options.requiredTagsFor(resourceType)) > 0 {
+					result.TaggableResources++
+				}
+				untagged, invalid := checkResourceTags(block.Body, resourceType, resourceName, options)
+				if untagged != nil {
+					result.UntaggedResources = append(result.UntaggedResources, *untagged)
+				}
+				if invalid != nil {
+					result.InvalidTagResources = append(result.InvalidTagResources, *invalid)
+				}
+			}
+			if !analyzeResources {
+				continue
+			}
+
+			counted := resourceTypeMap[resourceType]
+			counted.Type = resourceType
+			counted.Count++
+			counted.InstanceCount += resourceInstanceCount(block.Body)
+			resourceTypeMap[resourceType] = counted
+			if violation := checkResourceNaming(resourceType, resourceName, options.Policy); violation != nil {
+				result.Violations = append(result.Violations, *violation)
+			}
+			result.Provisioners = append(result.Provisioners, findProvisioners(block.Body, resourceType, resourceName)...)
+			result.HardcodedRegions = append(result.HardcodedRegions, findHardcodedRegions(block.Body, "", resourceType, resourceName)...)
+			if recommended, deprecated := options.DeprecatedResources[resourceType]; deprecated {
+				result.DeprecatedResources = append(result.DeprecatedResources, DeprecatedResource{Type: resourceType, Name: resourceName, Recommended: recommended})
 			}
 		}
 	}
 
-	return resourceTypeMap, untaggedResources
+	return resourceTypeMap, result
+}
+
+func findProvisioners(body *hclsyntax.Body, resourceType, resourceName string) []ProvisionerUsage {
+	var provisioners []ProvisionerUsage
+	for _, block := range body.Blocks {
+		if block.Type == "provisioner" && len(block.Labels) > 0 {
+			provisioners = append(provisioners, ProvisionerUsage{ResourceType: resourceType, Name: resourceName, Provisioner: block.Labels[0]})
+		}
+	}
+	return provisioners
+}
+
+// regionAttributePattern matches attributes holding a region or availability
+// zone across providers: region and *_region (AWS), location (Azure) and
+// zone or zones (Google)
+var regionAttributePattern = regexp.MustCompile(`^(?:[a-z0-9_]+_)?regions?$|^(?:location|zones?|availability_zones?|azs)$`)
+
+// findHardcodedRegions reports region attributes, including those of nested
+// blocks, whose value is a literal string or a list containing literal
+// strings. References such as var.region or data.aws_availability_zones are
+// skipped, as are templates that interpolate one.
+func findHardcodedRegions(body *hclsyntax.Body, prefix, resourceType, resourceName string) []HardcodedRegionFinding {
+	var findings []HardcodedRegionFinding
+	for name, attr := range body.Attributes {
+		if !regionAttributePattern.MatchString(name) {
+			continue
+		}
+		if literals := literalRegionValues(attr.Expr); len(literals) > 0 {
+			findings = append(findings, HardcodedRegionFinding{
+				ResourceType: resourceType,
+				Name:         resourceName,
+				Attribute:    prefix + name,
+				Value:        strings.Join(literals, ", "),
+			})
+		}
+	}
+	for _, nested := range body.Blocks {
+		findings = append(findings, findHardcodedRegions(nested.Body, prefix+nested.Type+".", resourceType, resourceName)...)
+	}
+	// Attributes are a map, so order findings for stable reports
+	slices.SortFunc(findings, func(a, b HardcodedRegionFinding) int {
+		return strings.Compare(a.Attribute, b.Attribute)
+	})
+	return findings
+}
+
+func literalRegionValues(expr hclsyntax.Expression) []string {
+	if tuple, ok := expr.(*hclsyntax.TupleConsExpr); ok {
+		return lo.FilterMap(tuple.Exprs, func(element hclsyntax.Expression, _ int) (string, bool) {
+			value, ok := literalString(element)
+			return value, ok && strings.TrimSpace(value) != ""
+		})
+	}
+	if value, ok := literalString(expr); ok && strings.TrimSpace(value) != "" {
+		return []string{value}
+	}
+	return nil
 }
 
-func checkResourceTags(body *hclsyntax.Body, resourceType, resourceName string) *UntaggedResource {
-	tags := parseResourceTagsHCL(body)
-	missingTags := findMissingTags(tags)
+// resourceInstanceCount reads a literal count or the length of a literal
+// for_each collection, including toset([...]). Dynamic values such as
+// var.instance_count cannot be resolved statically and count as one instance.
+func resourceInstanceCount(body *hclsyntax.Body) int {
+	count, _ := staticInstanceCount(body)
+	return count
+}
 
-	if len(missingTags) > 0 {
-		return &UntaggedResource{
+// staticInstanceCount is resourceInstanceCount for any block taking count or
+// for_each; known is false when either is set to a value that is not literal.
+func staticInstanceCount(body *hclsyntax.Body) (count int, known bool) {
+	if attr, exists := body.Attributes["count"]; exists {
+		if value, diags := attr.Expr.Value(nil); !diags.HasErrors() && value.IsWhollyKnown() && !value.IsNull() &&
+			gocty.FromCtyValue(value, &count) == nil && count >= 0 {
+			return count, true
+		}
+		return 1, false
+	}
+	if attr, exists := body.Attributes["for_each"]; exists {
+		if length, ok := literalCollectionLength(attr.Expr); ok {
+			return length, true
+		}
+		return 1, false
+	}
+	return 1, true
+}
+
+func literalCollectionLength(expr hclsyntax.Expression) (int, bool) {
+	if call, ok := expr.(*hclsyntax.FunctionCallExpr); ok && call.Name == "toset" && len(call.Args) == 1 {
+		value, diags := call.Args[0].Value(nil)
+		if diags.HasErrors() || !value.IsWhollyKnown() || value.IsNull() || !value.CanIterateElements() {
+			return 0, false
+		}
+		// A set holds each distinct element once
+		distinct := make(map[string]bool)
+		for it := value.ElementIterator(); it.Next(); {
+			_, element := it.Element()
+			distinct[element.GoString()] = true
+		}
+		return len(distinct), true
+	}
+
+	value, diags := expr.Value(nil)
+	if diags.HasErrors() || !value.IsKnown() || value.IsNull() || !value.CanIterateElements() {
+		return 0, false
+	}
+	return value.LengthInt(), true
+}
+
+// checkResourceTags reports the required tags a resource is missing and the
+// tags whose values break the policy's tag_value_rules
+func checkResourceTags(body *hclsyntax.Body, resourceType, resourceName string, options AnalysisOptions) (*UntaggedResource, *InvalidTagResource) {
+	tags := parseResourceTagsHCL(body, resourceType)
+
+	// GCP label keys are always lowercase, so Owner in a policy matches an owner label
+	caseInsensitive := options.TagsCaseInsensitive || tagAttributeFor(resourceType) == labelTagAttribute
+
+	var untagged *UntaggedResource
+	if missingTags := findMissingTags(tags, options.requiredTagsFor(resourceType), caseInsensitive); len(missingTags) > 0 {
+		untagged = &UntaggedResource{
 			ResourceType: resourceType,
 			Name:         resourceName,
 			MissingTags:  missingTags,
 		}
 	}
-	return nil
+
+	var invalid *InvalidTagResource
+	if invalidTags := options.Policy.invalidTagValues(tags); len(invalidTags) > 0 {
+		invalid = &InvalidTagResource{
+			ResourceType: resourceType,
+			Name:         resourceName,
+			InvalidTags:  invalidTags,
+		}
+	}
+	return untagged, invalid
 }
 
-func findMissingTags(tags map[string]string) []string {
+// findMissingTags reports required tags that are absent or blank. With
+// caseInsensitive, keys on both sides are lowercased before comparison and
+// missing tags are still reported as configured.
+func findMissingTags(tags map[string]string, requiredTags []string, caseInsensitive bool) []string {
+	normalize := func(key string) string { return key }
+	if caseInsensitive {
+		normalize = strings.ToLower
+		tags = foldTagKeys(tags)
+	}
+
 	var missingTags []string
-	for _, requiredTag := range mandatoryTags {
-		value, exists := tags[requiredTag]
+	for _, requiredTag := range requiredTags {
+		value, exists := tags[normalize(requiredTag)]
 		// Tag is missing if it doesn't exist OR if the value is empty/whitespace-only
 		if !exists || strings.TrimSpace(value) == "" {
 			missingTags = append(missingTags, requiredTag)
@@ -443,10 +1349,45 @@ func findMissingTags(tags map[string]string) []string {
 	return missingTags
 }
 
-func parseResourceTagsHCL(body *hclsyntax.Body) map[string]string {
+// foldTagKeys lowercases tag keys, keeping a non-blank value when keys that
+// differ only in case collide
+func foldTagKeys(tags map[string]string) map[string]string {
+	folded := make(map[string]string, len(tags))
+	for key, value := range tags {
+		lower := strings.ToLower(key)
+		if strings.TrimSpace(folded[lower]) == "" {
+			folded[lower] = value
+		}
+	}
+	return folded
+}
+
+// defaultTagAttribute holds resource tags for AWS, Azure and most other providers
+const defaultTagAttribute = "tags"
+
+// labelTagAttribute holds GCP labels, whose keys GCP only allows in lowercase
+const labelTagAttribute = "labels"
+
+// tagAttributesByPrefix names the attribute holding tags for resource types
+// with a given prefix where it is not defaultTagAttribute
+var tagAttributesByPrefix = map[string]string{
+	"google_": labelTagAttribute,
+}
+
+// tagAttributeFor returns the attribute a resource type keeps its tags in
+func tagAttributeFor(resourceType string) string {
+	for prefix, attribute := range tagAttributesByPrefix {
+		if strings.HasPrefix(resourceType, prefix) {
+			return attribute
+		}
+	}
+	return defaultTagAttribute
+}
+
+func parseResourceTagsHCL(body *hclsyntax.Body, resourceType string) map[string]string {
 	tags := make(map[string]string)
 
-	if attr, exists := body.Attributes["tags"]; exists {
+	if attr, exists := body.Attributes[tagAttributeFor(resourceType)]; exists {
 		if tagsExpr, ok := attr.Expr.(*hclsyntax.ObjectConsExpr); ok {
 			for _, item := range tagsExpr.Items {
 				var key, value string
@@ -472,210 +1413,843 @@ func parseResourceTagsHCL(body *hclsyntax.Body) map[string]string {
 }
 
 func parseVariables(content string, filename string) []VariableDefinition {
-	body := parseHCLBody(content, filename)
+	body, source, _ := parseHCLSource(content, filename)
 	if body == nil {
 		return []VariableDefinition{}
 	}
 
-	return extractVariableDefinitions(body)
+	return extractVariableDefinitions(body, source)
 }
 
-func extractVariableDefinitions(body *hclsyntax.Body) []VariableDefinition {
+func extractVariableDefinitions(body *hclsyntax.Body, source []byte) []VariableDefinition {
 	var variables []VariableDefinition
 	for _, block := range body.Blocks {
 		if block.Type == "variable" && len(block.Labels) > 0 {
-			variables = append(variables, createVariableDefinition(block))
+			variables = append(variables, createVariableDefinition(block, source))
 		}
 	}
 	return variables
 }
 
-func createVariableDefinition(block *hclsyntax.Block) VariableDefinition {
+func createVariableDefinition(block *hclsyntax.Block, source []byte) VariableDefinition {
 	variableName := block.Labels[0]
 	_, hasDefault := block.Body.Attributes["default"]
 
 	return VariableDefinition{
 		Name:       variableName,
 		HasDefault: hasDefault,
+		Type:       extractVariableType(block.Body, source),
+		Sensitive:  isMarkedSensitive(block.Body),
+	}
+}
+
+// extractVariableType returns the type constraint's source text; type
+// expressions such as map(string) are not values, so they are not evaluated
+func extractVariableType(body *hclsyntax.Body, source []byte) string {
+	attr, exists := body.Attributes["type"]
+	if !exists {
+		return ""
 	}
+	return strings.Join(strings.Fields(string(attr.Expr.Range().SliceBytes(source))), " ")
 }
 
-func parseOutputs(content string, filename string) []string {
+func isMarkedSensitive(body *hclsyntax.Body) bool {
+	return isLiteralTrue(body, "sensitive")
+}
+
+func parseOutputDetails(content string, filename string) []OutputDetail {
 	body := parseHCLBody(content, filename)
 	if body == nil {
-		return []string{}
+		return []OutputDetail{}
 	}
 
-	return extractOutputNames(body)
+	return extractOutputDetails(body)
 }
 
-func extractOutputNames(body *hclsyntax.Body) []string {
-	var outputs []string
+func extractOutputDetails(body *hclsyntax.Body) []OutputDetail {
+	var outputs []OutputDetail
 	for _, block := range body.Blocks {
 		if block.Type == "output" && len(block.Labels) > 0 {
-			outputs = append(outputs, block.Labels[0])
+			outputs = append(outputs, OutputDetail{Name: block.Labels[0], Sensitive: isMarkedSensitive(block.Body)})
 		}
 	}
 	return outputs
 }
 
+func parseDataSources(content string, filename string) []DataSourceDetail {
+	body := parseHCLBody(content, filename)
+	if body == nil {
+		return []DataSourceDetail{}
+	}
+
+	return extractDataSources(body)
+}
+
+func extractDataSources(body *hclsyntax.Body) []DataSourceDetail {
+	var dataSources []DataSourceDetail
+	for _, block := range body.Blocks {
+		if block.Type == "data" && len(block.Labels) >= 2 {
+			dataSources = append(dataSources, DataSourceDetail{Type: block.Labels[0], Name: block.Labels[1]})
+		}
+	}
+	return dataSources
+}
+
+func parseMovedBlocks(content string, filename string) []MovedBlock {
+	body, source, _ := parseHCLSource(content, filename)
+	if body == nil {
+		return []MovedBlock{}
+	}
+
+	return extractMovedBlocks(body, source, filename)
+}
+
+func extractMovedBlocks(body *hclsyntax.Body, source []byte, filename string) []MovedBlock {
+	var movedBlocks []MovedBlock
+	for _, block := range body.Blocks {
+		if block.Type != "moved" {
+			continue
+		}
+		movedBlocks = append(movedBlocks, MovedBlock{
+			From: attributeSourceText(block.Body, "from", source),
+			To:   attributeSourceText(block.Body, "to", source),
+			File: filename,
+			Line: sourceLine(filename, block.DefRange().Start.Line),
+		})
+	}
+	return movedBlocks
+}
+
+// attributeSourceText returns an attribute's expression exactly as written,
+// since moved addresses are references rather than evaluable values
+func attributeSourceText(body *hclsyntax.Body, name string, source []byte) string {
+	attr, exists := body.Attributes[name]
+	if !exists {
+		return ""
+	}
+	return strings.TrimSpace(string(attr.Expr.Range().SliceBytes(source)))
+}
+
 func loadFileContent(path string) ([]byte, error) {
 	return script.File(path).Bytes()
 }
 
+// fileRead is a file whose content is being loaded ahead of the parser
+type fileRead struct {
+	path    string
+	content []byte
+	err     error
+	done    chan struct{}
+}
+
+// fileReadAhead keeps up to window file reads in flight while the walk
+// continues and hands them back in walk order, so I/O latency overlaps with
+// parsing while parse results stay identical to a serial walk
+type fileReadAhead struct {
+	window  int
+	read    func(path string) ([]byte, error)
+	pending []*fileRead
+}
+
+func newFileReadAhead(concurrency int, read func(path string) ([]byte, error)) *fileReadAhead {
+	return &fileReadAhead{window: max(concurrency, 1), read: read}
+}
+
+// submit starts reading path and returns the oldest read once the window is
+// full, blocking until that read completes; ok is false while it is filling
+func (r *fileReadAhead) submit(path string) (*fileRead, bool) {
+	read := &fileRead{path: path, done: make(chan struct{})}
+	if r.window == 1 {
+		read.content, read.err = r.read(path)
+		close(read.done)
+		return read, true
+	}
+
+	go func() {
+		defer close(read.done)
+		read.content, read.err = r.read(path)
+	}()
+	r.pending = append(r.pending, read)
+	if len(r.pending) < r.window {
+		return nil, false
+	}
+	return r.next(), true
+}
+
+func (r *fileReadAhead) next() *fileRead {
+	read := r.pending[0]
+	r.pending = r.pending[1:]
+	<-read.done
+	return read
+}
+
+// drain waits for every read still in flight, in walk order
+func (r *fileReadAhead) drain() []*fileRead {
+	reads := make([]*fileRead, 0, len(r.pending))
+	for len(r.pending) > 0 {
+		reads = append(reads, r.next())
+	}
+	return reads
+}
+
+var (
+	utf8BOM    = []byte{0xEF, 0xBB, 0xBF}
+	utf16LEBOM = []byte{0xFF, 0xFE}
+	utf16BEBOM = []byte{0xFE, 0xFF}
+)
+
+// normalizeFileEncoding strips a UTF-8 BOM and transcodes UTF-16 content to UTF-8.
+// The returned encoding name is empty when the content was left untouched.
+func normalizeFileEncoding(content []byte) ([]byte, string) {
+	switch {
+	case bytes.HasPrefix(content, utf8BOM):
+		return content[len(utf8BOM):], "utf-8-bom"
+	case bytes.HasPrefix(content, utf16LEBOM):
+		return decodeUTF16(content[len(utf16LEBOM):], binary.LittleEndian), "utf-16le"
+	case bytes.HasPrefix(content, utf16BEBOM):
+		return decodeUTF16(content[len(utf16BEBOM):], binary.BigEndian), "utf-16be"
+	}
+	return content, ""
+}
+
+func decodeUTF16(content []byte, order binary.ByteOrder) []byte {
+	units := make([]uint16, 0, len(content)/2)
+	for i := 0; i+1 < len(content); i += 2 {
+		units = append(units, order.Uint16(content[i:]))
+	}
+	return []byte(string(utf16.Decode(units)))
+}
+
 func analyzeRepositoryWithRecovery(repoPath string, logger *slog.Logger) (RepositoryAnalysis, error) {
-	rawData, err := processRepositoryFiles(repoPath, logger)
-	if err != nil {
+	return analyzeRepositoryWithOptions(repoPath, defaultAnalysisOptions(), logger)
+}
+
+func analyzeRepositoryWithOptions(repoPath string, options AnalysisOptions, logger *slog.Logger) (RepositoryAnalysis, error) {
+	return analyzeRepositoryWithContext(context.Background(), repoPath, options, logger)
+}
+
+// analyzeRepositoryWithContext stops walking the repository once ctx is done;
+// on timeout the analysis of the files read so far is returned with the error
+func analyzeRepositoryWithContext(ctx context.Context, repoPath string, options AnalysisOptions, logger *slog.Logger) (RepositoryAnalysis, error) {
+	rawData, err := processRepositoryFiles(ctx, repoPath, options, logger)
+	if err != nil && !errors.Is(err, ErrRepositoryTimeout) {
 		return RepositoryAnalysis{RepositoryPath: repoPath}, err
 	}
 
-	analysis := aggregateAnalysisData(rawData)
+	if options.ValidateOnly {
+		return RepositoryAnalysis{RepositoryPath: repoPath, LegacyHCLFiles: rawData.LegacyHCLFiles, FileErrors: rawData.FileErrors}, err
+	}
+
+	analysis := aggregateAnalysisData(rawData, options)
 	analysis.RepositoryPath = repoPath
+	// A walk cut short by the timeout may simply not have reached the Terraform files
+	analysis.IsEmpty = rawData.FilesProcessed == 0 && err == nil
+	analysis.Modules.LocalModuleTree, analysis.Modules.LocalModuleDepth = localModuleTree(repoPath, rawData.LocalModuleCalls)
+	analysis.ComplianceViolations = append(rawData.Violations, evaluateCompliancePolicy(analysis, options.Policy)...)
 
-	return analysis, nil
+	return analysis, err
 }
 
-func processRepositoryFiles(repoPath string, logger *slog.Logger) (RawAnalysisData, error) {
+func processRepositoryFiles(ctx context.Context, repoPath string, options AnalysisOptions, logger *slog.Logger) (RawAnalysisData, error) {
 	data := RawAnalysisData{}
 	stats := FileProcessingStats{}
+	ignorer, ignoreErr := loadRepositoryIgnorer(repoPath, options.IgnorePatterns)
+	if ignoreErr != nil {
+		logger.Warn("Ignoring unreadable ignore file", "path", repoPath, "error", ignoreErr)
+	}
+	fileCtx := FileProcessingContext{
+		RepoPath:  repoPath,
+		Data:      &data,
+		Stats:     &stats,
+		Options:   options,
+		Logger:    logger,
+		ReadAhead: newFileReadAhead(options.FileReadConcurrency, loadFileContent),
+		Ignore:    ignorer,
+	}
 
 	err := filepath.WalkDir(repoPath, func(path string, d fs.DirEntry, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			// Only a deadline is a timeout; an interrupt must not be downgraded to a warning
+			if !isTimeoutError(ctxErr) {
+				return fmt.Errorf("analysis cancelled after %d files: %w", stats.FilesProcessed, ctxErr)
+			}
+			return fmt.Errorf("%w after %d files: %w", ErrRepositoryTimeout, stats.FilesProcessed, ctxErr)
+		}
 		if err != nil {
 			logger.Debug("Error accessing path", "path", path, "error", err)
 			return err
 		}
-
-		ctx := FileProcessingContext{
-			Data:   &data,
-			Stats:  &stats,
-			Logger: logger,
-		}
-		return processFileEntry(path, d, ctx)
+		return processFileEntry(path, d, fileCtx)
 	})
+	if err == nil {
+		for _, read := range fileCtx.ReadAhead.drain() {
+			processFileRead(read, fileCtx)
+		}
+	}
 
+	data.FileTypes = stats.FileTypes
+	data.FilesProcessed = stats.FilesProcessed
 	logFileProcessingStats(stats, logger)
 	return data, err
 }
 
 func processFileEntry(path string, d fs.DirEntry, ctx FileProcessingContext) error {
-	if d.IsDir() || shouldSkipPath(path) {
+	if ctx.Ignore.Ignored(filepath.ToSlash(relativeRepoPath(ctx.RepoPath, path)), d.IsDir()) {
+		if d.IsDir() {
+			return filepath.SkipDir
+		}
+		return nil
+	}
+	if d.IsDir() && d.Name() == terraformWorkingDir {
+		ctx.Data.TerraformDirs = append(ctx.Data.TerraformDirs, CommittedTerraformDirFinding{Path: relativeRepoPath(ctx.RepoPath, path)})
+		return filepath.SkipDir
+	}
+	// Only the part below the repository root is matched, so clones under a
+	// parent such as /tmp/ or /vendor/ are still analyzed
+	if d.IsDir() || shouldSkipPath(filepath.ToSlash(relativeRepoPath(ctx.RepoPath, path))) {
 		return nil
 	}
 
-	if !isRelevantFile(path) {
+	ctx.Stats.FileTypes.record(path)
+	if !isRelevantFile(path, ctx.Options.IncludeExtensions...) {
 		ctx.Stats.FilesSkipped++
 		return nil
 	}
 
-	content, readErr := loadFileContent(path)
-	if readErr != nil {
-		ctx.Logger.Debug("Failed to read file, skipping", "path", path, "error", readErr)
-		ctx.Stats.FilesErrored++
-		return nil
+	if read, ok := ctx.ReadAhead.submit(path); ok {
+		processFileRead(read, ctx)
+	}
+	return nil
+}
+
+func processFileRead(read *fileRead, ctx FileProcessingContext) {
+	if read.err != nil {
+		ctx.Logger.Debug("Failed to read file, skipping", "path", read.path, "error", read.err)
+		recordFileError(read.path, "read error: "+read.err.Error(), ctx)
+		return
+	}
+
+	content, encoding := normalizeFileEncoding(read.content)
+	if encoding != "" {
+		ctx.Logger.Debug("Normalized file encoding", "path", read.path, "encoding", encoding)
 	}
 
-	parseFileContentWithContext(string(content), path, ctx)
+	parseFileContentWithContext(string(content), read.path, ctx)
 	ctx.Stats.FilesProcessed++
-	return nil
 }
 
 func parseFileContentWithContext(content, path string, ctx FileProcessingContext) {
-	parseBackendData(content, path, ctx.Data, ctx.Logger)
-	parseProviderData(content, path, ctx.Data, ctx.Logger)
-	parseModuleData(content, path, ctx.Data, ctx.Logger)
-	parseResourceData(content, path, ctx.Data, ctx.Logger)
-	parseVariableData(content, path, ctx.Data, ctx.Logger)
-	parseOutputData(content, path, ctx.Data, ctx.Logger)
+	if ctx.Options.ValidateOnly {
+		validateFileContent(content, path, ctx)
+		return
+	}
+	// Convert .tf.json once; every section parser below reads the rewrite
+	if isTerraformJSONFile(path) {
+		native, diags := terraformJSONToNative(content, path)
+		if diags.HasErrors() {
+			ctx.Logger.Debug("Failed to parse file, skipping", "path", path, "error", diags.Error())
+			recordFileError(path, parseFailureReason(diags), ctx)
+			return
+		}
+		content = native
+	}
+
+	_, diags := parseHCLBodyWithDiagnostics(content, path)
+	if recordLegacyHCLDiagnostics(content, path, diags, ctx) {
+		return
+	}
+	if diags.HasErrors() {
+		ctx.Logger.Debug("Failed to parse file, skipping", "path", path, "error", diags.Error())
+		recordFileError(path, parseFailureReason(diags), ctx)
+		return
+	}
+
+	sectionParsers := []struct {
+		section string
+		parse   func(content, path string, ctx FileProcessingContext)
+	}{
+		{SectionBackend, parseBackendData},
+		{SectionBackend, parseRequiredVersionData},
+		{SectionProviders, parseProviderData},
+		{SectionModules, parseModuleData},
+		{SectionVariables, parseVariableData},
+		{SectionOutputs, parseOutputData},
+		{SectionDataSources, parseDataSourceData},
+		{SectionMoved, parseMovedData},
+	}
+
+	for _, sectionParser := range sectionParsers {
+		if ctx.Options.includes(sectionParser.section) {
+			sectionParser.parse(content, path, ctx)
+		}
+	}
+
+	// Tag checks read resource blocks, so either section parses them
+	if ctx.Options.includes(SectionResources) || ctx.Options.includes(SectionTags) {
+		parseResourceData(content, path, ctx)
+	}
+
+	if ctx.Options.ScanSecrets && ctx.Options.includes(SectionSecrets) {
+		parseSecretData(content, path, ctx)
+	}
 }
 
-func parseBackendData(content, path string, data *RawAnalysisData, logger *slog.Logger) {
-	if data.Backend == nil {
-		if parsedBackend := parseBackendSafely(content, path, logger); parsedBackend != nil {
-			data.Backend = parsedBackend
+func parseBackendData(content, path string, ctx FileProcessingContext) {
+	if ctx.Data.Backend == nil {
+		if parsedBackend := parseBackendSafely(content, path, ctx.Logger); parsedBackend != nil {
+			ctx.Data.Backend = parsedBackend
 		}
 	}
 }
 
-func parseProviderData(content, path string, data *RawAnalysisData, logger *slog.Logger) {
-	if providers := parseProvidersSafely(content, path, logger); len(providers) > 0 {
-		data.Providers = append(data.Providers, providers...)
+func parseRequiredVersionData(content, path string, ctx FileProcessingContext) {
+	if ctx.Data.RequiredVersion == nil {
+		ctx.Data.RequiredVersion = parseRequiredVersionSafely(content, path, ctx.Logger)
+	}
+}
+
+func parseProviderData(content, path string, ctx FileProcessingContext) {
+	if providers := parseProvidersSafely(content, path, ctx.Logger); len(providers) > 0 {
+		ctx.Data.Providers = append(ctx.Data.Providers, providers...)
+	}
+}
+
+func parseModuleData(content, path string, ctx FileProcessingContext) {
+	if modules := parseModulesSafely(content, path, ctx.Logger); len(modules) > 0 {
+		ctx.Data.Modules = append(ctx.Data.Modules, modules...)
+		recordLocalModuleCalls(modules, filepath.Dir(relativeRepoPath(ctx.RepoPath, path)), ctx.Data)
+	}
+}
+
+func recordLocalModuleCalls(modules []ModuleDetail, dir string, data *RawAnalysisData) {
+	for _, module := range modules {
+		if module.SourceKind != ModuleSourceLocal {
+			continue
+		}
+		if data.LocalModuleCalls == nil {
+			data.LocalModuleCalls = make(map[string][]string)
+		}
+		data.LocalModuleCalls[dir] = lo.Union(data.LocalModuleCalls[dir], []string{module.Source})
+	}
+}
+
+func parseResourceData(content, path string, ctx FileProcessingContext) {
+	result := parseResourcesWithOptionsSafely(content, path, ctx.Options, ctx.Logger)
+	ctx.Data.ResourceTypes = append(ctx.Data.ResourceTypes, result.ResourceTypes...)
+	ctx.Data.UntaggedResources = append(ctx.Data.UntaggedResources, result.UntaggedResources...)
+	ctx.Data.TaggableResources += result.TaggableResources
+	ctx.Data.InvalidTags = append(ctx.Data.InvalidTags, result.InvalidTagResources...)
+	ctx.Data.Provisioners = append(ctx.Data.Provisioners, result.Provisioners...)
+	ctx.Data.Deprecated = append(ctx.Data.Deprecated, result.DeprecatedResources...)
+	ctx.Data.HardcodedRegions = append(ctx.Data.HardcodedRegions, result.HardcodedRegions...)
+	ctx.Data.Violations = append(ctx.Data.Violations, result.Violations...)
+}
+
+func parseVariableData(content, path string, ctx FileProcessingContext) {
+	if variables := parseVariablesSafely(content, path, ctx.Logger); len(variables) > 0 {
+		ctx.Data.Variables = append(ctx.Data.Variables, variables...)
+	}
+}
+
+func parseOutputData(content, path string, ctx FileProcessingContext) {
+	if outputs := parseOutputDetailsSafely(content, path, ctx.Logger); len(outputs) > 0 {
+		ctx.Data.Outputs = append(ctx.Data.Outputs, outputs...)
+	}
+}
+
+func parseDataSourceData(content, path string, ctx FileProcessingContext) {
+	if dataSources := parseDataSourcesSafely(content, path, ctx.Logger); len(dataSources) > 0 {
+		ctx.Data.DataSources = append(ctx.Data.DataSources, dataSources...)
+	}
+}
+
+func parseMovedData(content, path string, ctx FileProcessingContext) {
+	if movedBlocks := parseMovedBlocksSafely(content, relativeRepoPath(ctx.RepoPath, path), ctx.Logger); len(movedBlocks) > 0 {
+		ctx.Data.MovedBlocks = append(ctx.Data.MovedBlocks, movedBlocks...)
 	}
 }
 
-func parseModuleData(content, path string, data *RawAnalysisData, logger *slog.Logger) {
-	if modules := parseModulesSafely(content, path, logger); len(modules) > 0 {
-		data.Modules = append(data.Modules, modules...)
+func parseSecretData(content, path string, ctx FileProcessingContext) {
+	if findings := scanSecretsSafely(content, relativeRepoPath(ctx.RepoPath, path), ctx.Logger); len(findings) > 0 {
+		ctx.Data.SecretFindings = append(ctx.Data.SecretFindings, findings...)
 	}
 }
 
-func parseResourceData(content, path string, data *RawAnalysisData, logger *slog.Logger) {
-	resourceTypes, untaggedResources := parseResourcesSafely(content, path, logger)
-	data.ResourceTypes = append(data.ResourceTypes, resourceTypes...)
-	data.UntaggedResources = append(data.UntaggedResources, untaggedResources...)
+func validateFileContent(content, path string, ctx FileProcessingContext) {
+	_, diags := parseHCLBodyWithDiagnostics(content, path)
+	if !diags.HasErrors() || recordLegacyHCLDiagnostics(content, path, diags, ctx) {
+		return
+	}
+	// Validation prints every diagnostic rather than the first one
+	recordFileError(path, diags.Error(), ctx)
+}
+
+func recordFileError(path, reason string, ctx FileProcessingContext) {
+	ctx.Stats.FilesErrored++
+	ctx.Data.FileErrors = append(ctx.Data.FileErrors, FileError{
+		Path:   relativeRepoPath(ctx.RepoPath, path),
+		Reason: reason,
+	})
+}
+
+// parseFailureReason summarizes the first error for reports; the full
+// diagnostics are logged at debug level
+func parseFailureReason(diags hcl.Diagnostics) string {
+	for _, diag := range diags {
+		if diag.Severity != hcl.DiagError {
+			continue
+		}
+		if diag.Subject != nil {
+			return fmt.Sprintf("parse error at line %d: %s", diag.Subject.Start.Line, diag.Summary)
+		}
+		return "parse error: " + diag.Summary
+	}
+	return "parse error"
 }
 
-func parseVariableData(content, path string, data *RawAnalysisData, logger *slog.Logger) {
-	if variables := parseVariablesSafely(content, path, logger); len(variables) > 0 {
-		data.Variables = append(data.Variables, variables...)
+func recordLegacyHCLDiagnostics(content, path string, diags hcl.Diagnostics, ctx FileProcessingContext) bool {
+	// HCL1 had no JSON counterpart to migrate; errors in a .tf.json file or
+	// its native rewrite are file errors
+	if isTerraformJSONFile(path) {
+		return false
+	}
+	reason, legacy := detectLegacyHCL(content, diags)
+	if legacy {
+		ctx.Logger.Debug("Skipping legacy HCL1 file", "path", path, "reason", reason)
+		ctx.Data.LegacyHCLFiles = append(ctx.Data.LegacyHCLFiles, LegacyHCLFinding{
+			File:   relativeRepoPath(ctx.RepoPath, path),
+			Reason: reason,
+		})
 	}
+	return legacy
+}
+
+var (
+	terraformBlockPattern      = regexp.MustCompile(`(?m)^\s*(resource|data|variable|output|provider|module|terraform|locals)\s*["{]`)
+	legacyInterpolationPattern = regexp.MustCompile(`"\$\{[^"]*\}"`)
+)
+
+// legacyHCLDiagnostics maps HCL2 errors for syntax only HCL1 accepted to a reason
+var legacyHCLDiagnostics = map[string]string{
+	"Invalid argument name":     "quoted argument names",
+	"Invalid multi-line string": "multi-line quoted strings",
 }
 
-func parseOutputData(content, path string, data *RawAnalysisData, logger *slog.Logger) {
-	if outputs := parseOutputsSafely(content, path, logger); len(outputs) > 0 {
-		data.Outputs = append(data.Outputs, outputs...)
+// detectLegacyHCL reports whether content that failed to parse looks like
+// HCL1 Terraform: block keywords plus an HCL1-only syntax error. Pre-0.12
+// "${...}" interpolation is still valid HCL2, so it only adds to the reason;
+// on its own it would hide genuine syntax errors from FileErrors.
+func detectLegacyHCL(content string, diags hcl.Diagnostics) (string, bool) {
+	if !diags.HasErrors() || !terraformBlockPattern.MatchString(content) {
+		return "", false
+	}
+	var reasons []string
+	for _, diag := range diags {
+		if reason, ok := legacyHCLDiagnostics[diag.Summary]; ok && !slices.Contains(reasons, reason) {
+			reasons = append(reasons, reason)
+		}
 	}
+	if len(reasons) == 0 {
+		return "", false
+	}
+	if legacyInterpolationPattern.MatchString(content) {
+		reasons = append(reasons, "interpolation-only \"${...}\" expressions")
+	}
+	return strings.Join(reasons, ", "), true
 }
 
-func aggregateAnalysisData(data RawAnalysisData) RepositoryAnalysis {
-	return RepositoryAnalysis{
-		BackendConfig:    data.Backend,
-		Providers:        aggregateProviders(data.Providers),
-		Modules:          aggregateModules(data.Modules),
-		ResourceAnalysis: aggregateResources(data.ResourceTypes, data.UntaggedResources),
-		VariableAnalysis: VariableAnalysis{DefinedVariables: data.Variables},
-		OutputAnalysis:   OutputAnalysis{OutputCount: len(data.Outputs), Outputs: data.Outputs},
+// relativeRepoPath reports files relative to the repository root when possible
+func relativeRepoPath(repoPath, path string) string {
+	if rel, err := filepath.Rel(repoPath, path); err == nil {
+		return rel
+	}
+	return path
+}
+
+func aggregateAnalysisData(data RawAnalysisData, options AnalysisOptions) RepositoryAnalysis {
+	analysis := RepositoryAnalysis{
+		BackendConfig:          data.Backend,
+		RequiredVersion:        data.RequiredVersion,
+		Providers:              aggregateProviders(data.Providers, options.ProviderSources),
+		Modules:                aggregateModules(data.Modules),
+		ResourceAnalysis:       aggregateResources(data.ResourceTypes, data.UntaggedResources),
+		VariableAnalysis:       VariableAnalysis{DefinedVariables: data.Variables},
+		OutputAnalysis:         aggregateOutputs(data.Outputs),
+		DataSources:            aggregateDataSources(data.DataSources),
+		MovedAnalysis:          MovedAnalysis{TotalCount: len(data.MovedBlocks), MovedBlocks: data.MovedBlocks},
+		FileTypes:              data.FileTypes,
+		SecretFindings:         data.SecretFindings,
+		CommittedTerraformDirs: data.TerraformDirs,
+		LegacyHCLFiles:         data.LegacyHCLFiles,
+		FileErrors:             data.FileErrors,
+	}
+	analysis.ResourceAnalysis.InvalidTagResources = data.InvalidTags
+	analysis.ResourceAnalysis.TaggableResourceCount = data.TaggableResources
+	analysis.ResourceAnalysis.TagCoveragePct = tagCoveragePct(data.TaggableResources, len(data.UntaggedResources))
+	analysis.ResourceAnalysis.Provisioners = data.Provisioners
+	analysis.ResourceAnalysis.DeprecatedResources = data.Deprecated
+	analysis.ResourceAnalysis.HardcodedRegionFindings = data.HardcodedRegions
+	// Both are derived from the resource count, which only the resources section gathers
+	if options.includes(SectionResources) {
+		analysis.Classification = classifyRepository(analysis)
+		analysis.ComplexityScore = computeComplexity(analysis)
 	}
+	return analysis
 }
 
-func aggregateProviders(providers []ProviderDetail) ProvidersAnalysis {
-	uniqueProviders := lo.UniqBy(providers, func(p ProviderDetail) string {
-		return fmt.Sprintf("%s@%s", p.Source, p.Version)
+func aggregateOutputs(outputs []OutputDetail) OutputAnalysis {
+	names := lo.Map(outputs, func(output OutputDetail, _ int) string {
+		return output.Name
 	})
+	sensitive := lo.FilterMap(outputs, func(output OutputDetail, _ int) (string, bool) {
+		return output.Name, output.Sensitive
+	})
+
+	return OutputAnalysis{OutputCount: len(outputs), Outputs: names, SensitiveOutputs: sensitive}
+}
+
+func aggregateDataSources(dataSources []DataSourceDetail) DataSourceAnalysis {
+	typeCounts := lo.CountValuesBy(dataSources, func(d DataSourceDetail) string {
+		return d.Type
+	})
+
+	return DataSourceAnalysis{
+		TotalCount: len(dataSources),
+		DataSourceTypes: lo.MapToSlice(typeCounts, func(dataType string, count int) ResourceType {
+			return ResourceType{Type: dataType, Count: count}
+		}),
+		DataSources: dataSources,
+	}
+}
+
+// classifyRepository distinguishes repositories that create infrastructure
+// from pure lookup repositories and from those with nothing to analyze
+func classifyRepository(analysis RepositoryAnalysis) string {
+	switch {
+	case analysis.ResourceAnalysis.TotalResourceCount > 0:
+		return ClassificationManaged
+	case analysis.DataSources.TotalCount > 0 || analysis.OutputAnalysis.OutputCount > 0:
+		return ClassificationReadOnly
+	default:
+		return ClassificationEmpty
+	}
+}
+
+// complexityWeights are the per-item weights computeComplexity sums. Module
+// calls and provisioners weigh more than resources because each hides logic
+// a reviewer has to follow elsewhere.
+var complexityWeights = struct {
+	Resource    int
+	ModuleCall  int
+	Provider    int
+	Variable    int
+	Output      int
+	Provisioner int
+}{
+	Resource:    2,
+	ModuleCall:  5,
+	Provider:    3,
+	Variable:    1,
+	Output:      1,
+	Provisioner: 8,
+}
+
+// computeComplexity scores how much IaC a repository holds as a weighted sum
+// of its resources, module calls, providers, variables, outputs and provisioners
+func computeComplexity(analysis RepositoryAnalysis) int {
+	weights := complexityWeights
+	return analysis.ResourceAnalysis.TotalResourceCount*weights.Resource +
+		analysis.Modules.TotalModuleCalls*weights.ModuleCall +
+		analysis.Providers.UniqueProviderCount*weights.Provider +
+		len(analysis.VariableAnalysis.DefinedVariables)*weights.Variable +
+		analysis.OutputAnalysis.OutputCount*weights.Output +
+		len(analysis.ResourceAnalysis.Provisioners)*weights.Provisioner
+}
+
+// knownProviderSources maps legacy bare provider names whose registry
+// namespace is not hashicorp; every other bare name defaults to hashicorp/<name>
+var knownProviderSources = map[string]string{
+	"auth0":        "auth0/auth0",
+	"cloudflare":   "cloudflare/cloudflare",
+	"datadog":      "DataDog/datadog",
+	"digitalocean": "digitalocean/digitalocean",
+	"github":       "integrations/github",
+	"gitlab":       "gitlabhq/gitlab",
+	"mongodbatlas": "mongodb/mongodbatlas",
+	"newrelic":     "newrelic/newrelic",
+	"okta":         "okta/okta",
+	"pagerduty":    "PagerDuty/pagerduty",
+	"snowflake":    "Snowflake-Labs/snowflake",
+}
+
+// canonicalProviderSource qualifies a bare provider name such as "aws", as
+// recorded from provider blocks and source-less required_providers entries,
+// so it merges with "hashicorp/aws". Overrides win over knownProviderSources.
+func canonicalProviderSource(source string, overrides map[string]string) string {
+	if source == "" || strings.Contains(source, "/") {
+		return source
+	}
+	if canonical, ok := overrides[source]; ok {
+		return canonical
+	}
+	if canonical, ok := knownProviderSources[source]; ok {
+		return canonical
+	}
+	return "hashicorp/" + source
+}
+
+// providerSourcesMatching maps each canonical provider source to whether any
+// of its declarations matches, so a provider block without a version is
+// covered by the required_providers entry of the same source. "aws" and
+// "hashicorp/aws" are one provider; "integrations/github" and
+// "hashicorp/github" are not.
+func providerSourcesMatching(providers []ProviderDetail, matches func(ProviderDetail) bool) map[string]bool {
+	bySource := make(map[string]bool, len(providers))
+	for _, provider := range providers {
+		source := canonicalProviderSource(provider.Source, nil)
+		bySource[source] = bySource[source] || matches(provider)
+	}
+	return bySource
+}
+
+func hasVersionConstraint(provider ProviderDetail) bool {
+	return provider.Version != ""
+}
+
+// validateProviderSources rejects overrides that could never match a bare name
+func validateProviderSources(overrides map[string]string) error {
+	for name, source := range overrides {
+		if name == "" || strings.Contains(name, "/") {
+			return fmt.Errorf("invalid provider_sources name %q: must be a bare provider name", name)
+		}
+		if !strings.Contains(source, "/") {
+			return fmt.Errorf("invalid provider_sources source %q for %s: must be namespace/type", source, name)
+		}
+	}
+	return nil
+}
+
+func aggregateProviders(providers []ProviderDetail, sourceOverrides map[string]string) ProvidersAnalysis {
+	// Merge regions and aliases of the same provider declared in several files
+	uniqueProviders := make([]ProviderDetail, 0, len(providers))
+	indexByKey := make(map[string]int)
+	for _, provider := range providers {
+		provider.Source = canonicalProviderSource(provider.Source, sourceOverrides)
+		provider.Version = normalizeVersionConstraint(provider.Version)
+		key := fmt.Sprintf("%s@%s", provider.Source, provider.Version)
+		if i, exists := indexByKey[key]; exists {
+			uniqueProviders[i].Regions = lo.Union(uniqueProviders[i].Regions, provider.Regions)
+			uniqueProviders[i].Aliases = lo.Union(uniqueProviders[i].Aliases, provider.Aliases)
+			continue
+		}
+		indexByKey[key] = len(uniqueProviders)
+		uniqueProviders = append(uniqueProviders, provider)
+	}
+	uniqueProviders = mergeUnversionedProviders(uniqueProviders)
 
 	return ProvidersAnalysis{
 		UniqueProviderCount: len(uniqueProviders),
 		ProviderDetails:     uniqueProviders,
+		VersionIssues:       providerVersionIssues(uniqueProviders),
 	}
 }
 
-func aggregateModules(modules []ModuleDetail) ModulesAnalysis {
-	moduleCountMap := make(map[string]int)
-	totalModuleCalls := 0
+// mergeUnversionedProviders folds provider blocks, which never carry a
+// version, into the required_providers entry of the same source
+func mergeUnversionedProviders(providers []ProviderDetail) []ProviderDetail {
+	versionedIndex := make(map[string]int)
+	for i, provider := range providers {
+		if _, exists := versionedIndex[provider.Source]; !exists && provider.Version != "" {
+			versionedIndex[provider.Source] = i
+		}
+	}
+	isMerged := func(provider ProviderDetail, _ int) bool {
+		_, exists := versionedIndex[provider.Source]
+		return exists && provider.Version == ""
+	}
 
-	for _, module := range modules {
-		moduleCountMap[module.Source] += module.Count
-		totalModuleCalls += module.Count
+	for i, provider := range providers {
+		if isMerged(provider, i) {
+			target := &providers[versionedIndex[provider.Source]]
+			target.Regions = lo.Union(target.Regions, provider.Regions)
+			target.Aliases = lo.Union(target.Aliases, provider.Aliases)
+		}
+	}
+	return lo.Reject(providers, isMerged)
+}
+
+// localModuleTree walks local module calls from each root module, the
+// directories that call local modules but are not called by one, resolving
+// sources against the calling directory. Calls already on the current chain
+// are marked as cycles and sources outside the repository are not followed.
+func localModuleTree(repoPath string, calls map[string][]string) ([]LocalModuleNode, int) {
+	called := make(map[string]bool)
+	for dir, sources := range calls {
+		for _, source := range sources {
+			called[filepath.Join(dir, source)] = true
+		}
 	}
+	roots := lo.Filter(slices.Sorted(maps.Keys(calls)), func(dir string, _ int) bool {
+		return !called[dir]
+	})
 
-	uniqueModules := lo.MapToSlice(moduleCountMap, func(source string, count int) ModuleDetail {
-		return ModuleDetail{Source: source, Count: count}
+	var tree []LocalModuleNode
+	var walk func(dir string, chain []string)
+	walk = func(dir string, chain []string) {
+		for _, source := range slices.Sorted(slices.Values(calls[dir])) {
+			target := filepath.Join(dir, source)
+			node := LocalModuleNode{Path: filepath.ToSlash(target), CalledFrom: filepath.ToSlash(dir), Source: source, Depth: len(chain)}
+			switch {
+			case target == ".." || strings.HasPrefix(target, ".."+string(filepath.Separator)):
+				node.External = true
+			case slices.Contains(chain, target):
+				node.Cycle = true
+			default:
+				if info, err := os.Stat(filepath.Join(repoPath, target)); err != nil || !info.IsDir() {
+					node.Missing = true
+				}
+			}
+			tree = append(tree, node)
+			if !node.External && !node.Cycle && !node.Missing {
+				walk(target, append(slices.Clone(chain), target))
+			}
+		}
+	}
+	for _, root := range roots {
+		walk(root, []string{root})
+	}
+
+	depth := lo.Max(lo.Map(tree, func(node LocalModuleNode, _ int) int { return node.Depth }))
+	return tree, depth
+}
+
+func aggregateModules(modules []ModuleDetail) ModulesAnalysis {
+	uniqueModules := mergeModuleDetails(modules)
+	totalModuleCalls := lo.SumBy(uniqueModules, func(module ModuleDetail) int {
+		return module.Count
 	})
 
 	return ModulesAnalysis{
-		TotalModuleCalls:  totalModuleCalls,
-		UniqueModuleCount: len(uniqueModules),
-		UniqueModules:     uniqueModules,
+		TotalModuleCalls:    totalModuleCalls,
+		UniqueModuleCount:   len(uniqueModules),
+		UniqueModules:       uniqueModules,
+		SourceTypeBreakdown: moduleSourceTypeBreakdown(uniqueModules),
+		UnpinnedModules: lo.Reject(uniqueModules, func(module ModuleDetail, _ int) bool {
+			return isModulePinned(module)
+		}),
 	}
 }
 
 func aggregateResources(resourceTypes []ResourceType, untaggedResources []UntaggedResource) ResourceAnalysis {
 	resourceTypeCountMap := make(map[string]int)
+	resourceInstanceCountMap := make(map[string]int)
 	for _, resourceType := range resourceTypes {
 		resourceTypeCountMap[resourceType.Type] += resourceType.Count
+		resourceInstanceCountMap[resourceType.Type] += resourceType.InstanceCount
 	}
 
 	aggregatedResourceTypes := lo.MapToSlice(resourceTypeCountMap, func(resType string, count int) ResourceType {
-		return ResourceType{Type: resType, Count: count}
+		return ResourceType{Type: resType, Count: count, InstanceCount: resourceInstanceCountMap[resType]}
 	})
 
 	totalResourceCount := lo.Reduce(aggregatedResourceTypes, func(acc int, rt ResourceType, _ int) int {
@@ -684,17 +2258,29 @@ func aggregateResources(resourceTypes []ResourceType, untaggedResources []Untagg
 
 	return ResourceAnalysis{
 		TotalResourceCount:      totalResourceCount,
+		EffectiveResourceCount:  lo.SumBy(aggregatedResourceTypes, func(rt ResourceType) int { return rt.InstanceCount }),
 		UniqueResourceTypeCount: len(aggregatedResourceTypes),
 		ResourceTypes:           aggregatedResourceTypes,
 		UntaggedResources:       untaggedResources,
 	}
 }
 
+// tagCoveragePct is the percentage of taggable resources carrying every
+// required tag. With nothing to tag, coverage is 100.
+func tagCoveragePct(taggable, untagged int) float64 {
+	if taggable == 0 {
+		return 100
+	}
+	untagged = min(untagged, taggable)
+	return float64(taggable-untagged) / float64(taggable) * 100
+}
+
 func logFileProcessingStats(stats FileProcessingStats, logger *slog.Logger) {
 	logger.Debug("Repository analysis stats",
 		"files_processed", stats.FilesProcessed,
 		"files_skipped", stats.FilesSkipped,
-		"files_errored", stats.FilesErrored)
+		"files_errored", stats.FilesErrored,
+		"file_types", stats.FileTypes)
 }
 
 // ParseContext encapsulates parsing parameters following functional programming principles
@@ -728,6 +2314,17 @@ func parseBackendSafely(content string, filename string, logger *slog.Logger) *B
 	return parseWithRecovery(ctx)
 }
 
+func parseRequiredVersionSafely(content string, filename string, logger *slog.Logger) *string {
+	ctx := ParseContext[*string]{
+		Content:   content,
+		Filename:  filename,
+		ParseType: "RequiredVersion",
+		Logger:    logger,
+		Parser:    parseRequiredVersion,
+	}
+	return parseWithRecovery(ctx)
+}
+
 func parseProvidersSafely(content string, filename string, logger *slog.Logger) []ProviderDetail {
 	ctx := ParseContext[[]ProviderDetail]{
 		Content:   content,
@@ -759,6 +2356,30 @@ func parseResourcesSafely(content string, filename string, logger *slog.Logger)
 	return parseResources(content, filename)
 }
 
+func parseResourcesWithOptionsSafely(content string, filename string, options AnalysisOptions, logger *slog.Logger) ResourceParseResult {
+	ctx := ParseContext[ResourceParseResult]{
+		Content:   content,
+		Filename:  filename,
+		ParseType: "Resource",
+		Logger:    logger,
+		Parser: func(content, filename string) ResourceParseResult {
+			return parseResourcesWithOptions(content, filename, options)
+		},
+	}
+	return parseWithRecovery(ctx)
+}
+
+func scanSecretsSafely(content string, filename string, logger *slog.Logger) []SecretFinding {
+	ctx := ParseContext[[]SecretFinding]{
+		Content:   content,
+		Filename:  filename,
+		ParseType: "Secret",
+		Logger:    logger,
+		Parser:    scanSecrets,
+	}
+	return parseWithRecovery(ctx)
+}
+
 func parseVariablesSafely(content string, filename string, logger *slog.Logger) []VariableDefinition {
 	ctx := ParseContext[[]VariableDefinition]{
 		Content:   content,
@@ -770,27 +2391,85 @@ func parseVariablesSafely(content string, filename string, logger *slog.Logger)
 	return parseWithRecovery(ctx)
 }
 
-func parseOutputsSafely(content string, filename string, logger *slog.Logger) []string {
-	ctx := ParseContext[[]string]{
+func parseDataSourcesSafely(content string, filename string, logger *slog.Logger) []DataSourceDetail {
+	ctx := ParseContext[[]DataSourceDetail]{
+		Content:   content,
+		Filename:  filename,
+		ParseType: "DataSource",
+		Logger:    logger,
+		Parser:    parseDataSources,
+	}
+	return parseWithRecovery(ctx)
+}
+
+func parseMovedBlocksSafely(content string, filename string, logger *slog.Logger) []MovedBlock {
+	ctx := ParseContext[[]MovedBlock]{
+		Content:   content,
+		Filename:  filename,
+		ParseType: "Moved",
+		Logger:    logger,
+		Parser:    parseMovedBlocks,
+	}
+	return parseWithRecovery(ctx)
+}
+
+func parseOutputDetailsSafely(content string, filename string, logger *slog.Logger) []OutputDetail {
+	ctx := ParseContext[[]OutputDetail]{
 		Content:   content,
 		Filename:  filename,
 		ParseType: "Output",
 		Logger:    logger,
-		Parser:    parseOutputs,
+		Parser:    parseOutputDetails,
 	}
 	return parseWithRecovery(ctx)
 }
 
 func processRepositoryFilesWithRecovery(repo Repository, logger *slog.Logger) AnalysisResult {
+	return processRepositoryFilesWithOptions(repo, defaultAnalysisOptions(), logger)
+}
+
+func processRepositoryFilesWithOptions(repo Repository, options AnalysisOptions, logger *slog.Logger) AnalysisResult {
+	return processRepositoryFilesWithContext(context.Background(), repo, options, logger)
+}
+
+func processRepositoryFilesWithContext(ctx context.Context, repo Repository, options AnalysisOptions, logger *slog.Logger) (result AnalysisResult) {
 	repoLogger := logger.With("repository", repo.Name, "organization", repo.Organization)
 
 	defer func() {
 		if r := recover(); r != nil {
 			repoLogger.Error("Repository analysis panic recovered", "panic", r)
+			result = AnalysisResult{
+				RepoName:     repo.Name,
+				Organization: repo.Organization,
+				Error:        fmt.Errorf("%w: %v", ErrAnalysisPanic, r),
+			}
 		}
+		result = withErrorKind(result)
 	}()
 
-	analysis, err := analyzeRepositoryWithRecovery(repo.Path, repoLogger)
+	commit, fingerprint, cacheable := options.Cache.key(ctx, repo, options)
+	if cacheable {
+		if analysis, ok := options.Cache.Load(repo, commit, fingerprint); ok {
+			repoLogger.Debug("Using cached analysis", "commit", commit)
+			// The entry may have been stored from another clone workspace
+			analysis.RepositoryPath = repo.Path
+			return AnalysisResult{
+				RepoName:     repo.Name,
+				Organization: repo.Organization,
+				Analysis:     analysis,
+			}
+		}
+	}
+
+	analysis, err := analyzeRepositoryWithContext(ctx, repo.Path, options, repoLogger)
+	if errors.Is(err, ErrRepositoryTimeout) {
+		return AnalysisResult{
+			RepoName:     repo.Name,
+			Organization: repo.Organization,
+			Analysis:     analysis,
+			Error:        err,
+		}
+	}
 	if err != nil {
 		return AnalysisResult{
 			RepoName:     repo.Name,
@@ -799,6 +2478,12 @@ func processRepositoryFilesWithRecovery(repo Repository, logger *slog.Logger) An
 		}
 	}
 
+	if cacheable {
+		if err := options.Cache.Store(repo, commit, fingerprint, analysis); err != nil {
+			repoLogger.Warn("Failed to cache repository analysis", "error", err)
+		}
+	}
+
 	return AnalysisResult{
 		RepoName:     repo.Name,
 		Organization: repo.Organization,
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+)
+
+// ============================================================================
+// CACHE - On-disk analysis cache keyed by repository HEAD commit
+// ============================================================================
+
+// CacheDirName is the directory created under the user cache directory
+const CacheDirName = "tf-analyzer"
+
+// AnalysisCache stores serialized RepositoryAnalysis results under
+// <dir>/<org>/<repo>/<commit>-<options>.json so unchanged repositories are
+// not re-analyzed. Entries for other commits or options of the same
+// repository are removed whenever a new entry is written.
+type AnalysisCache struct {
+	dir        string
+	headCommit func(ctx context.Context, repoPath string) (string, error)
+	hits       atomic.Int64
+	misses     atomic.Int64
+}
+
+func newAnalysisCache(dir string) *AnalysisCache {
+	return &AnalysisCache{dir: dir, headCommit: gitHeadCommit}
+}
+
+// defaultCacheDir resolves ~/.cache/tf-analyzer or the platform equivalent
+func defaultCacheDir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve user cache directory: %w", err)
+	}
+	return filepath.Join(base, CacheDirName), nil
+}
+
+// Hits and Misses count lookups since the cache was created
+func (c *AnalysisCache) Hits() int64   { return c.hits.Load() }
+func (c *AnalysisCache) Misses() int64 { return c.misses.Load() }
+
+// gitHeadCommit resolves HEAD of a clean worktree. Uncommitted or untracked
+// files are not part of HEAD, so a dirty worktree is an error and is not cached.
+func gitHeadCommit(ctx context.Context, repoPath string) (string, error) {
+	output, err := exec.CommandContext(ctx, "git", "-C", repoPath, "rev-parse", "HEAD").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve HEAD of %s: %w", repoPath, err)
+	}
+
+	status, err := exec.CommandContext(ctx, "git", "-C", repoPath, "status", "--porcelain").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to read worktree status of %s: %w", repoPath, err)
+	}
+	if strings.TrimSpace(string(status)) != "" {
+		return "", fmt.Errorf("%s has uncommitted changes", repoPath)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// cacheOptionsFingerprint hashes the options that change analysis output, so
+// changing e.g. --mandatory-tags does not serve results computed for other tags
+func cacheOptionsFingerprint(options AnalysisOptions) (string, error) {
+	options.Cache = nil
+	options.FileReadConcurrency = 0
+	data, err := json.Marshal(struct {
+		ToolVersion string
+		Options     AnalysisOptions
+	}{ToolVersion, options})
+	if err != nil {
+		return "", fmt.Errorf("failed to fingerprint analysis options: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+func (c *AnalysisCache) repoDir(repo Repository) string {
+	return filepath.Join(c.dir, repo.Organization, repo.Name)
+}
+
+func (c *AnalysisCache) entryPath(repo Repository, commit, fingerprint string) string {
+	return filepath.Join(c.repoDir(repo), commit+"-"+fingerprint+".json")
+}
+
+// Load returns the cached analysis for the repository at commit, if any
+func (c *AnalysisCache) Load(repo Repository, commit, fingerprint string) (RepositoryAnalysis, bool) {
+	data, err := os.ReadFile(c.entryPath(repo, commit, fingerprint))
+	if err != nil {
+		c.misses.Add(1)
+		return RepositoryAnalysis{}, false
+	}
+
+	var analysis RepositoryAnalysis
+	if err := json.Unmarshal(data, &analysis); err != nil {
+		c.misses.Add(1)
+		return RepositoryAnalysis{}, false
+	}
+	c.hits.Add(1)
+	return analysis, true
+}
+
+// Store writes the analysis for commit and drops the repository's stale entries
+func (c *AnalysisCache) Store(repo Repository, commit, fingerprint string, analysis RepositoryAnalysis) error {
+	dir := c.repoDir(repo)
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to invalidate cache for %s/%s: %w", repo.Organization, repo.Name, err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	data, err := json.Marshal(analysis)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cached analysis: %w", err)
+	}
+	if err := os.WriteFile(c.entryPath(repo, commit, fingerprint), data, 0644); err != nil {
+		return fmt.Errorf("failed to write cached analysis: %w", err)
+	}
+	return nil
+}
+
+// key resolves the commit and options fingerprint for a lookup; ok is false
+// when caching is disabled or the repository has no resolvable clean HEAD
+func (c *AnalysisCache) key(ctx context.Context, repo Repository, options AnalysisOptions) (string, string, bool) {
+	if c == nil {
+		return "", "", false
+	}
+	commit, err := c.headCommit(ctx, repo.Path)
+	if err != nil || commit == "" {
+		return "", "", false
+	}
+	fingerprint, err := cacheOptionsFingerprint(options)
+	if err != nil {
+		return "", "", false
+	}
+	return commit, fingerprint, true
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestAnalysisCache(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	commit := "3f2a9c1"
+	newCache := func(t *testing.T) *AnalysisCache {
+		cache := newAnalysisCache(t.TempDir())
+		cache.headCommit = func(context.Context, string) (string, error) { return commit, nil }
+		return cache
+	}
+
+	newRepo := func(t *testing.T) Repository {
+		repoDir := createTempTerraformRepo(t, map[string]string{
+			"main.tf": `resource "aws_vpc" "main" {}`,
+		})
+		return Repository{Name: "network", Organization: "acme", Path: repoDir}
+	}
+
+	t.Run("a second run at the same commit reads from the cache", func(t *testing.T) {
+		// Given: a repository and an empty cache
+		repo := newRepo(t)
+		cache := newCache(t)
+		options := AnalysisOptions{Cache: cache}
+
+		// When: the repository is analyzed twice, with a file added in between
+		first := processRepositoryFilesWithOptions(repo, options, logger)
+		if err := os.WriteFile(filepath.Join(repo.Path, "extra.tf"), []byte(`resource "aws_subnet" "a" {}`), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+		second := processRepositoryFilesWithOptions(repo, options, logger)
+
+		// Then: the first run misses, the second hits and skips walking the files
+		if first.Error != nil || second.Error != nil {
+			t.Fatalf("Expected no errors, got %v and %v", first.Error, second.Error)
+		}
+		if cache.Hits() != 1 || cache.Misses() != 1 {
+			t.Errorf("Expected 1 hit and 1 miss, got %d hits and %d misses", cache.Hits(), cache.Misses())
+		}
+		if second.Analysis.ResourceAnalysis.TotalResourceCount != 1 {
+			t.Errorf("Expected the cached analysis with 1 resource, got %d", second.Analysis.ResourceAnalysis.TotalResourceCount)
+		}
+		if !reflect.DeepEqual(first.Analysis.ResourceAnalysis, second.Analysis.ResourceAnalysis) || second.Analysis.RepositoryPath != repo.Path {
+			t.Errorf("Expected the cached analysis to match the first run, got %+v", second.Analysis)
+		}
+	})
+
+	t.Run("a new commit misses and replaces the stale entry", func(t *testing.T) {
+		// Given: a cached analysis at the current commit
+		repo := newRepo(t)
+		cache := newCache(t)
+		options := AnalysisOptions{Cache: cache}
+		processRepositoryFilesWithOptions(repo, options, logger)
+
+		// When: the repository moves to a new commit
+		commit = "9b7e4d0"
+		t.Cleanup(func() { commit = "3f2a9c1" })
+		processRepositoryFilesWithOptions(repo, options, logger)
+
+		// Then: both runs miss and only the new commit stays cached
+		if cache.Hits() != 0 || cache.Misses() != 2 {
+			t.Errorf("Expected 0 hits and 2 misses, got %d hits and %d misses", cache.Hits(), cache.Misses())
+		}
+		entries, err := os.ReadDir(cache.repoDir(repo))
+		if err != nil || len(entries) != 1 {
+			t.Fatalf("Expected exactly one cache entry, got %v (%v)", entries, err)
+		}
+		if name := entries[0].Name(); name[:len(commit)] != commit {
+			t.Errorf("Expected the entry for commit %s, got %s", commit, name)
+		}
+	})
+
+	t.Run("changed analysis options miss the cache", func(t *testing.T) {
+		// Given: a cached analysis for the default mandatory tags
+		repo := newRepo(t)
+		cache := newCache(t)
+		processRepositoryFilesWithOptions(repo, AnalysisOptions{Cache: cache}, logger)
+
+		// When: the mandatory tags change
+		result := processRepositoryFilesWithOptions(repo, AnalysisOptions{Cache: cache, MandatoryTags: []string{"Team"}}, logger)
+
+		// Then: the repository is re-analyzed against the new tags
+		if cache.Hits() != 0 {
+			t.Errorf("Expected no cache hits, got %d", cache.Hits())
+		}
+		untagged := result.Analysis.ResourceAnalysis.UntaggedResources
+		if len(untagged) != 1 || !reflect.DeepEqual(untagged[0].MissingTags, []string{"Team"}) {
+			t.Errorf("Expected aws_vpc.main to be missing only Team, got %+v", untagged)
+		}
+	})
+
+	t.Run("repositories without a HEAD are not cached", func(t *testing.T) {
+		repo := newRepo(t)
+		cache := newCache(t)
+		cache.headCommit = func(context.Context, string) (string, error) { return "", os.ErrNotExist }
+
+		result := processRepositoryFilesWithOptions(repo, AnalysisOptions{Cache: cache}, logger)
+
+		if result.Error != nil || cache.Hits()+cache.Misses() != 0 {
+			t.Errorf("Expected an uncached analysis, got error %v and %d lookups", result.Error, cache.Hits()+cache.Misses())
+		}
+	})
+}
+
+func TestGitHeadCommit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	// Given: a git repository with one commit
+	repoDir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "--allow-empty", "-m", "initial"},
+	} {
+		if output, err := exec.Command("git", append([]string{"-C", repoDir}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v: %s", args, err, output)
+		}
+	}
+
+	// When: HEAD is resolved
+	commit, err := gitHeadCommit(context.Background(), repoDir)
+
+	// Then: it should be a full commit SHA
+	if err != nil || len(commit) != 40 {
+		t.Errorf("Expected a 40 character SHA, got %q (%v)", commit, err)
+	}
+
+	// When: the worktree has an uncommitted file
+	if err := os.WriteFile(filepath.Join(repoDir, "main.tf"), []byte(`resource "aws_s3_bucket" "b" {}`), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	commit, err = gitHeadCommit(context.Background(), repoDir)
+
+	// Then: HEAD no longer describes the content, so there is no cache key
+	if err == nil || commit != "" {
+		t.Errorf("Expected an error for a dirty worktree, got %q (%v)", commit, err)
+	}
+}
+
+func TestCreateConfigFromViperCache(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings map[string]any
+		enabled  bool
+		dir      string
+	}{
+		{name: "disabled by default", settings: map[string]any{}, enabled: false},
+		{name: "enabled with an explicit directory", settings: map[string]any{"cache.enabled": true, "cache.directory": "/var/cache/tfa"}, enabled: true, dir: "/var/cache/tfa"},
+		{name: "--no-cache overrides the config file", settings: map[string]any{"cache.enabled": true, "cache.disabled": true}, enabled: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			for key, value := range tt.settings {
+				viper.Set(key, value)
+			}
+
+			config, err := createConfigFromViper()
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if config.CacheEnabled != tt.enabled || (tt.dir != "" && config.CacheDir != tt.dir) {
+				t.Errorf("Expected enabled=%v dir=%q, got enabled=%v dir=%q", tt.enabled, tt.dir, config.CacheEnabled, config.CacheDir)
+			}
+			if cache := analysisOptionsFromConfig(config).Cache; (cache != nil) != tt.enabled {
+				t.Errorf("Expected cache in options to be %v, got %v", tt.enabled, cache)
+			}
+		})
+	}
+
+	t.Run("enabled without a directory uses the user cache directory", func(t *testing.T) {
+		viper.Reset()
+		viper.Set("cache.enabled", true)
+		config, err := createConfigFromViper()
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if filepath.Base(config.CacheDir) != CacheDirName {
+			t.Errorf("Expected a %s cache directory, got %q", CacheDirName, config.CacheDir)
+		}
+	})
+}
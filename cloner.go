@@ -1,14 +1,19 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io/fs"
 	"log/slog"
+	"net/url"
 	"os"
 	"os/exec"
 	"os/user"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -17,6 +22,53 @@ import (
 // CLONER - Repository cloning functionality
 // ============================================================================
 
+// SCM providers ghorg can clone from
+const (
+	SCMProviderGitHub = "github"
+	SCMProviderGitLab = "gitlab"
+)
+
+// scmTokenEnvVars maps each SCM provider to the variable ghorg reads its token from
+var scmTokenEnvVars = map[string]string{
+	SCMProviderGitHub: "GHORG_GITHUB_TOKEN",
+	SCMProviderGitLab: "GHORG_GITLAB_TOKEN",
+}
+
+// resolveSCMProvider defaults an empty provider to GitHub and rejects unknown ones
+func resolveSCMProvider(provider string) (string, error) {
+	if provider == "" {
+		return SCMProviderGitHub, nil
+	}
+	if _, ok := scmTokenEnvVars[provider]; !ok {
+		return "", fmt.Errorf("invalid --scm-provider %q: must be %s or %s", provider, SCMProviderGitHub, SCMProviderGitLab)
+	}
+	return provider, nil
+}
+
+// normalizeBaseURL requires an absolute http(s) URL for --base-url and strips
+// trailing slashes; an empty URL means the provider's public host
+func normalizeBaseURL(raw string) (string, error) {
+	trimmed := strings.TrimRight(strings.TrimSpace(raw), "/")
+	if trimmed == "" {
+		return "", nil
+	}
+
+	parsed, err := url.Parse(trimmed)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return "", fmt.Errorf("invalid base URL %q: expected an absolute http(s) URL such as https://github.example.com", raw)
+	}
+	return trimmed, nil
+}
+
+// normalizeBaseURLOrKeep normalizes a valid base URL and leaves a malformed one
+// untouched so validateCLIAnalysisConfig can report it
+func normalizeBaseURLOrKeep(raw string) string {
+	if normalized, err := normalizeBaseURL(raw); err == nil {
+		return normalized
+	}
+	return raw
+}
+
 type CloneOperation struct {
 	Org       string
 	TempDir   string
@@ -36,6 +88,13 @@ func createCloneOperation(org, tempDir string, config Config) CloneOperation {
 func buildGhorgCommand(ctx context.Context, op CloneOperation) *exec.Cmd {
 	args := []string{"clone", op.Org, "--path", op.TempDir}
 
+	// ghorg defaults to GitHub, so --scm-type is only passed for other providers;
+	// an invalid provider is rejected by validateCLIAnalysisConfig before cloning
+	provider, _ := resolveSCMProvider(op.Config.SCMProvider)
+	if provider != SCMProviderGitHub {
+		args = append(args, "--scm-type", provider)
+	}
+
 	if op.Config.SkipArchived {
 		args = append(args, "--skip-archived")
 	}
@@ -79,7 +138,7 @@ func buildGhorgCommand(ctx context.Context, op CloneOperation) *exec.Cmd {
 	cmd := exec.CommandContext(ctx, "ghorg", args...)
 
 	if op.Config.GitHubToken != "" {
-		cmd.Env = append(os.Environ(), "GHORG_GITHUB_TOKEN="+op.Config.GitHubToken)
+		cmd.Env = append(os.Environ(), scmTokenEnvVars[provider]+"="+op.Config.GitHubToken)
 	}
 
 	return cmd
@@ -131,8 +190,15 @@ func createTempReposFile(repos []string) (string, error) {
 }
 
 
-func createTempDirectoryWithRecovery(logger *slog.Logger) (string, error) {
-	baseDir := "~/src/gh-repos-clone"
+// DefaultClonesDir is the --clones-dir default; each organization clones into its own subdirectory
+const DefaultClonesDir = "~/src/gh-repos-clone"
+
+// createTempDirectoryWithRecovery creates a fresh directory under baseDir so
+// organizations cloned at the same time never share, or remove, each other's clones
+func createTempDirectoryWithRecovery(baseDir string, logger *slog.Logger) (string, error) {
+	if baseDir == "" {
+		baseDir = DefaultClonesDir
+	}
 
 	expandedPath, err := createAbsolutePath(baseDir)
 	if err != nil {
@@ -145,10 +211,9 @@ func createTempDirectoryWithRecovery(logger *slog.Logger) (string, error) {
 		return "", fmt.Errorf("failed to create base directory %s: %w", expandedPath, err)
 	}
 
-	tempDir := expandedPath
-
-	if err := os.MkdirAll(tempDir, 0755); err != nil {
-		return "", fmt.Errorf("failed to create temp directory %s: %w", tempDir, err)
+	tempDir, err := os.MkdirTemp(expandedPath, "tf-analyzer-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp directory in %s: %w", expandedPath, err)
 	}
 
 	logger.Debug("Temp directory created successfully", "path", tempDir)
@@ -269,8 +334,10 @@ func executeCloneCommand(ctx context.Context, op CloneOperation, logger *slog.Lo
 }
 
 
-func setupWorkspaceWithRecovery(logger *slog.Logger) (string, func(), error) {
-	tempDir, err := createTempDirectoryWithRecovery(logger)
+// setupWorkspaceWithRecovery creates an organization's clone directory; the
+// returned cleanup removes it unless keepClones is set
+func setupWorkspaceWithRecovery(baseDir string, keepClones bool, logger *slog.Logger) (string, func(), error) {
+	tempDir, err := createTempDirectoryWithRecovery(baseDir, logger)
 	if err != nil {
 		return "", nil, fmt.Errorf("failed to create temp dir: %w", err)
 	}
@@ -278,6 +345,10 @@ func setupWorkspaceWithRecovery(logger *slog.Logger) (string, func(), error) {
 	logger.Debug("Workspace setup completed", "temp_dir", tempDir)
 
 	cleanup := func() {
+		if keepClones {
+			logger.Info("Keeping cloned repositories", "temp_dir", tempDir)
+			return
+		}
 		if cleanupErr := removeTempDirectoryWithRecovery(tempDir, logger); cleanupErr != nil {
 			logger.Warn("Failed to cleanup temp directory", "temp_dir", tempDir, "error", cleanupErr)
 		}
@@ -321,6 +392,57 @@ func executeClonePhase(ctx context.Context, operation CloneOperation, logger *sl
 	return nil
 }
 
+// ghorgExitError is a ghorg run that exited unsuccessfully, keeping the
+// stderr output used to decide whether the clone is worth retrying
+type ghorgExitError struct {
+	ExitCode int
+	Stderr   string
+}
+
+func (e *ghorgExitError) Error() string {
+	if e.Stderr == "" {
+		return fmt.Sprintf("ghorg exited with code %d", e.ExitCode)
+	}
+	return fmt.Sprintf("ghorg exited with code %d: %s", e.ExitCode, e.Stderr)
+}
+
+// scmAPIErrorPattern captures the HTTP status of a failed request, as ghorg
+// reports SCM API failures ("GET https://...: 404 Not Found") and git
+// reports HTTPS clone failures ("The requested URL returned error: 404")
+var scmAPIErrorPattern = regexp.MustCompile(`\b(?:(?:GET|POST|PUT|PATCH|DELETE|HEAD) https?://\S+:|returned error:) (\d{3})\b`)
+
+// fatalCloneStatuses are SCM API statuses that retrying cannot fix: a bad
+// token or an organization that does not exist
+var fatalCloneStatuses = map[int]bool{
+	401: true,
+	404: true,
+}
+
+// fatalCloneMessagePattern matches authentication failures reported outside
+// an API response, e.g. by git itself
+var fatalCloneMessagePattern = regexp.MustCompile(`(?i)\b(?:bad credentials|authentication failed|invalid token)\b`)
+
+// isRetryableCloneError reports whether a clone failure looks transient.
+// ghorg exits with status 1 for timeouts, 5xx responses, rate limits and
+// dropped connections alike, so any exit is retried unless its stderr holds
+// a 401 or 404 API response or an authentication failure. Errors from
+// starting the command or from cancellation are never retried.
+func isRetryableCloneError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var exitErr *ghorgExitError
+	if !errors.As(err, &exitErr) {
+		return false
+	}
+	for _, match := range scmAPIErrorPattern.FindAllStringSubmatch(exitErr.Stderr, -1) {
+		if status, convErr := strconv.Atoi(match[1]); convErr == nil && fatalCloneStatuses[status] {
+			return false
+		}
+	}
+	return !fatalCloneMessagePattern.MatchString(exitErr.Stderr)
+}
+
 func executeCommandWithProgressTracking(ctx context.Context, cmd *exec.Cmd, op CloneOperation, logger *slog.Logger) error {
 	// Check context before starting
 	select {
@@ -329,6 +451,12 @@ func executeCommandWithProgressTracking(ctx context.Context, cmd *exec.Cmd, op C
 	default:
 	}
 
+	// Keep stderr to classify failures for the retry policy
+	var stderr bytes.Buffer
+	if cmd.Stderr == nil {
+		cmd.Stderr = &stderr
+	}
+
 	// Start the command
 	if err := cmd.Start(); err != nil {
 		return fmt.Errorf("failed to start ghorg command: %w", err)
@@ -346,8 +474,9 @@ func executeCommandWithProgressTracking(ctx context.Context, cmd *exec.Cmd, op C
 			if exitError, ok := err.(*exec.ExitError); ok {
 				logger.Error("ghorg command failed", 
 					"exit_code", exitError.ExitCode(),
-					"organization", op.Org)
-				return fmt.Errorf("ghorg exited with code %d", exitError.ExitCode())
+					"organization", op.Org,
+					"stderr", stderr.String())
+				return &ghorgExitError{ExitCode: exitError.ExitCode(), Stderr: strings.TrimSpace(stderr.String())}
 			}
 			return fmt.Errorf("ghorg command failed: %w", err)
 		}
@@ -2,11 +2,13 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"os"
 	"os/exec"
 	"os/user"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -155,6 +157,122 @@ func TestBuildGhorgCommand(t *testing.T) {
 	}
 }
 
+// TestBuildGhorgCommandSCMProvider tests that the SCM provider selects the ghorg type and token variable
+func TestBuildGhorgCommandSCMProvider(t *testing.T) {
+	tests := []struct {
+		name          string
+		provider      string
+		expectedArgs  []string
+		expectedEnv   string
+		unexpectedEnv string
+	}{
+		{
+			name:          "default is GitHub without --scm-type",
+			provider:      "",
+			expectedArgs:  []string{"ghorg", "clone", "test-org", "--path", "/tmp/test", "--concurrency", "5", "--git-filter", "blob:none"},
+			expectedEnv:   "GHORG_GITHUB_TOKEN=token123",
+			unexpectedEnv: "GHORG_GITLAB_TOKEN=",
+		},
+		{
+			name:          "explicit GitHub matches the default",
+			provider:      SCMProviderGitHub,
+			expectedArgs:  []string{"ghorg", "clone", "test-org", "--path", "/tmp/test", "--concurrency", "5", "--git-filter", "blob:none"},
+			expectedEnv:   "GHORG_GITHUB_TOKEN=token123",
+			unexpectedEnv: "GHORG_GITLAB_TOKEN=",
+		},
+		{
+			name:          "GitLab passes --scm-type and the GitLab token",
+			provider:      SCMProviderGitLab,
+			expectedArgs:  []string{"ghorg", "clone", "test-org", "--path", "/tmp/test", "--scm-type", "gitlab", "--concurrency", "5", "--git-filter", "blob:none"},
+			expectedEnv:   "GHORG_GITLAB_TOKEN=token123",
+			unexpectedEnv: "GHORG_GITHUB_TOKEN=",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given: a clone operation for the provider
+			op := CloneOperation{
+				Org:     "test-org",
+				TempDir: "/tmp/test",
+				Config:  Config{GitHubToken: "token123", CloneConcurrency: 5, SCMProvider: tt.provider},
+			}
+
+			// When: buildGhorgCommand is called
+			cmd := buildGhorgCommand(context.Background(), op)
+
+			// Then: args and token variable should match the provider
+			actualArgs := append([]string{"ghorg"}, cmd.Args[1:]...)
+			if strings.Join(actualArgs, " ") != strings.Join(tt.expectedArgs, " ") {
+				t.Errorf("Expected args %v, got %v", tt.expectedArgs, actualArgs)
+			}
+			if !slices.Contains(cmd.Env, tt.expectedEnv) {
+				t.Errorf("Expected env %s to be set", tt.expectedEnv)
+			}
+			if slices.ContainsFunc(cmd.Env, func(env string) bool { return strings.HasPrefix(env, tt.unexpectedEnv+"token123") }) {
+				t.Errorf("Expected no %s token for provider %q", tt.unexpectedEnv, tt.provider)
+			}
+		})
+	}
+
+	t.Run("unknown providers are rejected", func(t *testing.T) {
+		config := Config{Organizations: []string{"org"}, GitHubToken: "token123", SCMProvider: "bitbucket"}
+		if err := validateCLIAnalysisConfig(config); err == nil || !strings.Contains(err.Error(), "--scm-provider") {
+			t.Errorf("Expected --scm-provider validation error, got %v", err)
+		}
+	})
+}
+
+// TestNormalizeBaseURL tests --base-url validation and normalization
+func TestNormalizeBaseURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expected    string
+		expectError bool
+	}{
+		{name: "empty keeps the public host", input: "", expected: ""},
+		{name: "valid enterprise URL", input: "https://github.enterprise.com", expected: "https://github.enterprise.com"},
+		{name: "trailing slashes are trimmed", input: "https://github.enterprise.com/api/v3//", expected: "https://github.enterprise.com/api/v3"},
+		{name: "surrounding whitespace is trimmed", input: "  http://gitlab.internal:8080/ ", expected: "http://gitlab.internal:8080"},
+		{name: "scheme-less host is rejected", input: "github.enterprise.com", expectError: true},
+		{name: "unsupported scheme is rejected", input: "ftp://github.enterprise.com", expectError: true},
+		{name: "scheme without host is rejected", input: "https://", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When: the base URL is normalized
+			result, err := normalizeBaseURL(tt.input)
+
+			// Then: valid URLs are normalized and malformed ones rejected
+			if tt.expectError {
+				if err == nil || !strings.Contains(err.Error(), "invalid base URL") {
+					t.Errorf("Expected invalid base URL error, got %q, %v", result, err)
+				}
+				return
+			}
+			if err != nil || result != tt.expected {
+				t.Errorf("Expected %q, got %q (error %v)", tt.expected, result, err)
+			}
+		})
+	}
+
+	t.Run("config creation normalizes and validation reports malformed URLs", func(t *testing.T) {
+		// Given: environment with a trailing slash and one without a scheme
+		normalized := createConfigFromEnv(map[string]string{"GITHUB_BASE_URL": "https://github.enterprise.com/"})
+		malformed := createConfigFromEnv(map[string]string{"GITHUB_TOKEN": "token123", "GITHUB_ORGS": "org", "GITHUB_BASE_URL": "github.enterprise.com"})
+
+		// Then: the valid URL is normalized and the malformed one fails validation
+		if normalized.BaseURL != "https://github.enterprise.com" {
+			t.Errorf("Expected normalized base URL, got %q", normalized.BaseURL)
+		}
+		if err := validateCLIAnalysisConfig(malformed); err == nil || !strings.Contains(err.Error(), "github.enterprise.com") {
+			t.Errorf("Expected validation error naming the URL, got %v", err)
+		}
+	})
+}
+
 // TestExpandHomePath tests home directory expansion
 func TestExpandHomePath(t *testing.T) {
 	t.Run("expands home path correctly", func(t *testing.T) {
@@ -282,7 +400,7 @@ func TestCreateTempDirectoryWithRecovery(t *testing.T) {
 		logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
 
 		// When: createTempDirectoryWithRecovery is called
-		tempDir, err := createTempDirectoryWithRecovery(logger)
+		tempDir, err := createTempDirectoryWithRecovery(t.TempDir(), logger)
 
 		// Then: should create directory and return path
 		if err != nil {
@@ -489,11 +607,69 @@ func TestSetupWorkspaceWithRecovery(t *testing.T) {
 
 		// When: setupWorkspaceWithRecovery is called
 		// Then: should not panic
-		_, cleanup, _ := setupWorkspaceWithRecovery(logger)
+		_, cleanup, _ := setupWorkspaceWithRecovery(t.TempDir(), false, logger)
 		if cleanup != nil {
 			defer cleanup()
 		}
 	})
+
+	t.Run("concurrent organizations get separate workspaces", func(t *testing.T) {
+		// Given: organizations setting up workspaces under one clones directory at the same time
+		logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+		baseDir := t.TempDir()
+		const orgs = 4
+		dirs := make([]string, orgs)
+		cleanups := make([]func(), orgs)
+		errs := make(chan error, orgs)
+		done := make(chan struct{})
+		for i := range orgs {
+			go func() {
+				defer func() { done <- struct{}{} }()
+				dir, cleanup, err := setupWorkspaceWithRecovery(baseDir, false, logger)
+				if err != nil {
+					errs <- err
+					return
+				}
+				dirs[i], cleanups[i] = dir, cleanup
+				if err := os.WriteFile(filepath.Join(dir, "main.tf"), []byte("# clone"), 0644); err != nil {
+					errs <- err
+				}
+			}()
+		}
+		for range orgs {
+			<-done
+		}
+		close(errs)
+		for err := range errs {
+			t.Fatalf("Expected workspace setup to succeed, got %v", err)
+		}
+
+		// Then: every workspace is distinct and below the clones directory
+		if len(slices.Compact(slices.Sorted(slices.Values(dirs)))) != orgs {
+			t.Fatalf("Expected %d distinct workspaces, got %v", orgs, dirs)
+		}
+		for _, dir := range dirs {
+			if filepath.Dir(dir) != baseDir {
+				t.Errorf("Expected workspace %s under %s", dir, baseDir)
+			}
+		}
+
+		// When: one organization finishes and cleans up
+		cleanups[0]()
+
+		// Then: only its workspace is removed
+		if _, err := os.Stat(dirs[0]); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be removed, got %v", dirs[0], err)
+		}
+		for _, dir := range dirs[1:] {
+			if _, err := os.Stat(filepath.Join(dir, "main.tf")); err != nil {
+				t.Errorf("Expected the other organizations' clones to remain, got %v", err)
+			}
+		}
+		if _, err := os.Stat(baseDir); err != nil {
+			t.Errorf("Expected the clones directory to remain, got %v", err)
+		}
+	})
 }
 
 // TestExecuteClonePhaseWithRecovery tests clone phase execution
@@ -600,4 +776,32 @@ func TestExecuteCommandWithoutProgressTracking(t *testing.T) {
 			t.Errorf("Expected no error for echo command, got %v", err)
 		}
 	})
-}
\ No newline at end of file
+}
+func TestIsRetryableCloneError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"gateway error", &ghorgExitError{ExitCode: 1, Stderr: "502 Bad Gateway"}, true},
+		{"network timeout", &ghorgExitError{ExitCode: 1, Stderr: "dial tcp: i/o timeout"}, true},
+		{"rate limited", &ghorgExitError{ExitCode: 1, Stderr: "403 API rate limit exceeded"}, true},
+		{"wrapped transient failure", fmt.Errorf("ghorg clone failed: %w", &ghorgExitError{ExitCode: 1, Stderr: "connection reset by peer"}), true},
+		{"bad token", &ghorgExitError{ExitCode: 1, Stderr: "GET https://api.github.com/orgs/acme/repos: 401 Bad credentials []"}, false},
+		{"unknown organization", &ghorgExitError{ExitCode: 1, Stderr: "GET https://api.github.com/orgs/acme/repos: 404 Not Found []"}, false},
+		{"git authentication failure", &ghorgExitError{ExitCode: 1, Stderr: "fatal: Authentication failed for 'https://github.com/acme/app.git/'"}, false},
+		{"server error from the API", &ghorgExitError{ExitCode: 1, Stderr: "GET https://api.github.com/orgs/acme/repos: 503 Service Unavailable []"}, true},
+		{"status code inside a repository name", &ghorgExitError{ExitCode: 1, Stderr: "error cloning acme/error-404-page: connection reset by peer"}, true},
+		{"status code inside a duration", &ghorgExitError{ExitCode: 1, Stderr: "request timed out after 401ms"}, true},
+		{"missing repository over HTTPS", &ghorgExitError{ExitCode: 1, Stderr: "fatal: unable to access 'https://github.com/acme/app.git/': The requested URL returned error: 404"}, false},
+		{"cancelled", fmt.Errorf("command cancelled: %w", context.Canceled), false},
+		{"ghorg not installed", fmt.Errorf("failed to start ghorg command: %w", exec.ErrNotFound), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryableCloneError(tt.err); got != tt.expected {
+				t.Errorf("isRetryableCloneError(%v) = %v, expected %v", tt.err, got, tt.expected)
+			}
+		})
+	}
+}
@@ -2,42 +2,183 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"maps"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"runtime"
+	"slices"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
 )
 
 // ============================================================================
 // CLI - Professional Command Line Interface using Cobra and Fang
 // ============================================================================
 
+// ToolVersion is the released version of tf-analyzer
+const ToolVersion = "1.0.0"
+
+// DefaultReportPrefix is the --report-prefix default, giving the report file names below
+const DefaultReportPrefix = "terraform-analysis-report"
+
+// Placeholders expanded in --report-prefix
+const (
+	ReportPrefixOrgPlaceholder  = "{org}"
+	ReportPrefixDatePlaceholder = "{date}"
+	ReportPrefixDateLayout      = "2006-01-02"
+)
+
+// SplitReportSuffix ends --split-by-org report names whose prefix already
+// holds {org}, so they do not overwrite the combined reports
+const SplitReportSuffix = "-split"
+
+// Report file names written to the output directory
+const (
+	JSONReportFileName     = DefaultReportPrefix + ".json"
+	CSVReportFileName      = DefaultReportPrefix + ".csv"
+	ResourceCSVFileName    = "terraform-analysis-resources.csv"
+	UntaggedCSVFileName    = "terraform-analysis-untagged.csv"
+	FindingsJSONFileName   = "terraform-analysis-findings.json"
+	SARIFReportFileName    = DefaultReportPrefix + ".sarif"
+	MarkdownReportFileName = DefaultReportPrefix + ".md"
+	HTMLReportFileName     = DefaultReportPrefix + ".html"
+	YAMLReportFileName     = DefaultReportPrefix + ".yaml"
+	TOMLReportFileName     = DefaultReportPrefix + ".toml"
+	PrometheusFileName     = "tfanalyzer.prom"
+	SummaryJSONFileName    = "terraform-analysis-summary.json"
+)
+
+// Suffixes of the reports whose default names predate --report-prefix. With
+// the default prefix they keep the names above; any other prefix names them
+// <prefix><suffix>, e.g. acme-2026-10-14-resources.csv.
+const (
+	ResourceCSVSuffix  = "-resources.csv"
+	UntaggedCSVSuffix  = "-untagged.csv"
+	FindingsJSONSuffix = "-findings.json"
+	PrometheusSuffix   = ".prom"
+	SummaryJSONSuffix  = "-summary.json"
+)
+
+var defaultSuffixedReportNames = map[string]string{
+	ResourceCSVSuffix:  ResourceCSVFileName,
+	UntaggedCSVSuffix:  UntaggedCSVFileName,
+	FindingsJSONSuffix: FindingsJSONFileName,
+	PrometheusSuffix:   PrometheusFileName,
+	SummaryJSONSuffix:  SummaryJSONFileName,
+}
+
+// suffixedReportPath places one of the suffixed reports in outputDir
+func suffixedReportPath(outputDir, prefix, suffix string) string {
+	if prefix == DefaultReportPrefix {
+		return filepath.Join(outputDir, defaultSuffixedReportNames[suffix])
+	}
+	return filepath.Join(outputDir, prefix+suffix)
+}
+
+// TimestampedOutputLayout names --timestamped-output subdirectories, e.g. 20261014-093000
+const TimestampedOutputLayout = "20060102-150405"
+
+// Process exit codes documented in the analyze help
+const (
+	ExitCodeSuccess           = 0
+	ExitCodeError             = 1
+	ExitCodeThresholdExceeded = 2
+)
+
+// FailOnUntaggedDisabled is the --fail-on-untagged default that turns the gate off
+const FailOnUntaggedDisabled = -1
+
+// DefaultMinPinnedPct makes --require-pinned-providers demand that every provider is pinned
+const DefaultMinPinnedPct = 100
+
 var (
-	cfgFile          string
-	envFile          string
-	organizations    []string
-	githubToken      string
-	maxGoroutines    int
-	cloneConcurrency int
-	timeout          time.Duration
-	outputFormat     string
-	outputDir        string
-	verbose          bool
-	markdownStyle    string
-	rawMarkdown      bool
+	cfgFile             string
+	envFile             string
+	configProfile       string
+	organizations       []string
+	githubToken         string
+	tokenFile           string
+	scmProvider         string
+	maxGoroutines       int
+	cloneConcurrency    int
+	autoConcurrencyFlag bool
+	fileReadConcurrency int
+	maxRetries          int
+	progressInterval    time.Duration
+	timeout             time.Duration
+	timeoutAsWarning    bool
+	repoTimeout         time.Duration
+	requireRepos        bool
+	orgOrder            string
+	orgConcurrency      int
+	clonesDir           string
+	keepClones          bool
+	localPath           string
+	singleRepo          bool
+	outputFormat        string
+	outputDir           string
+	verbose             bool
+	quiet               bool
+	printConfig         bool
+	configSchemaCheck   bool
+	markdownStyle       string
+	rawMarkdown         bool
 	// Repository targeting flags
 	targetRepos     []string
 	targetReposFile string
+	singleRepoRef   string
 	matchRegex      string
 	matchPrefix     []string
 	excludeRegex    string
 	excludePrefix   []string
+	// Analysis mode flags
+	listProviders  bool
+	secretScanning bool
+	checkLatest    bool
+	validateOnly   bool
+	dryRun         bool
+	ignorePatterns []string
+	includeExt     []string
+	analyze        []string
+	since          string
+	// Compliance flags
+	complianceConfig             string
+	mandatoryTags                []string
+	tagsCaseInsensitive          bool
+	failOnUntagged               int
+	failOnMissingProviderVersion bool
+	requirePinnedProviders       bool
+	minPinnedPct                 int
+	failOnDeprecated             bool
+	// Output flags
+	writeManifest     bool
+	timestampedOutput bool
+	reportPrefix      string
+	splitByOrg        bool
+	splitOnly         bool
+	webhookURL        string
+	webhookHeaders    []string
+	webhookTimeout    time.Duration
+	cacheEnabled      bool
+	noCache           bool
+	cacheDir          string
+	sortReportsBy     string
+	maxTotalFindings  int
+	streamOutput      string
+	graphOutput       string
+	// Schema flags
+	schemaOutput string
 )
 
 // loadRequiredEnvFile loads a .env file and returns an error if it doesn't exist
@@ -91,7 +232,7 @@ multiple GitHub organizations to provide comprehensive insights into your infras
 
 For more information, visit: https://github.com/your-repo/tf-analyzer
 	`,
-	Version: "1.0.0",
+	Version: ToolVersion,
 }
 
 // analyzeCmd represents the analyze command
@@ -115,11 +256,171 @@ comprehensive Terraform configuration analysis.
 	# Analyze multiple organizations with custom settings (comma-separated)
 	tf-analyzer analyze --orgs "org1,org2,org3" --max-goroutines 50 --timeout 45m
 	
+	# Give up on any single repository after 5 minutes, keeping the rest of the run
+	tf-analyzer analyze --orgs "my-org" --repo-timeout 5m
+	
+	# Read the token from a mounted secret instead of GITHUB_TOKEN
+	tf-analyzer analyze --orgs "my-org" --token-file /run/secrets/github_token
+	
+	# Size workers and clones from the CPU count (explicit limits still win)
+	tf-analyzer analyze --orgs "my-org" --auto-concurrency
+	
+	# Clone onto a scratch volume and keep the clones afterwards for debugging
+	tf-analyzer analyze --orgs "my-org" --clones-dir /mnt/scratch/clones --keep-clones
+	
+	# Retry flaky clones up to 5 times, doubling the delay between attempts
+	tf-analyzer analyze --orgs "my-org" --max-retries 5
+	
+	# Prefetch file contents concurrently on slow network filesystems
+	tf-analyzer analyze --orgs "my-org" --file-read-concurrency 16
+	
+	# Print the resolved configuration and where each value came from
+	tf-analyzer analyze --orgs "my-org" --print-config
+	
+	# Fail on unknown or misspelled keys in the config file
+	tf-analyzer analyze --config .tf-analyzer.yaml --config-schema-check
+	
+	# Analyze a GitLab group instead of a GitHub organization
+	tf-analyzer analyze --orgs "my-group" --scm-provider gitlab --token "$GITLAB_TOKEN"
+	
+	# Clone and analyze exactly one repository
+	tf-analyzer analyze --repo "my-org/terraform-aws-vpc"
+	
+	# Analyze a monorepo or checkout already on disk (no GitHub token needed)
+	tf-analyzer analyze --local-path ./checkouts
+	tf-analyzer analyze --local-path ./infra --single-repo
+	
 	# Export reports to specific directory
 	tf-analyzer analyze --orgs "my-org" --output-dir ./custom-reports
 	
+	# Order repositories with the most untagged resources first
+	tf-analyzer analyze --orgs "my-org" --sort-reports-by untagged
+	
+	# Keep the findings detail small for huge runs; totals still count everything
+	tf-analyzer analyze --orgs "my-org" --max-total-findings 500
+	
+	# Stream each repository result to JSON Lines while a long run is in progress
+	tf-analyzer analyze --orgs "my-org" --stream-output results.jsonl
+	
+	# Export module usage as a Graphviz graph and render it
+	tf-analyzer analyze --orgs "my-org" --graph modules.dot && dot -Tsvg modules.dot -o modules.svg
+	
+	# Process priority organizations first so an interrupted run still covers them
+	tf-analyzer analyze --orgs "org1,org2,org3" --org-order "org3,org1"
+	
+	# Clone and analyze two organizations at a time
+	tf-analyzer analyze --orgs "org1,org2,org3,org4" --org-concurrency 2
+	
+	# Fail instead of writing an empty report when an organization has no repositories
+	tf-analyzer analyze --orgs "my-org" --require-repos
+	
+	# Export only findings (untagged, unpinned, secrets, violations) as flat JSON
+	tf-analyzer analyze --orgs "my-org" --format findings-json
+	
+	# Write only cross-organization totals and per-organization rollups for dashboards
+	tf-analyzer analyze --orgs "org1,org2" --format summary
+	
+	# Export tag findings as SARIF for GitHub code scanning
+	tf-analyzer analyze --orgs "my-org" --format sarif
+	
+	# Write a self-contained HTML report to share with stakeholders
+	tf-analyzer analyze --orgs "my-org" --format html
+	
+	# Write the comprehensive report as YAML (or TOML) for tools that prefer it over JSON
+	tf-analyzer analyze --orgs "my-org" --format yaml
+	
+	# Write metrics for the node_exporter textfile collector from a scheduled run
+	tf-analyzer analyze --orgs "my-org" --format prometheus --output-dir /var/lib/node_exporter/textfile
+	
+	# Skip re-analyzing repositories whose HEAD commit has not changed since the last run
+	tf-analyzer analyze --orgs "my-org" --cache
+	
+	# Record run inputs and report hashes in run-manifest.json
+	tf-analyzer analyze --orgs "my-org" --write-manifest
+	
+	# Keep every run's reports in ./reports/YYYYMMDD-HHMMSS instead of overwriting them
+	tf-analyzer analyze --orgs "my-org" --output-dir ./reports --timestamped-output
+	
+	# Name reports per organization and day, e.g. my-org-2026-10-14.json
+	tf-analyzer analyze --orgs "my-org" --report-prefix "{org}-{date}"
+	
+	# Also write terraform-analysis-report-<org>.json etc. for each organization
+	tf-analyzer analyze --orgs "org1,org2" --split-by-org
+	
+	# Write only the per-organization reports
+	tf-analyzer analyze --orgs "org1,org2" --split-by-org --split-only
+	
+	# POST the run summary to a dashboard when the run finishes
+	tf-analyzer analyze --orgs "my-org" --webhook-url https://dash.example.com/hooks/tf --webhook-header "Authorization: Bearer $TOKEN"
+	
+	# Log "processed X/Y repos" every 10 seconds in CI output
+	tf-analyzer analyze --orgs "my-org" --progress-interval 10s
+	
+	# Only analyze repositories with commits in the last 30 days
+	tf-analyzer analyze --orgs "my-org" --since 30d
+	
 	# Verbose logging for debugging
 	tf-analyzer analyze --orgs "test-org" --verbose
+	
+	# Only log errors and skip the console summary; report files are still written
+	tf-analyzer analyze --orgs "my-org" --format json --quiet
+	
+	# Print only the provider inventory (use --format json for JSON)
+	tf-analyzer analyze --orgs "my-org" --list-providers
+	
+	# Only check that every Terraform file parses (exits non-zero on failures)
+	tf-analyzer analyze --orgs "my-org" --validate-only
+	
+	# Preview which repositories the targeting flags select, without analyzing them
+	tf-analyzer analyze --orgs "my-org" --target-repos "api,web" --dry-run
+	
+	# Flag hardcoded credentials in attributes, heredocs, encoded literals and .tfvars
+	tf-analyzer analyze --orgs "my-org" --scan-secrets
+	
+	# Report how many releases each provider constraint is behind the registry's latest
+	tf-analyzer analyze --orgs "my-org" --check-latest
+	
+	# Skip vendored examples and fixtures (repositories can also list patterns in .tfanalyzerignore)
+	tf-analyzer analyze --orgs "my-org" --ignore "examples/" --ignore "**/test/fixtures/**"
+	
+	# Also analyze files with a custom extension
+	tf-analyzer analyze --orgs "my-org" --include-ext .tfmodule
+	
+	# Only check tagging compliance and the backend, skipping every other section
+	tf-analyzer analyze --orgs "my-org" --analyze tags,backend
+	
+	# Enforce your organization's own tagging policy
+	tf-analyzer analyze --orgs "my-org" --mandatory-tags "Team,Service"
+	
+	# Accept tag keys that differ from the mandatory tags only in case
+	tf-analyzer analyze --orgs "my-org" --tags-case-insensitive
+	
+	# Apply compliance policies declared in a single YAML file
+	tf-analyzer analyze --orgs "my-org" --compliance-config ./compliance.yaml
+	
+	# Fail a CI pipeline when more than 10 resources are untagged or a provider is unpinned
+	tf-analyzer analyze --orgs "my-org" --fail-on-untagged 10 --fail-on-missing-provider-version
+	
+	# Fail when fewer than 80% of providers are pinned with ~> or an exact version
+	tf-analyzer analyze --orgs "my-org" --require-pinned-providers --min-pinned-pct 80
+	
+	# Fail when a resource type listed in compliance.deprecated_resources is still used
+	tf-analyzer analyze --orgs "my-org" --config .tf-analyzer.yaml --fail-on-deprecated
+	
+	# Apply the "strict" profile from the config file over its base settings
+	tf-analyzer analyze --orgs "my-org" --config .tf-analyzer.yaml --profile strict
+
+## Exit Codes
+
+• 0: Analysis completed and every threshold passed
+• 1: Analysis or configuration error
+• 2: A --fail-on-untagged, --fail-on-missing-provider-version, --require-pinned-providers or --fail-on-deprecated threshold was exceeded (reports are still written)
+
+## Repository Targeting
+
+• --repo, --target-repos and --target-repos-file clone exactly the listed repositories and cannot be combined with name filters
+• Otherwise --match-regex or --match-prefix selects repositories first, then --exclude-regex or --exclude-prefix removes from that selection
+• Each filter accepts either a regex or prefixes, not both; regexes are compiled before cloning starts
 
 ## Configuration
 
@@ -129,7 +430,7 @@ You can specify configuration via:
 • Configuration file (.tf-analyzer.yaml)
 
 Environment variables:
-• GITHUB_TOKEN: GitHub API token (required)
+• GITHUB_TOKEN: GitHub API token (required unless --token or --token-file is given)
 • GITHUB_ORGS: Comma-separated list of organizations
 • MAX_GOROUTINES: Maximum concurrent goroutines (default: ` + fmt.Sprintf("%d", DefaultMaxGoroutines) + `)
 • CLONE_CONCURRENCY: Clone concurrency limit (default: ` + fmt.Sprintf("%d", DefaultCloneConcurrency) + `)
@@ -159,6 +460,28 @@ Manage tf-analyzer configuration files and settings.
 	`,
 }
 
+// schemaCmd prints the JSON Schema of the JSON report
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of the JSON report",
+	Long: `
+# Report Schema
+
+Print the JSON Schema (draft 2020-12) describing terraform-analysis-report.json,
+generated from the same types the report is written from.
+
+## Examples
+
+	# Print the schema
+	tf-analyzer schema
+	
+	# Write the schema to a file
+	tf-analyzer schema --output report.schema.json
+	`,
+	Args: cobra.NoArgs,
+	RunE: printSchema,
+}
+
 var configShowCmd = &cobra.Command{
 	Use:   "show",
 	Short: "Show current configuration",
@@ -181,7 +504,10 @@ func init() {
 	cobra.OnInitialize(initializeConfig)
 	initializeGlobalFlags()
 	initializeAnalyzeFlags()
+	schemaCmd.Flags().StringVarP(&schemaOutput, "output", "o", "", "write the schema to this file instead of stdout")
+	initializeServeFlags()
 	bindViperFlags()
+	registerFlagCompletions()
 	setupCommands()
 }
 
@@ -190,21 +516,53 @@ func initializeGlobalFlags() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.tf-analyzer.yaml)")
 	rootCmd.PersistentFlags().StringVar(&envFile, "env-file", ".env", "environment file path (default is .env in current directory)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "only log errors and skip console summaries (--verbose wins)")
 }
 
 // initializeAnalyzeFlags sets up flags specific to the analyze command
 func initializeAnalyzeFlags() {
 	analyzeCmd.Flags().StringSliceVarP(&organizations, "orgs", "o", []string{}, "GitHub organizations to analyze (space or comma-separated)")
 	analyzeCmd.Flags().StringVarP(&githubToken, "token", "t", "", "GitHub API token")
+	analyzeCmd.Flags().StringVar(&tokenFile, "token-file", "", "read the GitHub API token from this file, keeping it out of shell history and process lists (--token still wins)")
+	analyzeCmd.Flags().StringVar(&scmProvider, "scm-provider", SCMProviderGitHub, "hosting provider to clone from: github or gitlab (--token is used for either)")
 	analyzeCmd.Flags().IntVar(&maxGoroutines, "max-goroutines", DefaultMaxGoroutines, "maximum concurrent goroutines")
 	analyzeCmd.Flags().IntVar(&cloneConcurrency, "clone-concurrency", DefaultCloneConcurrency, "clone concurrency limit")
+	analyzeCmd.Flags().StringVar(&clonesDir, "clones-dir", DefaultClonesDir, "base directory for cloned repositories; each organization clones into a new subdirectory")
+	analyzeCmd.Flags().BoolVar(&keepClones, "keep-clones", false, "leave cloned repositories on disk after analysis instead of removing them")
+	analyzeCmd.Flags().BoolVar(&autoConcurrencyFlag, "auto-concurrency", false, fmt.Sprintf("derive --max-goroutines and --clone-concurrency from the CPU count (%d per CPU) unless set explicitly", AutoConcurrencyPerCPU))
+	analyzeCmd.Flags().IntVar(&fileReadConcurrency, "file-read-concurrency", DefaultFileReadConcurrency, "files read ahead of the parser per repository (1 reads serially)")
+	analyzeCmd.Flags().IntVar(&maxRetries, "max-retries", DefaultMaxRetries, "retries of a transient clone failure (network errors, 5xx, rate limits) with exponential backoff")
 	analyzeCmd.Flags().DurationVar(&timeout, "timeout", DefaultProcessTimeout, "processing timeout")
-	analyzeCmd.Flags().StringVar(&outputFormat, "format", "all", "output format: json, csv, markdown, or all")
+	analyzeCmd.Flags().DurationVar(&progressInterval, "progress-interval", DefaultProgressInterval, "how often to log processed/total repositories for long runs (0 disables)")
+	analyzeCmd.Flags().DurationVar(&repoTimeout, "repo-timeout", 0, "timeout for each repository's analysis; a repository exceeding it fails without stopping the run (0 disables)")
+	analyzeCmd.Flags().BoolVar(&timeoutAsWarning, "timeout-as-warning", false, "record repositories that hit the timeout as warnings with partial results instead of failures")
+	analyzeCmd.Flags().BoolVar(&requireRepos, "require-repos", false, "fail when any organization yields zero repositories")
+	analyzeCmd.Flags().StringVar(&orgOrder, "org-order", OrgOrderAsListed, "organization processing order: as-listed, alpha, or a comma-separated priority list")
+	analyzeCmd.Flags().IntVar(&orgConcurrency, "org-concurrency", DefaultOrgConcurrency, fmt.Sprintf("organizations cloned and analyzed at the same time (max %d)", MaxSafeOrgConcurrency))
+	analyzeCmd.Flags().StringVar(&outputFormat, "format", "all", "output format: json, csv, markdown, html, findings-json, sarif, prometheus, summary, yaml, toml, or all")
 	analyzeCmd.Flags().StringVar(&outputDir, "output-dir", ".", "output directory for reports")
 	analyzeCmd.Flags().StringVar(&markdownStyle, "markdown-style", "auto", "markdown rendering style: auto, dark, light, notty")
 	analyzeCmd.Flags().BoolVar(&rawMarkdown, "raw-markdown", false, "print raw markdown without glamour rendering")
+	analyzeCmd.Flags().StringVar(&sortReportsBy, "sort-reports-by", SortByOrg, "repository order in reports: "+strings.Join(validSortKeys, ", "))
+	analyzeCmd.Flags().BoolVar(&cacheEnabled, "cache", false, "reuse cached analyses of repositories whose HEAD commit is unchanged")
+	analyzeCmd.Flags().BoolVar(&noCache, "no-cache", false, "disable the analysis cache even if enabled in the config file")
+	analyzeCmd.Flags().StringVar(&cacheDir, "cache-dir", "", "analysis cache directory (default is the user cache directory, e.g. ~/.cache/tf-analyzer)")
+	analyzeCmd.Flags().BoolVar(&writeManifest, "write-manifest", false, "write run-manifest.json with run inputs and report hashes next to the reports")
+	analyzeCmd.Flags().BoolVar(&timestampedOutput, "timestamped-output", false, "write reports into a new YYYYMMDD-HHMMSS subdirectory of --output-dir to keep earlier runs")
+	analyzeCmd.Flags().StringVar(&reportPrefix, "report-prefix", DefaultReportPrefix, "base name of the JSON, CSV, Markdown, HTML and SARIF reports; {org} and {date} (YYYY-MM-DD) are expanded")
+	analyzeCmd.Flags().BoolVar(&splitByOrg, "split-by-org", false, "also write the prefix-named reports once per organization, named <prefix>-<org>, or <prefix>-split when the prefix holds {org}")
+	analyzeCmd.Flags().BoolVar(&splitOnly, "split-only", false, "with --split-by-org, skip the combined reports")
+	analyzeCmd.Flags().StringVar(&webhookURL, "webhook-url", "", "POST the summary JSON to this URL when the run finishes; failures only log a warning")
+	analyzeCmd.Flags().StringArrayVar(&webhookHeaders, "webhook-header", []string{}, "\"Name: Value\" header sent with the webhook (repeatable)")
+	analyzeCmd.Flags().DurationVar(&webhookTimeout, "webhook-timeout", DefaultWebhookTimeout, "timeout for the webhook request")
+	analyzeCmd.Flags().IntVar(&maxTotalFindings, "max-total-findings", 0, "cap the findings detail across all repositories (totals stay accurate; 0 is unlimited)")
+	analyzeCmd.Flags().StringVar(&streamOutput, "stream-output", "", "append each repository result to this JSON Lines file as soon as it completes")
+	analyzeCmd.Flags().StringVar(&graphOutput, "graph", "", "write module usage across repositories to this Graphviz DOT file")
+	analyzeCmd.Flags().BoolVar(&printConfig, "print-config", false, "print the resolved configuration with the origin of each value and exit")
+	analyzeCmd.Flags().BoolVar(&configSchemaCheck, "config-schema-check", false, "fail when the config file has unknown keys, as \"config validate\" does")
 
 	// Repository targeting flags for ghorg integration
+	analyzeCmd.Flags().StringVar(&singleRepoRef, "repo", "", "clone and analyze a single repository given as <org>/<name>")
 	analyzeCmd.Flags().StringSliceVar(&targetRepos, "target-repos", []string{}, "comma-separated list of specific repositories to clone")
 	analyzeCmd.Flags().StringVar(&targetReposFile, "target-repos-file", "", "path to file containing repository names (one per line)")
 	analyzeCmd.Flags().StringVar(&matchRegex, "match-regex", "", "regex pattern to match repository names")
@@ -212,34 +570,113 @@ func initializeAnalyzeFlags() {
 	analyzeCmd.Flags().StringVar(&excludeRegex, "exclude-regex", "", "regex pattern to exclude repository names")
 	analyzeCmd.Flags().StringSliceVar(&excludePrefix, "exclude-prefix", []string{}, "comma-separated prefixes to exclude repository names")
 
-	// Mark required flags
-	if err := analyzeCmd.MarkFlagRequired("orgs"); err != nil {
-		panic(fmt.Sprintf("Failed to mark orgs flag as required: %v", err))
-	}
+	// Analysis mode flags
+	analyzeCmd.Flags().BoolVar(&listProviders, "list-providers", false, "only inventory providers and print them instead of writing reports")
+	analyzeCmd.Flags().BoolVar(&secretScanning, "scan-secrets", false, "scan resource attributes and .tfvars files for hardcoded credentials")
+	analyzeCmd.Flags().BoolVar(&checkLatest, "check-latest", false, "query the Terraform Registry for each provider's latest version and report how far behind constraints are")
+	analyzeCmd.Flags().BoolVar(&validateOnly, "validate-only", false, "only check that Terraform files parse and list the ones that fail")
+	analyzeCmd.Flags().BoolVar(&dryRun, "dry-run", false, "list the repositories that would be analyzed and exit (name filters still clone to resolve)")
+	analyzeCmd.Flags().StringVar(&since, "since", "", "only analyze repositories with a commit in this window, e.g. 30d or 72h")
+	analyzeCmd.Flags().StringArrayVar(&ignorePatterns, "ignore", []string{}, "gitignore-style path pattern to skip in every repository (repeatable; adds to "+IgnoreFileName+")")
+	analyzeCmd.Flags().StringSliceVar(&analyze, "analyze", []string{}, "analysis sections to run (repeatable or comma-separated; default all): "+strings.Join(analysisSections, ", "))
+	analyzeCmd.Flags().StringArrayVar(&includeExt, "include-ext", []string{}, "additional file extension to analyze, e.g. .tfmodule (repeatable; adds to .tf, .tf.json, .tfvars and .hcl)")
+
+	// Compliance flags
+	analyzeCmd.Flags().StringVar(&configProfile, "profile", "", "named profile from the config file's profiles section to merge over its base settings")
+	analyzeCmd.Flags().StringVar(&complianceConfig, "compliance-config", "", "path to a YAML file declaring compliance policies")
+	analyzeCmd.Flags().IntVar(&failOnUntagged, "fail-on-untagged", FailOnUntaggedDisabled, "exit with code 2 when more than N resources are untagged (negative disables)")
+	analyzeCmd.Flags().BoolVar(&failOnMissingProviderVersion, "fail-on-missing-provider-version", false, "exit with code 2 when any provider has no version constraint")
+	analyzeCmd.Flags().BoolVar(&requirePinnedProviders, "require-pinned-providers", false, "exit with code 2 when fewer than --min-pinned-pct percent of providers are pinned")
+	analyzeCmd.Flags().IntVar(&minPinnedPct, "min-pinned-pct", DefaultMinPinnedPct, "percentage of providers that must be pinned for --require-pinned-providers (0-100)")
+	analyzeCmd.Flags().BoolVar(&failOnDeprecated, "fail-on-deprecated", false, "exit with code 2 when any resource type listed in compliance.deprecated_resources is used")
+	analyzeCmd.Flags().BoolVar(&tagsCaseInsensitive, "tags-case-insensitive", false, "match resource tag keys against mandatory tags ignoring case")
+	analyzeCmd.Flags().StringSliceVar(&mandatoryTags, "mandatory-tags", []string{}, "comma-separated tags every resource must carry (default "+strings.Join(defaultMandatoryTags, ",")+")")
+
+	// Local analysis flags
+	analyzeCmd.Flags().StringVar(&localPath, "local-path", "", "analyze repositories already on disk instead of cloning (each subdirectory is a repository)")
+	analyzeCmd.Flags().BoolVar(&singleRepo, "single-repo", false, "with --local-path, analyze the path itself as a single repository")
+
+	// Either organizations to clone, a single repository or a local path is required
+	analyzeCmd.MarkFlagsOneRequired("orgs", "repo", "local-path")
+	analyzeCmd.MarkFlagsMutuallyExclusive("orgs", "repo", "local-path")
+	analyzeCmd.MarkFlagsMutuallyExclusive("repo", "target-repos", "target-repos-file")
+	analyzeCmd.MarkFlagsMutuallyExclusive("cache", "no-cache")
+}
+
+// analyzeFlagBindings maps analyze command flags to their viper keys
+var analyzeFlagBindings = map[string]string{
+	"orgs":                  "organizations",
+	"profile":               "profile",
+	"token":                 "github.token",
+	"token-file":            "github.token_file",
+	"scm-provider":          "github.scm_provider",
+	"max-goroutines":        "processing.max_goroutines",
+	"clone-concurrency":     "processing.clone_concurrency",
+	"clones-dir":            "processing.clones_dir",
+	"keep-clones":           "processing.keep_clones",
+	"auto-concurrency":      "processing.auto_concurrency",
+	"file-read-concurrency": "processing.file_read_concurrency",
+	"max-retries":           "processing.max_retries",
+	"timeout":               "processing.timeout",
+	"progress-interval":     "processing.progress_interval",
+	"timeout-as-warning":    "processing.timeout_as_warning",
+	"repo-timeout":          "processing.repo_timeout",
+	"require-repos":         "processing.require_repos",
+	"org-order":             "processing.org_order",
+	"org-concurrency":       "processing.org_concurrency",
+	"local-path":            "local.path",
+	"single-repo":           "local.single_repo",
+	"format":                "output.format",
+	"output-dir":            "output.directory",
+	"markdown-style":        "ui.markdown_style",
+	"raw-markdown":          "ui.raw_markdown",
+	"write-manifest":        "output.write_manifest",
+	"timestamped-output":    "output.timestamped",
+	"report-prefix":         "output.report_prefix",
+	"split-by-org":          "output.split_by_org",
+	"split-only":            "output.split_only",
+	"webhook-url":           "output.webhook_url",
+	"webhook-header":        "output.webhook_headers",
+	"webhook-timeout":       "output.webhook_timeout",
+	"cache":                 "cache.enabled",
+	"no-cache":              "cache.disabled",
+	"cache-dir":             "cache.directory",
+	"sort-reports-by":       "output.sort_by",
+	"max-total-findings":    "output.max_total_findings",
+	"stream-output":         "output.stream_file",
+	"graph":                 "output.graph_file",
+	// Repository targeting flags
+	"repo":              "github.repo",
+	"target-repos":      "github.target_repos",
+	"target-repos-file": "github.target_repos_file",
+	"match-regex":       "github.match_regex",
+	"match-prefix":      "github.match_prefix",
+	"exclude-regex":     "github.exclude_regex",
+	"exclude-prefix":    "github.exclude_prefix",
+	// Analysis mode flags
+	"list-providers": "analysis.list_providers",
+	"scan-secrets":   "analysis.scan_secrets",
+	"check-latest":   "analysis.check_latest",
+	"validate-only":  "analysis.validate_only",
+	"dry-run":        "analysis.dry_run",
+	"ignore":         "analysis.ignore",
+	"include-ext":    "analysis.include_extensions",
+	"analyze":        "analysis.sections",
+	"since":          "analysis.since",
+	// Compliance flags
+	"compliance-config":                "compliance.config_file",
+	"mandatory-tags":                   "compliance.mandatory_tags",
+	"tags-case-insensitive":            "compliance.tags_case_insensitive",
+	"fail-on-untagged":                 "compliance.fail_on_untagged",
+	"fail-on-missing-provider-version": "compliance.fail_on_missing_provider_version",
+	"require-pinned-providers":         "compliance.require_pinned_providers",
+	"min-pinned-pct":                   "compliance.min_pinned_pct",
+	"fail-on-deprecated":               "compliance.fail_on_deprecated",
 }
 
 // bindViperFlags binds command flags to viper configuration
 func bindViperFlags() {
-	flagBindings := map[string]string{
-		"orgs":              "organizations",
-		"token":             "github.token",
-		"max-goroutines":    "processing.max_goroutines",
-		"clone-concurrency": "processing.clone_concurrency",
-		"timeout":           "processing.timeout",
-		"format":            "output.format",
-		"output-dir":        "output.directory",
-		"markdown-style":    "ui.markdown_style",
-		"raw-markdown":      "ui.raw_markdown",
-		// Repository targeting flags
-		"target-repos":      "github.target_repos",
-		"target-repos-file": "github.target_repos_file",
-		"match-regex":       "github.match_regex",
-		"match-prefix":      "github.match_prefix",
-		"exclude-regex":     "github.exclude_regex",
-		"exclude-prefix":    "github.exclude_prefix",
-	}
-
-	for flag, viperKey := range flagBindings {
+	for flag, viperKey := range analyzeFlagBindings {
 		if err := viper.BindPFlag(viperKey, analyzeCmd.Flags().Lookup(flag)); err != nil {
 			panic(fmt.Sprintf("Failed to bind %s flag: %v", flag, err))
 		}
@@ -249,7 +686,7 @@ func bindViperFlags() {
 // setupCommands adds all subcommands to the root command
 func setupCommands() {
 	configCmd.AddCommand(configShowCmd, configInitCmd, configValidateCmd)
-	rootCmd.AddCommand(analyzeCmd, configCmd)
+	rootCmd.AddCommand(analyzeCmd, configCmd, schemaCmd, serveCmd, completionCmd)
 }
 
 // initializeConfig loads configuration from files and environment
@@ -267,6 +704,11 @@ func loadEnvironmentFile() {
 		envFilePath = ".env"
 	}
 
+	// Local analysis needs no GitHub token, so the .env file is optional
+	if _, err := os.Stat(envFilePath); os.IsNotExist(err) && localPath != "" {
+		return
+	}
+
 	if err := loadRequiredEnvFile(envFilePath); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		fmt.Fprintf(os.Stderr, "Create an environment file at '%s' with required configuration.\n", envFilePath)
@@ -294,16 +736,17 @@ func setupViperConfig() {
 	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 }
 
+// envVarBindings maps viper keys to the unprefixed environment variables bound to them
+var envVarBindings = map[string]string{
+	"github.token":                 "GITHUB_TOKEN",
+	"organizations":                "GITHUB_ORGS",
+	"processing.max_goroutines":    "MAX_GOROUTINES",
+	"processing.clone_concurrency": "CLONE_CONCURRENCY",
+}
+
 // bindEnvironmentVariables binds specific environment variables
 func bindEnvironmentVariables() {
-	envBindings := map[string]string{
-		"github.token":                 "GITHUB_TOKEN",
-		"organizations":                "GITHUB_ORGS",
-		"processing.max_goroutines":    "MAX_GOROUTINES",
-		"processing.clone_concurrency": "CLONE_CONCURRENCY",
-	}
-
-	for viperKey, envVar := range envBindings {
+	for viperKey, envVar := range envVarBindings {
 		if err := viper.BindEnv(viperKey, envVar); err != nil {
 			panic(fmt.Sprintf("Failed to bind %s env var: %v", envVar, err))
 		}
@@ -318,42 +761,123 @@ func loadConfigFile() {
 }
 
 func runAnalyze(cmd *cobra.Command, args []string) error {
+	// Flags have parsed; a failed run or gate prints only its error, not the usage
+	cmd.SilenceUsage = true
+
+	if printConfig {
+		return printResolvedConfig(os.Stdout, cmd)
+	}
+
 	logger := setupAnalysisLogger()
 	config, err := prepareAnalysisConfig()
 	if err != nil {
 		return err
 	}
 
+	if config.DryRun {
+		return runDryRun(config, logger)
+	}
+
 	processingCtx, err := setupAnalysis(config, logger)
 	if err != nil {
 		return err
 	}
 	defer releaseProcessingContext(processingCtx)
 
-	ctx, cancel := context.WithTimeout(context.Background(), config.ProcessTimeout)
+	// Execute sets the command's context; commands run directly have none
+	parentCtx := cmd.Context()
+	if parentCtx == nil {
+		parentCtx = context.Background()
+	}
+
+	// SIGINT and SIGTERM cancel cloning and analysis; reports are still
+	// written for the repositories analyzed so far
+	signalCtx, stopSignals := signal.NotifyContext(parentCtx, os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+	ctx, cancel := context.WithTimeout(signalCtx, config.ProcessTimeout)
 	defer cancel()
 
 	reporter, analysisErr := executeAnalysisWorkflow(ctx, processingCtx)
+	// stopSignals cancels signalCtx, so check for an interrupt first
+	interrupted := signalCtx.Err() != nil
+	// A second signal while reports are written terminates immediately
+	stopSignals()
+	if interrupted {
+		logger.Warn("Analysis interrupted; writing reports for completed repositories")
+		analysisErr = errors.Join(ErrAnalysisInterrupted, analysisErr)
+	}
 
 	if analysisErr != nil {
 		logger.Error("Analysis completed with errors", "error", analysisErr)
 	}
 
+	if config.ValidateOnly {
+		if err := reporter.PrintValidationResults(os.Stdout); err != nil {
+			return err
+		}
+		return analysisErr
+	}
+
+	if config.ListProviders {
+		if err := reporter.PrintProviderInventory(viper.GetString("output.format")); err != nil {
+			return fmt.Errorf("failed to print provider inventory: %w", err)
+		}
+		return analysisErr
+	}
+
+	if config.CheckLatest {
+		// An interrupt stops the remaining registry lookups; reports are still written
+		checkCtx, stopCheck := signal.NotifyContext(parentCtx, os.Interrupt, syscall.SIGTERM)
+		checkLatestProviderVersions(checkCtx, reporter, newRegistryClient(DefaultRegistryURL, DefaultRegistryTimeout), logger)
+		stopCheck()
+	}
+
+	if err := reporter.SortResults(config.SortReportsBy); err != nil {
+		return fmt.Errorf("failed to sort results: %w", err)
+	}
+
 	if err := generateReports(reporter, config); err != nil {
 		return fmt.Errorf("failed to generate reports: %w", err)
 	}
 
-	if err := handleConsoleOutput(reporter, logger); err != nil {
-		logger.Error("Failed to display console output", "error", err)
+	if !quietOutput() {
+		if err := handleConsoleOutput(reporter, logger); err != nil {
+			logger.Error("Failed to display console output", "error", err)
+		}
+	}
+
+	notifyWebhook(reporter, config, logger)
+
+	// Thresholds are checked last so the reports are written even when the run fails
+	if thresholdErr := reporter.CheckComplianceThresholds(config); thresholdErr != nil {
+		logger.Error("Compliance thresholds exceeded", "error", thresholdErr)
+		if analysisErr == nil {
+			return thresholdErr
+		}
 	}
 
 	return analysisErr
 }
 
+// runDryRun prints the selected repositories before any processing context,
+// stream file or report is created
+func runDryRun(config Config, logger *slog.Logger) error {
+	ctx, cancel := context.WithTimeout(context.Background(), config.ProcessTimeout)
+	defer cancel()
+
+	repositories, err := dryRunRepositories(ctx, config, executeClonePhase, logger)
+	if err != nil {
+		return fmt.Errorf("failed to resolve repositories: %w", err)
+	}
+	return printDryRunPlan(os.Stdout, repositories)
+}
+
 func setupAnalysisLogger() *slog.Logger {
 	logLevel := slog.LevelInfo
 	if verbose {
-		_, _ = logLevel, slog.LevelDebug
+		logLevel = slog.LevelDebug
+	} else if quiet {
+		logLevel = slog.LevelError
 	}
 
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
@@ -361,9 +885,17 @@ func setupAnalysisLogger() *slog.Logger {
 		AddSource: verbose,
 	}))
 	slog.SetDefault(logger)
+	if verbose && quiet {
+		logger.Warn("--verbose and --quiet both set; using --verbose")
+	}
 	return logger
 }
 
+// quietOutput reports whether console summaries are skipped; --verbose wins over --quiet
+func quietOutput() bool {
+	return quiet && !verbose
+}
+
 func prepareAnalysisConfig() (Config, error) {
 	config, err := createConfigFromViper()
 	if err != nil {
@@ -387,6 +919,11 @@ func setupAnalysis(config Config, logger *slog.Logger) (ProcessingContext, error
 
 func executeAnalysisWorkflow(ctx context.Context, processingCtx ProcessingContext) (*Reporter, error) {
 	reporter := NewReporter()
+	reporter.SetMandatoryTags(analysisOptionsFromConfig(processingCtx.Config).requiredTags())
+	reporter.SetMaxTotalFindings(processingCtx.Config.MaxTotalFindings)
+	if processingCtx.Config.LocalPath != "" {
+		return reporter, analyzeLocalPath(ctx, processingCtx, reporter)
+	}
 	analysisErr := cloneAndAnalyzeMultipleOrgs(ctx, processingCtx, reporter)
 	return reporter, analysisErr
 }
@@ -405,14 +942,12 @@ func handleConsoleOutput(reporter *Reporter, logger *slog.Logger) error {
 
 func printMarkdownReport(reporter *Reporter, logger *slog.Logger) error {
 	if viper.GetBool("ui.raw_markdown") {
-		_ = reporter.PrintMarkdownToScreen
+		reporter.PrintMarkdownToScreen()
 		return nil
 	}
 
-	style := viper.GetString("ui.markdown_style")
-	if style == "auto" {
-		style = detectTerminalCapabilities()
-	}
+	style := resolveMarkdownStyle(viper.GetString("ui.markdown_style"))
+	logger.Debug("Rendering markdown report", "style", style)
 
 	if err := reporter.PrintMarkdownToScreenWithStyle(style); err != nil {
 		logger.Error("Failed to render markdown", "error", err)
@@ -444,7 +979,191 @@ func getStringSliceFromViper(key string) []string {
 	return []string{}
 }
 
+// resolveConcurrencyLimits applies --auto-concurrency for cpus to the limits
+// left at their defaults; a value from a flag, environment variable or config
+// file always wins over the derived one
+func resolveConcurrencyLimits(cpus int) (maxGoroutines, cloneConcurrency int) {
+	maxGoroutines = viper.GetInt("processing.max_goroutines")
+	cloneConcurrency = viper.GetInt("processing.clone_concurrency")
+	if !viper.GetBool("processing.auto_concurrency") {
+		return maxGoroutines, cloneConcurrency
+	}
+
+	autoGoroutines, autoClones := autoConcurrency(cpus)
+	if !viper.IsSet("processing.max_goroutines") {
+		maxGoroutines = autoGoroutines
+	}
+	if !viper.IsSet("processing.clone_concurrency") {
+		cloneConcurrency = autoClones
+	}
+	return maxGoroutines, cloneConcurrency
+}
+
+// applyConfigProfile merges the named profile from the config file's
+// profiles section over the base configuration. Flags and environment
+// variables still take precedence over the profile's settings.
+func applyConfigProfile(name string) error {
+	if name == "" {
+		return nil
+	}
+
+	profiles := viper.GetStringMap("profiles")
+	// viper lower-cases config keys, so profile names match case-insensitively
+	profile, ok := profiles[strings.ToLower(name)]
+	if !ok {
+		if len(profiles) == 0 {
+			return fmt.Errorf("unknown profile %q: the config file defines no profiles", name)
+		}
+		return fmt.Errorf("unknown profile %q (available: %s)", name, strings.Join(slices.Sorted(maps.Keys(profiles)), ", "))
+	}
+
+	settings, ok := profile.(map[string]any)
+	if !ok {
+		return fmt.Errorf("profile %q must be a map of configuration settings", name)
+	}
+	if _, nested := settings["profiles"]; nested {
+		return fmt.Errorf("profile %q cannot define profiles", name)
+	}
+	return viper.MergeConfigMap(settings)
+}
+
+// configFileOnlyKeys are settings with no flag or environment variable
+var configFileOnlyKeys = []string{"environment", "github.base_url", "github.skip_archived", "github.skip_forks"}
+
+// freeFormConfigKeys hold maps keyed by user data, such as resource type
+// globs, so only the key itself must be known
+var freeFormConfigKeys = []string{"analysis.provider_sources", "compliance.tag_rules", "compliance.deprecated_resources"}
+
+// knownConfigKey reports whether key is a setting tf-analyzer reads.
+// Settings inside profiles.<name> are checked like top-level settings.
+func knownConfigKey(key string) bool {
+	if rest, inProfile := strings.CutPrefix(key, "profiles."); inProfile {
+		_, key, inProfile = strings.Cut(rest, ".")
+		if !inProfile {
+			return true
+		}
+	}
+	if slices.ContainsFunc(freeFormConfigKeys, func(prefix string) bool {
+		return key == prefix || strings.HasPrefix(key, prefix+".")
+	}) {
+		return true
+	}
+	_, envKey := envVarBindings[key]
+	return envKey || slices.Contains(configFileOnlyKeys, key) || slices.Contains(slices.Collect(maps.Values(analyzeFlagBindings)), key)
+}
+
+// checkConfigFileKeys rejects config file keys tf-analyzer does not read,
+// which viper otherwise ignores, suggesting the closest known key for typos
+func checkConfigFileKeys(path string) error {
+	if path == "" {
+		return nil
+	}
+	file := viper.New()
+	file.SetConfigFile(path)
+	if err := file.ReadInConfig(); err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	var unknown []string
+	for _, key := range slices.Sorted(slices.Values(file.AllKeys())) {
+		if knownConfigKey(key) {
+			continue
+		}
+		if suggestion := closestConfigKey(key); suggestion != "" {
+			key += fmt.Sprintf(" (did you mean %s?)", suggestion)
+		}
+		unknown = append(unknown, key)
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("config file %s has unknown keys: %s", path, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// closestConfigKey returns the known key within two edits of key, if any
+func closestConfigKey(key string) string {
+	prefix := ""
+	if rest, inProfile := strings.CutPrefix(key, "profiles."); inProfile {
+		name, setting, _ := strings.Cut(rest, ".")
+		prefix, key = "profiles."+name+".", setting
+	}
+	candidates := slices.Concat(configFileOnlyKeys, freeFormConfigKeys, slices.Collect(maps.Keys(envVarBindings)), slices.Collect(maps.Values(analyzeFlagBindings)))
+	slices.Sort(candidates)
+
+	best, bestDistance := "", 3
+	for _, candidate := range candidates {
+		if distance := editDistance(key, candidate); distance < bestDistance {
+			best, bestDistance = prefix+candidate, distance
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			substitution := previous[j-1]
+			if a[i-1] != b[j-1] {
+				substitution++
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, substitution)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
+
+// resolveGitHubToken reads github.token_file, which takes precedence over
+// GITHUB_TOKEN and the config file's token but not over an explicit --token
+func resolveGitHubToken() (string, error) {
+	path := effectiveTokenFile()
+	if path == "" {
+		return viper.GetString("github.token"), nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read --token-file: %w", err)
+	}
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return "", fmt.Errorf("--token-file %s is empty", path)
+	}
+	return token, nil
+}
+
+// effectiveTokenFile returns the --token-file path when the token is read
+// from it, i.e. unless --token overrides it
+func effectiveTokenFile() string {
+	// The flag variable is only non-empty when --token was passed
+	if githubToken != "" {
+		return ""
+	}
+	return viper.GetString("github.token_file")
+}
+
 func createConfigFromViper() (Config, error) {
+	if configSchemaCheck {
+		if err := checkConfigFileKeys(viper.ConfigFileUsed()); err != nil {
+			return Config{}, err
+		}
+	}
+	if err := applyConfigProfile(viper.GetString("profile")); err != nil {
+		return Config{}, err
+	}
+
+	token, err := resolveGitHubToken()
+	if err != nil {
+		return Config{}, err
+	}
+
 	// Get organizations from viper
 	orgs := viper.GetStringSlice("organizations")
 	if len(orgs) == 0 {
@@ -466,32 +1185,153 @@ func createConfigFromViper() (Config, error) {
 	matchPrefix := getStringSliceFromViper("github.match_prefix")
 	excludePrefix := getStringSliceFromViper("github.exclude_prefix")
 
+	// --repo narrows the clone to one repository of one organization
+	repo := viper.GetString("github.repo")
+	if repo != "" {
+		if len(targetRepos) > 0 || viper.GetString("github.target_repos_file") != "" {
+			return Config{}, fmt.Errorf("--repo cannot be combined with --target-repos or --target-repos-file")
+		}
+		org, name, err := parseRepoReference(repo)
+		if err != nil {
+			return Config{}, err
+		}
+		orgs, targetRepos = []string{org}, []string{name}
+	}
+
+	maxGoroutines, cloneConcurrency := resolveConcurrencyLimits(runtime.NumCPU())
+
+	sinceDuration, err := parseSinceDuration(viper.GetString("analysis.since"))
+	if err != nil {
+		return Config{}, err
+	}
+
+	includeExtensions, err := normalizeIncludeExtensions(viper.GetStringSlice("analysis.include_extensions"))
+	if err != nil {
+		return Config{}, err
+	}
+
+	analyzeSections, err := normalizeAnalysisSections(getStringSliceFromViper("analysis.sections"))
+	if err != nil {
+		return Config{}, err
+	}
+
+	failOnUntagged := FailOnUntaggedDisabled
+	if viper.IsSet("compliance.fail_on_untagged") {
+		failOnUntagged = viper.GetInt("compliance.fail_on_untagged")
+	}
+
+	cacheEnabled := viper.GetBool("cache.enabled") && !viper.GetBool("cache.disabled")
+	cacheDir := viper.GetString("cache.directory")
+	if cacheEnabled && cacheDir == "" {
+		dir, err := defaultCacheDir()
+		if err != nil {
+			return Config{}, err
+		}
+		cacheDir = dir
+	}
+
+	complianceConfigFile := viper.GetString("compliance.config_file")
+	var compliancePolicy CompliancePolicy
+	if complianceConfigFile != "" {
+		policy, err := loadCompliancePolicy(complianceConfigFile)
+		if err != nil {
+			return Config{}, err
+		}
+		compliancePolicy = policy
+	}
+
 	return Config{
-		Organizations:    orgs,
-		GitHubToken:      viper.GetString("github.token"),
-		MaxGoroutines:    viper.GetInt("processing.max_goroutines"),
-		CloneConcurrency: viper.GetInt("processing.clone_concurrency"),
-		ProcessTimeout:   viper.GetDuration("processing.timeout"),
-		RetryDelay:       retryDelay,
-		SkipArchived:     viper.GetBool("github.skip_archived"),
-		SkipForks:        viper.GetBool("github.skip_forks"),
-		BaseURL:          viper.GetString("github.base_url"),
+		Organizations:       orgs,
+		GitHubToken:         token,
+		SCMProvider:         viper.GetString("github.scm_provider"),
+		MaxGoroutines:       maxGoroutines,
+		CloneConcurrency:    cloneConcurrency,
+		FileReadConcurrency: viper.GetInt("processing.file_read_concurrency"),
+		MaxRetries:          viper.GetInt("processing.max_retries"),
+		ProcessTimeout:      viper.GetDuration("processing.timeout"),
+		ProgressInterval:    viper.GetDuration("processing.progress_interval"),
+		TimeoutAsWarning:    viper.GetBool("processing.timeout_as_warning"),
+		RepoTimeout:         viper.GetDuration("processing.repo_timeout"),
+		RequireRepos:        viper.GetBool("processing.require_repos"),
+		OrgOrder:            viper.GetString("processing.org_order"),
+		OrgConcurrency:      viper.GetInt("processing.org_concurrency"),
+		ClonesDir:           viper.GetString("processing.clones_dir"),
+		KeepClones:          viper.GetBool("processing.keep_clones"),
+		RetryDelay:          retryDelay,
+		LocalPath:           viper.GetString("local.path"),
+		SingleRepo:          viper.GetBool("local.single_repo"),
+		CacheEnabled:        cacheEnabled,
+		CacheDir:            cacheDir,
+		SkipArchived:        viper.GetBool("github.skip_archived"),
+		SkipForks:           viper.GetBool("github.skip_forks"),
+		BaseURL:             normalizeBaseURLOrKeep(viper.GetString("github.base_url")),
 		// Repository targeting options
+		Repo:            repo,
 		TargetRepos:     targetRepos,
 		TargetReposFile: viper.GetString("github.target_repos_file"),
 		MatchRegex:      viper.GetString("github.match_regex"),
 		MatchPrefix:     matchPrefix,
 		ExcludeRegex:    viper.GetString("github.exclude_regex"),
 		ExcludePrefix:   excludePrefix,
+		// Analysis options
+		ListProviders:     viper.GetBool("analysis.list_providers"),
+		ScanSecrets:       viper.GetBool("analysis.scan_secrets"),
+		CheckLatest:       viper.GetBool("analysis.check_latest"),
+		ValidateOnly:      viper.GetBool("analysis.validate_only"),
+		DryRun:            viper.GetBool("analysis.dry_run"),
+		IgnorePatterns:    viper.GetStringSlice("analysis.ignore"),
+		IncludeExtensions: includeExtensions,
+		AnalyzeSections:   analyzeSections,
+		Since:             sinceDuration,
+		// Provider block labels are lower-case, matching viper's map keys
+		ProviderSources: viper.GetStringMapString("analysis.provider_sources"),
+		// Output options
+		WriteManifest:     viper.GetBool("output.write_manifest"),
+		SortReportsBy:     viper.GetString("output.sort_by"),
+		MaxTotalFindings:  viper.GetInt("output.max_total_findings"),
+		StreamOutput:      viper.GetString("output.stream_file"),
+		GraphOutput:       viper.GetString("output.graph_file"),
+		TimestampedOutput: viper.GetBool("output.timestamped"),
+		ReportPrefix:      viper.GetString("output.report_prefix"),
+		SplitByOrg:        viper.GetBool("output.split_by_org"),
+		SplitOnly:         viper.GetBool("output.split_only"),
+		WebhookURL:        viper.GetString("output.webhook_url"),
+		WebhookHeaders:    viper.GetStringSlice("output.webhook_headers"),
+		WebhookTimeout:    viper.GetDuration("output.webhook_timeout"),
+		// Compliance options
+		ComplianceConfigFile:         complianceConfigFile,
+		MandatoryTags:                getStringSliceFromViper("compliance.mandatory_tags"),
+		TagsCaseInsensitive:          viper.GetBool("compliance.tags_case_insensitive"),
+		TagRules:                     viper.GetStringMapStringSlice("compliance.tag_rules"),
+		FailOnUntagged:               failOnUntagged,
+		FailOnMissingProviderVersion: viper.GetBool("compliance.fail_on_missing_provider_version"),
+		RequirePinnedProviders:       viper.GetBool("compliance.require_pinned_providers"),
+		MinPinnedPct:                 viper.GetInt("compliance.min_pinned_pct"),
+		DeprecatedResources:          viper.GetStringMapString("compliance.deprecated_resources"),
+		FailOnDeprecated:             viper.GetBool("compliance.fail_on_deprecated"),
+		Compliance:                   compliancePolicy,
 	}, nil
 }
 
 func validateCLIAnalysisConfig(config Config) error {
-	if len(config.Organizations) == 0 {
-		return fmt.Errorf("at least one organization must be specified")
+	if config.SingleRepo && config.LocalPath == "" {
+		return fmt.Errorf("--single-repo requires --local-path")
+	}
+	if config.LocalPath == "" {
+		if len(config.Organizations) == 0 {
+			return fmt.Errorf("at least one organization must be specified")
+		}
+		if config.GitHubToken == "" {
+			return fmt.Errorf("GitHub token is required (set GITHUB_TOKEN or use --token or --token-file)")
+		}
 	}
-	if config.GitHubToken == "" {
-		return fmt.Errorf("GitHub token is required (set GITHUB_TOKEN or use --token)")
+
+	if _, err := resolveSCMProvider(config.SCMProvider); err != nil {
+		return err
+	}
+
+	if _, err := normalizeBaseURL(config.BaseURL); err != nil {
+		return err
 	}
 
 	// Validate targeting configuration
@@ -499,18 +1339,185 @@ func validateCLIAnalysisConfig(config Config) error {
 		return err
 	}
 
+	if _, err := resultComparator(config.SortReportsBy); err != nil {
+		return err
+	}
+
+	if err := validateReportPrefix(config.ReportPrefix); err != nil {
+		return err
+	}
+	if config.SplitOnly && !config.SplitByOrg {
+		return fmt.Errorf("--split-only requires --split-by-org")
+	}
+	if err := validateWebhookConfig(config); err != nil {
+		return err
+	}
+
+	if config.MinPinnedPct < 0 || config.MinPinnedPct > 100 {
+		return fmt.Errorf("--min-pinned-pct must be between 0 and 100, got %d", config.MinPinnedPct)
+	}
+
+	if config.MaxTotalFindings < 0 {
+		return fmt.Errorf("--max-total-findings must not be negative, got %d", config.MaxTotalFindings)
+	}
+
+	if _, err := orderOrganizations(config.Organizations, config.OrgOrder); err != nil {
+		return err
+	}
+
+	if err := validateTagRules(config.TagRules); err != nil {
+		return err
+	}
+	if err := validateProviderSources(config.ProviderSources); err != nil {
+		return err
+	}
+
+	if err := validateIgnorePatterns(config.IgnorePatterns); err != nil {
+		return err
+	}
+
+	if config.ValidateOnly && config.ListProviders {
+		return fmt.Errorf("--validate-only and --list-providers cannot be used together")
+	}
+
 	return validateAnalysisConfiguration(config)
 }
 
 func generateReports(reporter *Reporter, config Config) error {
 	format := viper.GetString("output.format")
-	outputDir := viper.GetString("output.directory")
-
-	if err := ensureOutputDirectory(outputDir); err != nil {
+	now := time.Now()
+	outputDir, err := resolveOutputDirectory(viper.GetString("output.directory"), config.TimestampedOutput, now)
+	if err != nil {
 		return err
 	}
+	if config.TimestampedOutput {
+		slog.Info("Writing reports to timestamped directory", "directory", outputDir)
+	}
+
+	var paths []string
+	if !config.SplitOnly {
+		prefix := expandReportPrefix(config.ReportPrefix, reportOrganizations(config), now)
+		if err := generateReportsByFormat(reporter, format, outputDir, prefix); err != nil {
+			return err
+		}
+		paths = generatedReportPaths(format, outputDir, prefix)
+	}
+
+	if config.SplitByOrg {
+		for _, org := range reporter.Organizations() {
+			prefix := organizationReportPrefix(config.ReportPrefix, org, now, !config.SplitOnly)
+			orgPaths, err := generateOrganizationReports(reporter.ForOrganization(org), format, outputDir, prefix)
+			if err != nil {
+				return fmt.Errorf("failed to generate reports for organization %s: %w", org, err)
+			}
+			paths = append(paths, orgPaths...)
+		}
+	}
+
+	if config.GraphOutput != "" {
+		if err := reporter.ExportModuleGraph(config.GraphOutput); err != nil {
+			return err
+		}
+		paths = append(paths, config.GraphOutput)
+	}
+
+	if config.WriteManifest {
+		return writeRunManifest(config, paths, outputDir)
+	}
+	return nil
+}
+
+// organizationReport is a --split-by-org format; only the formats named after
+// the report prefix are split, the fixed-name files stay combined
+type organizationReport struct {
+	extension string
+	selected  func(format string) bool
+	export    func(reporter *Reporter, filename string) error
+}
 
-	return generateReportsByFormat(reporter, format, outputDir)
+var organizationReports = []organizationReport{
+	{".json", shouldGenerateJSON, (*Reporter).ExportJSON},
+	{".csv", shouldGenerateCSV, (*Reporter).ExportCSV},
+	{".md", shouldGenerateMarkdown, (*Reporter).ExportMarkdown},
+	{".html", shouldGenerateHTML, (*Reporter).ExportHTML},
+	{".sarif", shouldGenerateSARIF, (*Reporter).ExportSARIF},
+	{".yaml", shouldGenerateYAML, (*Reporter).ExportYAML},
+	{".toml", shouldGenerateTOML, (*Reporter).ExportTOML},
+}
+
+// generateOrganizationReports writes one organization's reports and returns their paths
+func generateOrganizationReports(reporter *Reporter, format, outputDir, prefix string) ([]string, error) {
+	var paths []string
+	for _, report := range organizationReports {
+		if !report.selected(format) {
+			continue
+		}
+		path := filepath.Join(outputDir, prefix+report.extension)
+		if err := report.export(reporter, path); err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// organizationReportPrefix names one organization's reports: {org} in the
+// prefix is replaced by the organization, otherwise "-<org>" is appended.
+// Alongside the combined reports, a prefix with {org} also gets
+// SplitReportSuffix, since for a single organization both names would match.
+// GitLab subgroup separators become "-" to keep the file in the output directory.
+func organizationReportPrefix(prefix, org string, now time.Time, combined bool) string {
+	org = strings.ReplaceAll(org, "/", "-")
+	if prefix == "" {
+		prefix = DefaultReportPrefix
+	}
+	if !strings.Contains(prefix, ReportPrefixOrgPlaceholder) {
+		prefix += "-" + ReportPrefixOrgPlaceholder
+	} else if combined {
+		prefix += SplitReportSuffix
+	}
+	return expandReportPrefix(prefix, []string{org}, now)
+}
+
+// validateReportPrefix rejects prefixes that would write outside the output directory
+func validateReportPrefix(prefix string) error {
+	if strings.ContainsAny(prefix, `/\`) || prefix == "." || prefix == ".." {
+		return fmt.Errorf("--report-prefix must be a file name without path separators, got %q", prefix)
+	}
+	return nil
+}
+
+// reportOrganizations lists the organizations a run covers for {org}
+func reportOrganizations(config Config) []string {
+	if config.LocalPath != "" {
+		return []string{LocalOrganization}
+	}
+	return config.Organizations
+}
+
+// expandReportPrefix substitutes {org} with the analyzed organizations joined
+// by "-" and {date} with the run date; an empty prefix gives the default names
+func expandReportPrefix(prefix string, organizations []string, now time.Time) string {
+	if prefix == "" {
+		return DefaultReportPrefix
+	}
+	return strings.NewReplacer(
+		ReportPrefixOrgPlaceholder, strings.Join(organizations, "-"),
+		ReportPrefixDatePlaceholder, now.Format(ReportPrefixDateLayout),
+	).Replace(prefix)
+}
+
+// resolveOutputDirectory creates and returns the directory reports are written
+// to: outputDir itself, or with timestamped set a subdirectory named for now
+// so earlier runs are preserved
+func resolveOutputDirectory(outputDir string, timestamped bool, now time.Time) (string, error) {
+	if timestamped {
+		outputDir = filepath.Join(outputDir, now.Format(TimestampedOutputLayout))
+	}
+	if err := ensureOutputDirectory(outputDir); err != nil {
+		return "", err
+	}
+	return outputDir, nil
 }
 
 func ensureOutputDirectory(outputDir string) error {
@@ -520,21 +1527,65 @@ func ensureOutputDirectory(outputDir string) error {
 	return nil
 }
 
-func generateReportsByFormat(reporter *Reporter, format, outputDir string) error {
+// generateReportsByFormat writes the reports for format; prefix names the
+// JSON, CSV, Markdown, HTML and SARIF reports
+func generateReportsByFormat(reporter *Reporter, format, outputDir, prefix string) error {
 	if shouldGenerateJSON(format) {
-		if err := generateJSONReport(reporter, outputDir); err != nil {
+		if err := generateJSONReport(reporter, outputDir, prefix); err != nil {
 			return err
 		}
 	}
 
 	if shouldGenerateCSV(format) {
-		if err := generateCSVReport(reporter, outputDir); err != nil {
+		if err := generateCSVReport(reporter, outputDir, prefix); err != nil {
 			return err
 		}
 	}
 
 	if shouldGenerateMarkdown(format) {
-		if err := generateMarkdownReport(reporter, outputDir); err != nil {
+		if err := generateMarkdownReport(reporter, outputDir, prefix); err != nil {
+			return err
+		}
+	}
+
+	if shouldGenerateHTML(format) {
+		if err := generateHTMLReport(reporter, outputDir, prefix); err != nil {
+			return err
+		}
+	}
+
+	if shouldGenerateFindingsJSON(format) {
+		if err := generateFindingsJSONReport(reporter, outputDir, prefix); err != nil {
+			return err
+		}
+	}
+
+	if shouldGenerateSARIF(format) {
+		if err := generateSARIFReport(reporter, outputDir, prefix); err != nil {
+			return err
+		}
+	}
+
+	if shouldGeneratePrometheus(format) {
+		if err := generatePrometheusReport(reporter, outputDir, prefix); err != nil {
+			return err
+		}
+	}
+
+	if shouldGenerateSummaryJSON(format) {
+		if err := generateSummaryJSONReport(reporter, outputDir, prefix); err != nil {
+			return err
+		}
+	}
+
+	if shouldGenerateYAML(format) {
+		if err := generateYAMLReport(reporter, outputDir, prefix); err != nil {
+			return err
+		}
+	}
+
+	if shouldGenerateTOML(format) {
+		if err := generateTOMLReport(reporter, outputDir, prefix); err != nil {
 			return err
 		}
 	}
@@ -542,6 +1593,44 @@ func generateReportsByFormat(reporter *Reporter, format, outputDir string) error
 	return nil
 }
 
+// generatedReportPaths lists the report files produced for a format
+func generatedReportPaths(format, outputDir, prefix string) []string {
+	var paths []string
+	if shouldGenerateJSON(format) {
+		paths = append(paths, filepath.Join(outputDir, prefix+".json"))
+	}
+	if shouldGenerateCSV(format) {
+		paths = append(paths, filepath.Join(outputDir, prefix+".csv"))
+		paths = append(paths, suffixedReportPath(outputDir, prefix, ResourceCSVSuffix))
+		paths = append(paths, suffixedReportPath(outputDir, prefix, UntaggedCSVSuffix))
+	}
+	if shouldGenerateMarkdown(format) {
+		paths = append(paths, filepath.Join(outputDir, prefix+".md"))
+	}
+	if shouldGenerateHTML(format) {
+		paths = append(paths, filepath.Join(outputDir, prefix+".html"))
+	}
+	if shouldGenerateFindingsJSON(format) {
+		paths = append(paths, suffixedReportPath(outputDir, prefix, FindingsJSONSuffix))
+	}
+	if shouldGenerateSARIF(format) {
+		paths = append(paths, filepath.Join(outputDir, prefix+".sarif"))
+	}
+	if shouldGeneratePrometheus(format) {
+		paths = append(paths, suffixedReportPath(outputDir, prefix, PrometheusSuffix))
+	}
+	if shouldGenerateSummaryJSON(format) {
+		paths = append(paths, suffixedReportPath(outputDir, prefix, SummaryJSONSuffix))
+	}
+	if shouldGenerateYAML(format) {
+		paths = append(paths, filepath.Join(outputDir, prefix+".yaml"))
+	}
+	if shouldGenerateTOML(format) {
+		paths = append(paths, filepath.Join(outputDir, prefix+".toml"))
+	}
+	return paths
+}
+
 func shouldGenerateJSON(format string) bool {
 	return format == "all" || format == "json"
 }
@@ -554,30 +1643,211 @@ func shouldGenerateMarkdown(format string) bool {
 	return format == "all" || format == "markdown"
 }
 
-func generateJSONReport(reporter *Reporter, outputDir string) error {
-	jsonPath := filepath.Join(outputDir, "terraform-analysis-report.json")
+// shouldGenerateHTML is only true when requested explicitly so "all" keeps its existing file set
+func shouldGenerateHTML(format string) bool {
+	return format == "html"
+}
+
+// shouldGenerateFindingsJSON is only true when requested explicitly; "all" keeps the standard reports
+func shouldGenerateFindingsJSON(format string) bool {
+	return format == "findings-json"
+}
+
+// shouldGenerateSARIF is only true when requested explicitly, like findings-json
+func shouldGenerateSARIF(format string) bool {
+	return format == "sarif"
+}
+
+// shouldGeneratePrometheus is only true when requested explicitly, like findings-json
+func shouldGeneratePrometheus(format string) bool {
+	return format == "prometheus"
+}
+
+// shouldGenerateSummaryJSON is only true when requested explicitly, like findings-json
+func shouldGenerateSummaryJSON(format string) bool {
+	return format == "summary"
+}
+
+func shouldGenerateYAML(format string) bool {
+	return format == "yaml"
+}
+
+func shouldGenerateTOML(format string) bool {
+	return format == "toml"
+}
+
+func generateJSONReport(reporter *Reporter, outputDir, prefix string) error {
+	jsonPath := filepath.Join(outputDir, prefix+".json")
 	if err := reporter.ExportJSON(jsonPath); err != nil {
 		return fmt.Errorf("failed to generate JSON report: %w", err)
 	}
 	return nil
 }
 
-func generateCSVReport(reporter *Reporter, outputDir string) error {
-	csvPath := filepath.Join(outputDir, "terraform-analysis-report.csv")
+func generateCSVReport(reporter *Reporter, outputDir, prefix string) error {
+	csvPath := filepath.Join(outputDir, prefix+".csv")
 	if err := reporter.ExportCSV(csvPath); err != nil {
 		return fmt.Errorf("failed to generate CSV report: %w", err)
 	}
+
+	resourceCSVPath := suffixedReportPath(outputDir, prefix, ResourceCSVSuffix)
+	if err := reporter.ExportResourceCSV(resourceCSVPath); err != nil {
+		return fmt.Errorf("failed to generate resource CSV report: %w", err)
+	}
+
+	untaggedCSVPath := suffixedReportPath(outputDir, prefix, UntaggedCSVSuffix)
+	if err := reporter.ExportUntaggedCSV(untaggedCSVPath); err != nil {
+		return fmt.Errorf("failed to generate untagged CSV report: %w", err)
+	}
+	return nil
+}
+
+func generateFindingsJSONReport(reporter *Reporter, outputDir, prefix string) error {
+	findingsPath := suffixedReportPath(outputDir, prefix, FindingsJSONSuffix)
+	if err := reporter.ExportFindingsJSON(findingsPath); err != nil {
+		return fmt.Errorf("failed to generate findings JSON report: %w", err)
+	}
+	return nil
+}
+
+func generateSARIFReport(reporter *Reporter, outputDir, prefix string) error {
+	sarifPath := filepath.Join(outputDir, prefix+".sarif")
+	if err := reporter.ExportSARIF(sarifPath); err != nil {
+		return fmt.Errorf("failed to generate SARIF report: %w", err)
+	}
+	return nil
+}
+
+func generatePrometheusReport(reporter *Reporter, outputDir, prefix string) error {
+	metricsPath := suffixedReportPath(outputDir, prefix, PrometheusSuffix)
+	if err := reporter.ExportPrometheus(metricsPath); err != nil {
+		return fmt.Errorf("failed to generate Prometheus metrics: %w", err)
+	}
+	return nil
+}
+
+func generateSummaryJSONReport(reporter *Reporter, outputDir, prefix string) error {
+	summaryPath := suffixedReportPath(outputDir, prefix, SummaryJSONSuffix)
+	if err := reporter.ExportSummaryJSON(summaryPath); err != nil {
+		return fmt.Errorf("failed to generate summary JSON: %w", err)
+	}
+	return nil
+}
+
+func generateYAMLReport(reporter *Reporter, outputDir, prefix string) error {
+	yamlPath := filepath.Join(outputDir, prefix+".yaml")
+	if err := reporter.ExportYAML(yamlPath); err != nil {
+		return fmt.Errorf("failed to generate YAML report: %w", err)
+	}
+	return nil
+}
+
+func generateTOMLReport(reporter *Reporter, outputDir, prefix string) error {
+	tomlPath := filepath.Join(outputDir, prefix+".toml")
+	if err := reporter.ExportTOML(tomlPath); err != nil {
+		return fmt.Errorf("failed to generate TOML report: %w", err)
+	}
+	return nil
+}
+
+func generateHTMLReport(reporter *Reporter, outputDir, prefix string) error {
+	htmlPath := filepath.Join(outputDir, prefix+".html")
+	if err := reporter.ExportHTML(htmlPath); err != nil {
+		return fmt.Errorf("failed to generate HTML report: %w", err)
+	}
 	return nil
 }
 
-func generateMarkdownReport(reporter *Reporter, outputDir string) error {
-	mdPath := filepath.Join(outputDir, "terraform-analysis-report.md")
+func generateMarkdownReport(reporter *Reporter, outputDir, prefix string) error {
+	mdPath := filepath.Join(outputDir, prefix+".md")
 	if err := reporter.ExportMarkdown(mdPath); err != nil {
 		return fmt.Errorf("failed to generate Markdown report: %w", err)
 	}
 	return nil
 }
 
+// Origins of resolved configuration values, in viper's precedence order
+const (
+	ConfigOriginFlag    = "flag"
+	ConfigOriginEnv     = "env"
+	ConfigOriginFile    = "file"
+	ConfigOriginDefault = "default"
+	// The GitHub token read from --token-file, which wins over env and file values
+	ConfigOriginTokenFile = "token-file"
+)
+
+// printResolvedConfig writes every known configuration key as YAML with its
+// effective value and a comment naming where that value came from
+func printResolvedConfig(w io.Writer, cmd *cobra.Command) error {
+	if err := applyConfigProfile(viper.GetString("profile")); err != nil {
+		return err
+	}
+
+	keys := slices.Concat(slices.Collect(maps.Values(analyzeFlagBindings)), slices.Collect(maps.Keys(envVarBindings)), viper.AllKeys())
+	slices.Sort(keys)
+
+	resolved := &yaml.Node{Kind: yaml.MappingNode}
+	for _, key := range slices.Compact(keys) {
+		value := viper.Get(key)
+		if duration, ok := value.(time.Duration); ok {
+			value = duration.String()
+		}
+		origin := configValueOrigin(key, cmd)
+		if key == "github.token" {
+			token, err := resolveGitHubToken()
+			if err != nil {
+				return err
+			}
+			value = maskToken(token)
+			if effectiveTokenFile() != "" {
+				origin = ConfigOriginTokenFile
+			}
+		}
+		if key == "output.webhook_headers" {
+			value = maskWebhookHeaders(viper.GetStringSlice(key))
+		}
+
+		var valueNode yaml.Node
+		if err := valueNode.Encode(value); err != nil {
+			return fmt.Errorf("failed to encode %s: %w", key, err)
+		}
+		if valueNode.Kind != yaml.ScalarNode {
+			valueNode.Style = yaml.FlowStyle
+		}
+		valueNode.LineComment = origin
+		resolved.Content = append(resolved.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, &valueNode)
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(resolved); err != nil {
+		return fmt.Errorf("failed to write resolved configuration: %w", err)
+	}
+	return encoder.Close()
+}
+
+// configValueOrigin reports which source viper resolves a key from
+func configValueOrigin(key string, cmd *cobra.Command) string {
+	for flag, viperKey := range analyzeFlagBindings {
+		if viperKey == key && cmd.Flags().Changed(flag) {
+			return ConfigOriginFlag
+		}
+	}
+
+	envVar, bound := envVarBindings[key]
+	if !bound {
+		envVar = "TF_ANALYZER_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+	}
+	if _, set := os.LookupEnv(envVar); set {
+		return ConfigOriginEnv
+	}
+
+	if viper.InConfig(key) {
+		return ConfigOriginFile
+	}
+	return ConfigOriginDefault
+}
+
 func showConfig(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Configuration File: %s\n\n", viper.ConfigFileUsed())
 
@@ -627,10 +1897,14 @@ func createConfigTemplate() string {
 # GitHub Configuration
 github:
   token: "${GITHUB_TOKEN}"  # Set via environment variable
+  # token_file: "/run/secrets/github_token"  # Read the token from a file instead (--token still wins)
+  scm_provider: "github"    # Clone source: github or gitlab (token and base_url apply to either)
   base_url: ""              # For GitHub Enterprise (optional)
   skip_archived: true       # Skip archived repositories
   skip_forks: false        # Skip forked repositories
   
+  # repo: "my-org/terraform-aws-vpc"  # Analyze only this repository (replaces organizations)
+
   # Repository targeting options (use only one approach)
   # target_repos:           # Specific repositories to clone
   #   - "terraform-aws-vpc"
@@ -650,21 +1924,105 @@ organizations:
   - "hashicorp"
   - "terraform-providers"
 
+# Local analysis: analyze checkouts on disk instead of cloning organizations
+# local:
+#   path: "./checkouts"    # Each subdirectory is analyzed as a repository
+#   single_repo: false     # Analyze path itself as one repository
+
 # Processing Configuration  
 processing:
-  max_goroutines: ` + fmt.Sprintf("%d", DefaultMaxGoroutines) + `       # Maximum concurrent goroutines
-  clone_concurrency: ` + fmt.Sprintf("%d", DefaultCloneConcurrency) + `    # Clone concurrency limit
+  # max_goroutines: ` + fmt.Sprintf("%d", DefaultMaxGoroutines) + `     # Maximum concurrent goroutines
+  # clone_concurrency: ` + fmt.Sprintf("%d", DefaultCloneConcurrency) + `  # Clone concurrency limit
+  auto_concurrency: false  # Derive the limits above from the CPU count unless set (` + fmt.Sprintf("%d", AutoConcurrencyPerCPU) + ` per CPU)
+  file_read_concurrency: ` + fmt.Sprintf("%d", DefaultFileReadConcurrency) + ` # Files read ahead of the parser (raise on network filesystems)
+  max_retries: ` + fmt.Sprintf("%d", DefaultMaxRetries) + `           # Retries of transient clone failures (auth failures are never retried)
   timeout: "30m"           # Processing timeout
+  progress_interval: "30s" # How often to log processed/total repositories (0 disables)
+  timeout_as_warning: false # Treat repositories that hit the timeout as warnings
+  repo_timeout: "0s"       # Limit on each repository's analysis (0 disables)
+  require_repos: false     # Fail when an organization yields no repositories
+  org_order: "as-listed"   # as-listed, alpha, or a comma-separated priority list
+  org_concurrency: ` + fmt.Sprintf("%d", DefaultOrgConcurrency) + `       # Organizations cloned and analyzed at the same time
+  clones_dir: "` + DefaultClonesDir + `" # Each organization clones into a new subdirectory
+  keep_clones: false       # Leave clones on disk after analysis for debugging
 
 # Output Configuration
 output:
-  format: "all"            # json, csv, markdown, or all
+  format: "all"            # json, csv, markdown, html, findings-json, sarif, prometheus, summary, yaml, toml, or all
   directory: "."           # Output directory for reports
+  write_manifest: false    # Write run-manifest.json alongside the reports
+  timestamped: false       # Write each run into a new YYYYMMDD-HHMMSS subdirectory
+  report_prefix: "` + DefaultReportPrefix + `" # Report base name; {org} and {date} are expanded
+  split_by_org: false      # Also write <prefix>-<org> reports for each organization
+  split_only: false        # With split_by_org, skip the combined reports
+  # webhook_url: "https://dash.example.com/hooks/tf" # POST the summary JSON when the run finishes
+  # webhook_headers: ["Authorization: Bearer <token>"]
+  webhook_timeout: "10s"   # Limit on the webhook request
+  sort_by: "org"           # Repository order: org, name, resources, untagged, score, complexity
+  max_total_findings: 0    # Cap findings detail across all repositories (0 = unlimited)
+  stream_file: ""          # JSON Lines file written as each repository completes (empty = disabled)
+  graph_file: ""           # Graphviz DOT file of module usage across repositories (empty = disabled)
+
+# Analysis Configuration
+# analysis:
+#   ignore: ["examples/", "**/fixtures/**"]  # gitignore-style paths skipped in every repository
+#   include_extensions: [".tfmodule"]        # Extensions analyzed alongside .tf, .tf.json, .tfvars and .hcl
+#   sections: ["tags", "backend"]            # Sections to run (default all): ` + strings.Join(analysisSections, ", ") + `
+#   since: "30d"            # Skip repositories without a commit in this window (e.g. 30d, 72h)
+#   check_latest: false     # Query the Terraform Registry for each provider's latest version
+#   provider_sources:       # Registry sources for bare provider names (others default to hashicorp/<name>)
+#     github: "integrations/github"
+#     internal: "registry.example.com/platform/internal"
+
+# Analysis Cache Configuration
+cache:
+  enabled: false           # Reuse analyses of repositories whose HEAD commit is unchanged
+  directory: ""            # Defaults to the user cache directory (~/.cache/tf-analyzer)
 
 # UI Configuration
 ui:
   markdown_style: "auto"  # Markdown rendering style: auto, dark, light, notty
   raw_markdown: false     # Print raw markdown without glamour rendering
+
+# Named profiles merged over these settings with --profile (flags still win)
+# profiles:
+#   strict:
+#     compliance:
+#       mandatory_tags: ["Environment", "Owner", "CostCenter"]
+#       fail_on_untagged: 0
+#       require_pinned_providers: true
+#   sandbox:
+#     processing:
+#       max_goroutines: 10
+#     compliance:
+#       fail_on_untagged: -1
+
+# Compliance Configuration
+# compliance:
+#   mandatory_tags: ["Environment", "Owner", "Project", "CostCenter"]  # Overrides required_tags below
+#   tags_case_insensitive: false    # Let "environment" satisfy "Environment"
+#   fail_on_untagged: 10            # Exit 2 when more resources than this are untagged (-1 disables)
+#   fail_on_missing_provider_version: true  # Exit 2 when any provider is unpinned
+#   require_pinned_providers: true  # Exit 2 when fewer than min_pinned_pct percent of providers are pinned
+#   min_pinned_pct: 80
+#   tag_rules:                      # Required tags per resource type glob; exact types win over globs
+#     aws_instance: ["Environment", "Owner", "CostCenter"]
+#     aws_iam_*: ["Owner"]
+#     aws_route53_record: []        # Empty list exempts the type from tag checks
+#   deprecated_resources:           # Deprecated resource types and their recommended replacements
+#     aws_alb: "aws_lb"
+#     aws_alb_listener: "aws_lb_listener"
+#   fail_on_deprecated: true        # Exit 2 when any deprecated resource type is used
+#   config_file: "compliance.yaml"  # YAML policy document, for example:
+#     required_tags: ["Environment", "Owner"]
+#     allowed_providers: ["hashicorp/aws"]
+#     allowed_backends: ["s3"]
+#     naming_regex: "^[a-z0-9_]+$"
+#     min_versions:
+#       hashicorp/aws: "5.0.0"
+#     tag_value_rules:
+#       Environment: "^(prod|staging|dev)$"
+#       CostCenter: "^CC-\\d{4}$"
 `
 }
 
@@ -689,7 +2047,28 @@ func initConfig(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func printSchema(cmd *cobra.Command, args []string) error {
+	schema, err := reportSchemaJSON()
+	if err != nil {
+		return err
+	}
+
+	if schemaOutput == "" {
+		_, err = cmd.OutOrStdout().Write(schema)
+		return err
+	}
+	if err := os.WriteFile(schemaOutput, schema, 0644); err != nil {
+		return fmt.Errorf("failed to write schema file: %w", err)
+	}
+	fmt.Printf("Report schema written: %s\n", schemaOutput)
+	return nil
+}
+
 func validateConfig(cmd *cobra.Command, args []string) error {
+	if err := checkConfigFileKeys(viper.ConfigFileUsed()); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
 	config, err := createConfigFromViper()
 	if err != nil {
 		return fmt.Errorf("failed to load configuration: %w", err)
@@ -707,6 +2086,18 @@ func validateConfig(cmd *cobra.Command, args []string) error {
 func Execute() {
 	err := rootCmd.Execute()
 	if err != nil {
-		os.Exit(1)
+		os.Exit(exitCodeFor(err))
+	}
+}
+
+// exitCodeFor maps a command error to the documented process exit code
+func exitCodeFor(err error) int {
+	switch {
+	case err == nil:
+		return ExitCodeSuccess
+	case errors.Is(err, ErrComplianceThresholdExceeded):
+		return ExitCodeThresholdExceeded
+	default:
+		return ExitCodeError
 	}
 }
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
@@ -11,6 +13,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
@@ -122,6 +125,453 @@ func TestCreateConfigFromViper(t *testing.T) {
 	}
 }
 
+func TestCreateConfigFromViperMandatoryTags(t *testing.T) {
+	viper.Reset()
+	viper.Set("organizations", "org1")
+	viper.Set("compliance.mandatory_tags", "Team,Service")
+
+	config, err := createConfigFromViper()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if strings.Join(config.MandatoryTags, ",") != "Team,Service" {
+		t.Errorf("Expected mandatory tags [Team Service], got %v", config.MandatoryTags)
+	}
+	if tags := analysisOptionsFromConfig(config).requiredTags(); strings.Join(tags, ",") != "Team,Service" {
+		t.Errorf("Expected analysis to require [Team Service], got %v", tags)
+	}
+}
+
+func TestCreateConfigFromViperTagRules(t *testing.T) {
+	viper.Reset()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(`
+organizations: ["org1"]
+compliance:
+  tag_rules:
+    aws_instance: ["Owner", "CostCenter"]
+    aws_route53_record: []
+`))
+	if err != nil {
+		t.Fatalf("Expected config to parse, got %v", err)
+	}
+
+	config, err := createConfigFromViper()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	options := analysisOptionsFromConfig(config)
+	if tags := options.requiredTagsFor("aws_instance"); strings.Join(tags, ",") != "Owner,CostCenter" {
+		t.Errorf("Expected aws_instance to require [Owner CostCenter], got %v", tags)
+	}
+	if tags, ok := options.TagRules["aws_route53_record"]; !ok || len(tags) != 0 {
+		t.Errorf("Expected aws_route53_record to be exempt, got %v (present %v)", tags, ok)
+	}
+}
+
+func TestCreateConfigFromViperFailOnUntagged(t *testing.T) {
+	// Given: no threshold configured
+	viper.Reset()
+	config, err := createConfigFromViper()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Then: the gate is disabled rather than failing on any untagged resource
+	if config.FailOnUntagged != FailOnUntaggedDisabled {
+		t.Errorf("Expected FailOnUntagged %d, got %d", FailOnUntaggedDisabled, config.FailOnUntagged)
+	}
+
+	// When: an explicit zero threshold is configured
+	viper.Set("compliance.fail_on_untagged", 0)
+	config, err = createConfigFromViper()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Then: it is kept so any untagged resource fails the run
+	if config.FailOnUntagged != 0 {
+		t.Errorf("Expected FailOnUntagged 0, got %d", config.FailOnUntagged)
+	}
+}
+
+func TestCreateConfigFromViperTokenFile(t *testing.T) {
+	tokenPath := filepath.Join(t.TempDir(), "github_token")
+	if err := os.WriteFile(tokenPath, []byte("  file-token\n"), 0600); err != nil {
+		t.Fatalf("Failed to write token file: %v", err)
+	}
+
+	t.Run("the token file is read and trimmed", func(t *testing.T) {
+		viper.Reset()
+		viper.Set("github.token_file", tokenPath)
+
+		config, err := createConfigFromViper()
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if config.GitHubToken != "file-token" {
+			t.Errorf("Expected token 'file-token', got %q", config.GitHubToken)
+		}
+	})
+
+	t.Run("the token file wins over GITHUB_TOKEN", func(t *testing.T) {
+		// Given: a token in the environment and a token file
+		viper.Reset()
+		bindEnvironmentVariables()
+		t.Setenv("GITHUB_TOKEN", "env-token")
+		viper.Set("github.token_file", tokenPath)
+
+		config, err := createConfigFromViper()
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if config.GitHubToken != "file-token" {
+			t.Errorf("Expected the token file to win, got %q", config.GitHubToken)
+		}
+	})
+
+	t.Run("an explicit --token wins over the token file", func(t *testing.T) {
+		// Given: --token on the command line and a token file
+		viper.Reset()
+		bindViperFlags()
+		if err := analyzeCmd.Flags().Set("token", "flag-token"); err != nil {
+			t.Fatalf("Failed to set flag: %v", err)
+		}
+		t.Cleanup(func() {
+			flag := analyzeCmd.Flags().Lookup("token")
+			_ = flag.Value.Set(flag.DefValue)
+			flag.Changed = false
+			viper.Reset()
+		})
+		viper.Set("github.token_file", tokenPath)
+
+		config, err := createConfigFromViper()
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if config.GitHubToken != "flag-token" {
+			t.Errorf("Expected the flag to win, got %q", config.GitHubToken)
+		}
+	})
+
+	t.Run("unreadable and empty token files are errors", func(t *testing.T) {
+		emptyPath := filepath.Join(t.TempDir(), "empty")
+		if err := os.WriteFile(emptyPath, []byte("\n"), 0600); err != nil {
+			t.Fatalf("Failed to write token file: %v", err)
+		}
+		for path, expected := range map[string]string{
+			filepath.Join(t.TempDir(), "missing"): "failed to read --token-file",
+			emptyPath:                             "is empty",
+		} {
+			viper.Reset()
+			viper.Set("github.token_file", path)
+			if _, err := createConfigFromViper(); err == nil || !strings.Contains(err.Error(), expected) {
+				t.Errorf("Expected an error containing %q for %s, got %v", expected, path, err)
+			}
+		}
+	})
+}
+
+func TestCheckConfigFileKeys(t *testing.T) {
+	writeConfig := func(t *testing.T, content string) string {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), ".tf-analyzer.yaml")
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+		return path
+	}
+
+	t.Run("the generated template and free-form maps are valid", func(t *testing.T) {
+		path := writeConfig(t, createConfigTemplate()+`
+compliance:
+  tag_rules:
+    aws_iam_*: ["Owner"]
+analysis:
+  provider_sources:
+    github: "integrations/github"
+profiles:
+  strict:
+    compliance:
+      fail_on_untagged: 0
+`)
+		if err := checkConfigFileKeys(path); err != nil {
+			t.Errorf("Expected a valid config, got %v", err)
+		}
+	})
+
+	t.Run("unknown and misspelled keys are errors", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			content  string
+			expected string
+		}{
+			{"unknown key", "colour: blue\n", "unknown keys: colour"},
+			{"misspelled key", "organisations: [\"org1\"]\n", "organisations (did you mean organizations?)"},
+			{"misspelled nested key", "processing:\n  max_gorutines: 5\n", "processing.max_gorutines (did you mean processing.max_goroutines?)"},
+			{"misspelled profile setting", "profiles:\n  strict:\n    output:\n      formt: json\n", "profiles.strict.output.formt (did you mean profiles.strict.output.format?)"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				err := checkConfigFileKeys(writeConfig(t, tt.content))
+				if err == nil || !strings.Contains(err.Error(), tt.expected) {
+					t.Errorf("Expected error containing %q, got %v", tt.expected, err)
+				}
+			})
+		}
+	})
+
+	t.Run("analyze checks keys only with --config-schema-check", func(t *testing.T) {
+		viper.Reset()
+		t.Cleanup(func() {
+			configSchemaCheck = false
+			viper.Reset()
+		})
+		viper.SetConfigFile(writeConfig(t, "organizations: [\"org1\"]\norganisations: [\"org2\"]\n"))
+		if err := viper.ReadInConfig(); err != nil {
+			t.Fatalf("Expected config to parse, got %v", err)
+		}
+
+		if _, err := createConfigFromViper(); err != nil {
+			t.Errorf("Expected unknown keys to be ignored by default, got %v", err)
+		}
+		configSchemaCheck = true
+		if _, err := createConfigFromViper(); err == nil || !strings.Contains(err.Error(), "organisations") {
+			t.Errorf("Expected an unknown key error, got %v", err)
+		}
+	})
+}
+
+func TestCreateConfigFromViperProfiles(t *testing.T) {
+	readProfiles := func(t *testing.T) {
+		t.Helper()
+		viper.Reset()
+		viper.SetConfigType("yaml")
+		err := viper.ReadConfig(strings.NewReader(`
+organizations: ["org1"]
+processing:
+  max_goroutines: 50
+  clone_concurrency: 10
+compliance:
+  mandatory_tags: ["Owner"]
+profiles:
+  strict:
+    processing:
+      max_goroutines: 8
+    compliance:
+      mandatory_tags: ["Owner", "CostCenter"]
+      fail_on_untagged: 0
+  sandbox:
+    compliance:
+      fail_on_untagged: -1
+`))
+		if err != nil {
+			t.Fatalf("Expected config to parse, got %v", err)
+		}
+	}
+
+	t.Run("a selected profile is merged over the base config", func(t *testing.T) {
+		// Given: a config file with a strict profile
+		readProfiles(t)
+		viper.Set("profile", "Strict")
+
+		// When: the configuration is resolved
+		config, err := createConfigFromViper()
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then: the profile's settings win and the rest of the base config is kept
+		if strings.Join(config.MandatoryTags, ",") != "Owner,CostCenter" {
+			t.Errorf("Expected profile mandatory tags [Owner CostCenter], got %v", config.MandatoryTags)
+		}
+		if config.FailOnUntagged != 0 {
+			t.Errorf("Expected profile FailOnUntagged 0, got %d", config.FailOnUntagged)
+		}
+		if config.MaxGoroutines != 8 || config.CloneConcurrency != 10 {
+			t.Errorf("Expected MaxGoroutines 8 and CloneConcurrency 10, got %d and %d", config.MaxGoroutines, config.CloneConcurrency)
+		}
+		if len(config.Organizations) != 1 || config.Organizations[0] != "org1" {
+			t.Errorf("Expected base organizations [org1], got %v", config.Organizations)
+		}
+	})
+
+	t.Run("without a profile the base config applies", func(t *testing.T) {
+		readProfiles(t)
+
+		config, err := createConfigFromViper()
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if strings.Join(config.MandatoryTags, ",") != "Owner" || config.MaxGoroutines != 50 || config.FailOnUntagged != FailOnUntaggedDisabled {
+			t.Errorf("Expected the base config, got tags %v, MaxGoroutines %d, FailOnUntagged %d", config.MandatoryTags, config.MaxGoroutines, config.FailOnUntagged)
+		}
+	})
+
+	t.Run("an unknown profile is an error naming the defined ones", func(t *testing.T) {
+		readProfiles(t)
+		viper.Set("profile", "prod")
+
+		_, err := createConfigFromViper()
+		if err == nil || !strings.Contains(err.Error(), `"prod"`) || !strings.Contains(err.Error(), "sandbox, strict") {
+			t.Errorf("Expected an unknown profile error listing sandbox, strict, got %v", err)
+		}
+
+		viper.Reset()
+		viper.Set("profile", "prod")
+		if _, err := createConfigFromViper(); err == nil || !strings.Contains(err.Error(), "defines no profiles") {
+			t.Errorf("Expected an error without profiles, got %v", err)
+		}
+	})
+}
+
+func TestAutoConcurrency(t *testing.T) {
+	tests := []struct {
+		cpus, expectedGoroutines, expectedClones int
+	}{
+		{0, AutoConcurrencyPerCPU, AutoConcurrencyPerCPU},
+		{2, 2 * AutoConcurrencyPerCPU, 2 * AutoConcurrencyPerCPU},
+		{64, 64 * AutoConcurrencyPerCPU, MaxSafeCloneConcurrency},
+		{4096, MaxSafeMaxGoroutines, MaxSafeCloneConcurrency},
+	}
+	for _, tt := range tests {
+		goroutines, clones := autoConcurrency(tt.cpus)
+		if goroutines != tt.expectedGoroutines || clones != tt.expectedClones {
+			t.Errorf("autoConcurrency(%d) = %d, %d; expected %d, %d", tt.cpus, goroutines, clones, tt.expectedGoroutines, tt.expectedClones)
+		}
+	}
+}
+
+func TestResolveConcurrencyLimits(t *testing.T) {
+	// newCommand binds the concurrency flags as analyze does, so unchanged flags report their defaults
+	newCommand := func(t *testing.T, args ...string) {
+		t.Helper()
+		viper.Reset()
+		t.Cleanup(viper.Reset)
+		cmd := &cobra.Command{}
+		cmd.Flags().Int("max-goroutines", DefaultMaxGoroutines, "")
+		cmd.Flags().Int("clone-concurrency", DefaultCloneConcurrency, "")
+		cmd.Flags().Bool("auto-concurrency", false, "")
+		if err := cmd.Flags().Parse(args); err != nil {
+			t.Fatalf("Failed to parse flags: %v", err)
+		}
+		for _, flag := range []string{"max-goroutines", "clone-concurrency", "auto-concurrency"} {
+			if err := viper.BindPFlag(analyzeFlagBindings[flag], cmd.Flags().Lookup(flag)); err != nil {
+				t.Fatalf("Failed to bind %s: %v", flag, err)
+			}
+		}
+	}
+	limits := func(t *testing.T) (int, int) {
+		t.Helper()
+		return resolveConcurrencyLimits(3)
+	}
+
+	t.Run("defaults apply without --auto-concurrency", func(t *testing.T) {
+		newCommand(t)
+		if goroutines, clones := limits(t); goroutines != DefaultMaxGoroutines || clones != DefaultCloneConcurrency {
+			t.Errorf("Expected %d and %d, got %d and %d", DefaultMaxGoroutines, DefaultCloneConcurrency, goroutines, clones)
+		}
+	})
+
+	t.Run("both limits derive from the CPU count", func(t *testing.T) {
+		newCommand(t, "--auto-concurrency")
+		if goroutines, clones := limits(t); goroutines != 12 || clones != 12 {
+			t.Errorf("Expected 12 and 12 for 3 CPUs, got %d and %d", goroutines, clones)
+		}
+	})
+
+	t.Run("an explicit flag wins over the derived limit", func(t *testing.T) {
+		newCommand(t, "--auto-concurrency", "--max-goroutines", "7")
+		if goroutines, clones := limits(t); goroutines != 7 || clones != 12 {
+			t.Errorf("Expected 7 and 12, got %d and %d", goroutines, clones)
+		}
+	})
+
+	t.Run("a configured value wins over the derived limit", func(t *testing.T) {
+		newCommand(t, "--auto-concurrency")
+		viper.Set("processing.clone_concurrency", 5)
+		if goroutines, clones := limits(t); goroutines != 12 || clones != 5 {
+			t.Errorf("Expected 12 and 5, got %d and %d", goroutines, clones)
+		}
+	})
+
+	t.Run("the generated template leaves both limits to auto_concurrency", func(t *testing.T) {
+		newCommand(t)
+		template := strings.Replace(createConfigTemplate(), "auto_concurrency: false", "auto_concurrency: true", 1)
+		viper.SetConfigType("yaml")
+		if err := viper.ReadConfig(strings.NewReader(template)); err != nil {
+			t.Fatalf("Failed to read the template: %v", err)
+		}
+		if goroutines, clones := limits(t); goroutines != 12 || clones != 12 {
+			t.Errorf("Expected 12 and 12 for 3 CPUs, got %d and %d", goroutines, clones)
+		}
+	})
+}
+
+func TestPrintResolvedConfig(t *testing.T) {
+	// Given: max goroutines set through the environment and overridden by a flag
+	viper.Reset()
+	bindViperFlags()
+	bindEnvironmentVariables()
+	t.Setenv("MAX_GOROUTINES", "7")
+	t.Setenv("CLONE_CONCURRENCY", "3")
+	if err := analyzeCmd.Flags().Set("max-goroutines", "9"); err != nil {
+		t.Fatalf("Failed to set flag: %v", err)
+	}
+	t.Cleanup(func() {
+		flag := analyzeCmd.Flags().Lookup("max-goroutines")
+		_ = flag.Value.Set(flag.DefValue)
+		flag.Changed = false
+		viper.Reset()
+	})
+
+	// When: the resolved configuration is printed
+	var output bytes.Buffer
+	if err := printResolvedConfig(&output, analyzeCmd); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Then: each value should carry its effective source
+	for _, expected := range []string{
+		"processing.max_goroutines: 9 # flag",
+		"processing.clone_concurrency: \"3\" # env",
+		"output.format: all # default",
+	} {
+		if !strings.Contains(output.String(), expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, output.String())
+		}
+	}
+}
+
+func TestPrintResolvedConfigTokenFile(t *testing.T) {
+	// Given: a token in the environment and a --token-file holding another
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	bindViperFlags()
+	bindEnvironmentVariables()
+	t.Setenv("GITHUB_TOKEN", "envtoken12345")
+	tokenFile := filepath.Join(t.TempDir(), "tok")
+	if err := os.WriteFile(tokenFile, []byte("filetoken67890\n"), 0600); err != nil {
+		t.Fatalf("Failed to write token file: %v", err)
+	}
+	viper.Set("github.token_file", tokenFile)
+
+	// When: the resolved configuration is printed
+	var output bytes.Buffer
+	if err := printResolvedConfig(&output, analyzeCmd); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Then: the token the run uses is shown, attributed to the token file
+	expected := "github.token: " + maskToken("filetoken67890") + " # " + ConfigOriginTokenFile
+	if !strings.Contains(output.String(), expected) {
+		t.Errorf("Expected output to contain %q, got:\n%s", expected, output.String())
+	}
+}
+
 func TestCreateConfigFromViperWithStringOrgs(t *testing.T) {
 	// Clear viper state before test
 	viper.Reset()
@@ -610,6 +1060,56 @@ func TestGenerateReports(t *testing.T) {
 			t.Error("Expected CSV file NOT to be created")
 		}
 	})
+
+	t.Run("writes timestamped runs into their own subdirectory", func(t *testing.T) {
+		// Given: --timestamped-output and an output directory
+		reporter := NewReporter()
+		config := Config{Organizations: []string{"test-org"}, TimestampedOutput: true, WriteManifest: true}
+		viper.Reset()
+		tempDir := t.TempDir()
+		viper.Set("output.format", "json")
+		viper.Set("output.directory", tempDir)
+
+		// When: generateReports is called
+		if err := generateReports(reporter, config); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then: the report and manifest land in a single YYYYMMDD-HHMMSS subdirectory
+		entries, err := os.ReadDir(tempDir)
+		if err != nil || len(entries) != 1 || !entries[0].IsDir() {
+			t.Fatalf("Expected exactly one run subdirectory, got %v (%v)", entries, err)
+		}
+		if _, err := time.Parse(TimestampedOutputLayout, entries[0].Name()); err != nil {
+			t.Errorf("Expected a YYYYMMDD-HHMMSS directory name, got %q", entries[0].Name())
+		}
+		for _, filename := range []string{JSONReportFileName, RunManifestFileName} {
+			if _, err := os.Stat(filepath.Join(tempDir, entries[0].Name(), filename)); err != nil {
+				t.Errorf("Expected %s inside the run directory: %v", filename, err)
+			}
+		}
+	})
+}
+
+func TestResolveOutputDirectory(t *testing.T) {
+	baseDir := t.TempDir()
+	now := time.Date(2026, 10, 14, 9, 30, 5, 0, time.UTC)
+
+	// The default writes directly into the output directory
+	dir, err := resolveOutputDirectory(baseDir, false, now)
+	if err != nil || dir != baseDir {
+		t.Errorf("Expected %s, got %s (%v)", baseDir, dir, err)
+	}
+
+	// Timestamped runs create and return a subdirectory named for the run time
+	dir, err = resolveOutputDirectory(baseDir, true, now)
+	expected := filepath.Join(baseDir, "20261014-093005")
+	if err != nil || dir != expected {
+		t.Fatalf("Expected %s, got %s (%v)", expected, dir, err)
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		t.Errorf("Expected %s to be created, got %v", dir, err)
+	}
 }
 
 // TestShowConfig tests configuration display
@@ -776,11 +1276,87 @@ func TestSetupAnalysisLogger(t *testing.T) {
 		// When: setupAnalysisLogger is called
 		logger := setupAnalysisLogger()
 		
-		// Then: should return a valid logger
+		// Then: should return a logger that keeps debug logs
 		if logger == nil {
-			t.Error("Expected logger, got nil")
+			t.Fatal("Expected logger, got nil")
+		}
+		if !logger.Enabled(context.Background(), slog.LevelDebug) {
+			t.Error("Expected debug logs when verbose")
+		}
+	})
+
+	t.Run("logs only errors when quiet", func(t *testing.T) {
+		// Given: quiet is set without verbose
+		originalVerbose, originalQuiet := verbose, quiet
+		defer func() { verbose, quiet = originalVerbose, originalQuiet }()
+		verbose, quiet = false, true
+
+		// When: setupAnalysisLogger is called
+		logger := setupAnalysisLogger()
+
+		// Then: warnings are dropped and errors are kept
+		if logger.Enabled(context.Background(), slog.LevelWarn) || !logger.Enabled(context.Background(), slog.LevelError) {
+			t.Error("Expected an error-only logger when quiet")
+		}
+		if !quietOutput() {
+			t.Error("Expected console output to be skipped when quiet")
 		}
 	})
+
+	t.Run("verbose wins over quiet", func(t *testing.T) {
+		originalVerbose, originalQuiet := verbose, quiet
+		defer func() { verbose, quiet = originalVerbose, originalQuiet }()
+		verbose, quiet = true, true
+
+		logger := setupAnalysisLogger()
+
+		if !logger.Enabled(context.Background(), slog.LevelDebug) {
+			t.Error("Expected debug logs when --verbose is also set")
+		}
+		if quietOutput() {
+			t.Error("Expected console output when --verbose is also set")
+		}
+	})
+}
+
+func TestQuietSkipsConsoleOutput(t *testing.T) {
+	// Given: a local repository analyzed with --quiet and JSON reports
+	viper.Reset()
+	defer viper.Reset()
+	originalVerbose, originalQuiet := verbose, quiet
+	defer func() { verbose, quiet = originalVerbose, originalQuiet }()
+	verbose, quiet = false, true
+	localPath := createTempTerraformRepo(t, map[string]string{"repo/main.tf": `resource "aws_s3_bucket" "data" {}`})
+	outputDir := t.TempDir()
+	viper.Set("local.path", localPath)
+	viper.Set("processing.max_goroutines", 1)
+	viper.Set("processing.clone_concurrency", 1)
+	viper.Set("processing.timeout", "10s")
+	viper.Set("output.format", "json")
+	viper.Set("output.directory", outputDir)
+
+	// When: analyze runs with stdout captured
+	originalStdout := os.Stdout
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	os.Stdout = writer
+	runErr := runAnalyze(&cobra.Command{}, []string{})
+	os.Stdout = originalStdout
+	_ = writer.Close()
+	output, _ := io.ReadAll(reader)
+
+	// Then: nothing is printed, but the JSON report is still written
+	if runErr != nil {
+		t.Fatalf("Expected no error, got %v", runErr)
+	}
+	if len(output) != 0 {
+		t.Errorf("Expected no console output when quiet, got %q", output)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, JSONReportFileName)); err != nil {
+		t.Errorf("Expected the JSON report to be written, got %v", err)
+	}
 }
 
 // TestPrepareAnalysisConfig tests configuration preparation
@@ -937,7 +1513,7 @@ func TestGenerateReportsByFormatAdditional(t *testing.T) {
 		tempDir := t.TempDir()
 
 		// When: generateReportsByFormat is called for JSON
-		err := generateReportsByFormat(reporter, "json", tempDir)
+		err := generateReportsByFormat(reporter, "json", tempDir, DefaultReportPrefix)
 
 		// Then: should generate JSON report
 		if err != nil {
@@ -967,7 +1543,7 @@ func TestGenerateReportsByFormatAdditional(t *testing.T) {
 		tempDir := t.TempDir()
 
 		// When: generateReportsByFormat is called for CSV
-		err := generateReportsByFormat(reporter, "csv", tempDir)
+		err := generateReportsByFormat(reporter, "csv", tempDir, DefaultReportPrefix)
 
 		// Then: should generate CSV report
 		if err != nil {
@@ -997,7 +1573,7 @@ func TestGenerateReportsByFormatAdditional(t *testing.T) {
 		tempDir := t.TempDir()
 
 		// When: generateReportsByFormat is called for markdown
-		err := generateReportsByFormat(reporter, "markdown", tempDir)
+		err := generateReportsByFormat(reporter, "markdown", tempDir, DefaultReportPrefix)
 
 		// Then: should generate markdown report
 		if err != nil {
@@ -1016,7 +1592,7 @@ func TestGenerateReportsByFormatAdditional(t *testing.T) {
 		tempDir := t.TempDir()
 
 		// When: generateReportsByFormat is called with unknown format
-		err := generateReportsByFormat(reporter, "unknown", tempDir)
+		err := generateReportsByFormat(reporter, "unknown", tempDir, DefaultReportPrefix)
 
 		// Then: should handle gracefully (no error expected)
 		if err != nil {
@@ -1027,6 +1603,212 @@ func TestGenerateReportsByFormatAdditional(t *testing.T) {
 
 
 // TestInitializeConfigAdditional tests config initialization with different scenarios
+func TestReportPrefix(t *testing.T) {
+	now := time.Date(2026, 10, 14, 9, 30, 0, 0, time.UTC)
+
+	t.Run("expands placeholders", func(t *testing.T) {
+		tests := []struct {
+			prefix   string
+			orgs     []string
+			expected string
+		}{
+			{prefix: "", orgs: []string{"acme"}, expected: DefaultReportPrefix},
+			{prefix: "nightly", orgs: []string{"acme"}, expected: "nightly"},
+			{prefix: "{org}-{date}", orgs: []string{"acme"}, expected: "acme-2026-10-14"},
+			{prefix: "tf-{org}", orgs: []string{"acme", "globex"}, expected: "tf-acme-globex"},
+		}
+		for _, tt := range tests {
+			if got := expandReportPrefix(tt.prefix, tt.orgs, now); got != tt.expected {
+				t.Errorf("expandReportPrefix(%q, %v) = %q, expected %q", tt.prefix, tt.orgs, got, tt.expected)
+			}
+		}
+		if got := reportOrganizations(Config{LocalPath: "/repos", Organizations: []string{"acme"}}); len(got) != 1 || got[0] != LocalOrganization {
+			t.Errorf("Expected the local organization for --local-path, got %v", got)
+		}
+	})
+
+	t.Run("rejects path separators", func(t *testing.T) {
+		for _, prefix := range []string{"../report", "reports/acme", `reports\acme`, ".."} {
+			if err := validateReportPrefix(prefix); err == nil {
+				t.Errorf("Expected an error for %q", prefix)
+			}
+		}
+		if err := validateReportPrefix("{org}-{date}"); err != nil {
+			t.Errorf("Expected placeholders to be accepted, got %v", err)
+		}
+	})
+
+	t.Run("names the reports and manifest entries", func(t *testing.T) {
+		// Given: a custom prefix with the organization placeholder
+		viper.Reset()
+		defer viper.Reset()
+		tempDir := t.TempDir()
+		viper.Set("output.format", "all")
+		viper.Set("output.directory", tempDir)
+		config := Config{Organizations: []string{"acme"}, ReportPrefix: "{org}-export", WriteManifest: true}
+
+		// When: reports are generated
+		if err := generateReports(NewReporter(), config); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then: the main reports use the expanded prefix and the default names are absent
+		for _, name := range []string{"acme-export.json", "acme-export.csv", "acme-export.md", "acme-export-resources.csv", "acme-export-untagged.csv"} {
+			if _, err := os.Stat(filepath.Join(tempDir, name)); err != nil {
+				t.Errorf("Expected %s to be written, got %v", name, err)
+			}
+		}
+		for _, name := range []string{JSONReportFileName, ResourceCSVFileName, UntaggedCSVFileName} {
+			if _, err := os.Stat(filepath.Join(tempDir, name)); !os.IsNotExist(err) {
+				t.Errorf("Expected no %s with a custom prefix, got %v", name, err)
+			}
+		}
+		manifest, err := os.ReadFile(filepath.Join(tempDir, RunManifestFileName))
+		if err != nil || !strings.Contains(string(manifest), "acme-export.json") {
+			t.Errorf("Expected the manifest to list acme-export.json, got %s (%v)", manifest, err)
+		}
+	})
+
+	t.Run("names the single-format reports", func(t *testing.T) {
+		// Given: the formats that used to have fixed file names
+		tests := map[string]string{
+			"findings-json": "acme-export-findings.json",
+			"prometheus":    "acme-export.prom",
+			"summary":       "acme-export-summary.json",
+		}
+		for format, want := range tests {
+			// When: the paths are derived from a custom prefix and from the default one
+			paths := generatedReportPaths(format, "out", "acme-export")
+			defaults := generatedReportPaths(format, "out", DefaultReportPrefix)
+
+			// Then: the custom prefix names the file and the default keeps its historical name
+			if len(paths) != 1 || paths[0] != filepath.Join("out", want) {
+				t.Errorf("Expected %s to be written to %s, got %v", format, want, paths)
+			}
+			if len(defaults) != 1 || defaults[0] != filepath.Join("out", defaultSuffixedReportNames[strings.TrimPrefix(want, "acme-export")]) {
+				t.Errorf("Expected %s to keep its default name, got %v", format, defaults)
+			}
+		}
+	})
+}
+
+func TestSplitReportsByOrganization(t *testing.T) {
+	now := time.Date(2026, 10, 14, 9, 30, 0, 0, time.UTC)
+	reporter := NewReporter()
+	reporter.AddResults([]AnalysisResult{
+		{RepoName: "network", Organization: "acme", Analysis: RepositoryAnalysis{RepositoryPath: "/clones/acme/network"}},
+		{RepoName: "billing", Organization: "globex", Analysis: RepositoryAnalysis{RepositoryPath: "/clones/globex/billing"}},
+		{RepoName: "storage", Organization: "acme", Analysis: RepositoryAnalysis{RepositoryPath: "/clones/acme/storage"}},
+	})
+	repositoryPaths := func(t *testing.T, path string) []string {
+		t.Helper()
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Expected %s to be written: %v", path, err)
+		}
+		var report ComprehensiveReport
+		if err := json.Unmarshal(content, &report); err != nil {
+			t.Fatalf("Expected a JSON report: %v", err)
+		}
+		return lo.Map(report.Repositories, func(repo RepositoryForJSON, _ int) string {
+			return repo.RepositoryPath
+		})
+	}
+	generate := func(t *testing.T, config Config) string {
+		t.Helper()
+		viper.Reset()
+		t.Cleanup(viper.Reset)
+		tempDir := t.TempDir()
+		viper.Set("output.format", "json")
+		viper.Set("output.directory", tempDir)
+		if err := generateReports(reporter, config); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		return tempDir
+	}
+
+	t.Run("names organization reports after the prefix", func(t *testing.T) {
+		tests := []struct {
+			prefix   string
+			org      string
+			expected string
+		}{
+			{prefix: "", org: "acme", expected: DefaultReportPrefix + "-acme"},
+			{prefix: "nightly-{date}", org: "acme", expected: "nightly-2026-10-14-acme"},
+			{prefix: "{org}-export", org: "acme", expected: "acme-export"},
+			{prefix: DefaultReportPrefix, org: "group/subgroup", expected: DefaultReportPrefix + "-group-subgroup"},
+		}
+		for _, tt := range tests {
+			if got := organizationReportPrefix(tt.prefix, tt.org, now, false); got != tt.expected {
+				t.Errorf("organizationReportPrefix(%q, %q) = %q, expected %q", tt.prefix, tt.org, got, tt.expected)
+			}
+		}
+	})
+
+	t.Run("writes one report per organization alongside the combined report", func(t *testing.T) {
+		// When: reports are generated with --split-by-org
+		tempDir := generate(t, Config{Organizations: []string{"acme", "globex"}, SplitByOrg: true, WriteManifest: true})
+
+		// Then: each organization's report holds only its repositories
+		assert.Equal(t, []string{"/clones/acme/network", "/clones/acme/storage"}, repositoryPaths(t, filepath.Join(tempDir, DefaultReportPrefix+"-acme.json")))
+		assert.Equal(t, []string{"/clones/globex/billing"}, repositoryPaths(t, filepath.Join(tempDir, DefaultReportPrefix+"-globex.json")))
+		assert.Len(t, repositoryPaths(t, filepath.Join(tempDir, JSONReportFileName)), 3, "combined report")
+		manifest, err := os.ReadFile(filepath.Join(tempDir, RunManifestFileName))
+		if err != nil || !strings.Contains(string(manifest), DefaultReportPrefix+"-globex.json") {
+			t.Errorf("Expected the manifest to list the organization reports, got %s (%v)", manifest, err)
+		}
+	})
+
+	t.Run("keeps {org} reports apart from the combined report", func(t *testing.T) {
+		// Given: a single organization and a prefix naming it
+		if got := organizationReportPrefix("r-{org}-{date}", "acme", now, true); got != "r-acme-2026-10-14"+SplitReportSuffix {
+			t.Errorf("Expected the split suffix alongside the combined report, got %q", got)
+		}
+		single := reporter.ForOrganization("acme")
+		viper.Reset()
+		t.Cleanup(viper.Reset)
+		tempDir := t.TempDir()
+		viper.Set("output.format", "json")
+		viper.Set("output.directory", tempDir)
+
+		// When: reports are generated with --split-by-org and a manifest
+		config := Config{Organizations: []string{"acme"}, SplitByOrg: true, WriteManifest: true, ReportPrefix: "r-{org}"}
+		if err := generateReports(single, config); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then: both reports exist and the manifest lists each once
+		combined := filepath.Join(tempDir, "r-acme.json")
+		split := filepath.Join(tempDir, "r-acme"+SplitReportSuffix+".json")
+		assert.Len(t, repositoryPaths(t, combined), 2)
+		assert.Len(t, repositoryPaths(t, split), 2)
+		content, err := os.ReadFile(filepath.Join(tempDir, RunManifestFileName))
+		require.NoError(t, err)
+		var manifest RunManifest
+		require.NoError(t, json.Unmarshal(content, &manifest))
+		names := lo.Map(manifest.ReportFiles, func(file ManifestReportFile, _ int) string { return file.Name })
+		assert.Equal(t, []string{"r-acme.json", "r-acme" + SplitReportSuffix + ".json"}, names)
+	})
+
+	t.Run("skips the combined report with --split-only", func(t *testing.T) {
+		tempDir := generate(t, Config{Organizations: []string{"acme", "globex"}, SplitByOrg: true, SplitOnly: true})
+
+		entries, err := os.ReadDir(tempDir)
+		if err != nil {
+			t.Fatalf("Failed to read output directory: %v", err)
+		}
+		names := lo.Map(entries, func(entry os.DirEntry, _ int) string { return entry.Name() })
+		assert.Equal(t, []string{DefaultReportPrefix + "-acme.json", DefaultReportPrefix + "-globex.json"}, names)
+	})
+
+	t.Run("--split-only requires --split-by-org", func(t *testing.T) {
+		err := validateCLIAnalysisConfig(Config{LocalPath: t.TempDir(), SplitOnly: true})
+		if err == nil || !strings.Contains(err.Error(), "--split-only requires --split-by-org") {
+			t.Errorf("Expected a --split-only error, got %v", err)
+		}
+	})
+}
+
 func TestInitializeConfigAdditional(t *testing.T) {
 	t.Run("initializes config with env file loading", func(t *testing.T) {
 		// Given: environment with dotenv file
@@ -1300,6 +2082,31 @@ func TestRunAnalyzeIntegration(t *testing.T) {
 		}
 	})
 
+	t.Run("prints the error without the usage once flags have parsed", func(t *testing.T) {
+		// Given: a command whose configuration fails validation
+		viper.Reset()
+		defer viper.Reset()
+		viper.Set("organizations", []string{})
+		viper.Set("github.token", "fake-token")
+		var output bytes.Buffer
+		cmd := &cobra.Command{Use: "analyze", RunE: runAnalyze}
+		cmd.Flags().Bool("example", false, "flag that would appear in the usage")
+		cmd.SetOut(&output)
+		cmd.SetErr(&output)
+		cmd.SetArgs([]string{})
+
+		// When: the command is executed
+		err := cmd.Execute()
+
+		// Then: the error is returned and printed without the flag usage
+		if err == nil {
+			t.Fatal("Expected a configuration error, got nil")
+		}
+		if strings.Contains(output.String(), "Usage:") || !strings.Contains(output.String(), "at least one organization") {
+			t.Errorf("Expected only the error to be printed, got:\n%s", output.String())
+		}
+	})
+
 	t.Run("handles valid config", func(t *testing.T) {
 		// Given: Valid configuration
 		viper.Reset()
@@ -1375,6 +2182,11 @@ func TestRunAnalyzeIntegration(t *testing.T) {
 // TestMarkdownEdgeCases tests markdown processing edge cases
 func TestMarkdownEdgeCases(t *testing.T) {
 	t.Run("handles terminal detection edge cases", func(t *testing.T) {
+		// Simulate a TTY so the color environment decides the style
+		originalIsTerminal := stdoutIsTerminal
+		stdoutIsTerminal = func() bool { return true }
+		defer func() { stdoutIsTerminal = originalIsTerminal }()
+
 		// Test various terminal environment combinations
 		testCases := []struct {
 			colorTerm string
@@ -1867,4 +2679,33 @@ func TestMaskTokenEdgeCases(t *testing.T) {
 			})
 		}
 	})
-}
\ No newline at end of file
+}
+
+// validateFlags resolves and validates the configuration the way prepareAnalysisConfig does
+func validateFlags() error {
+	config, err := createConfigFromViper()
+	if err != nil {
+		return fmt.Errorf("failed to create configuration: %w", err)
+	}
+	return validateCLIAnalysisConfig(config)
+}
+
+// executeCommand runs the analysis workflow for config without writing reports
+func executeCommand(ctx context.Context, config Config) error {
+	if err := validateCLIAnalysisConfig(config); err != nil {
+		return fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("analysis cancelled before start: %w", err)
+	}
+
+	processingCtx, err := setupAnalysis(config, slog.Default())
+	if err != nil {
+		return err
+	}
+	defer releaseProcessingContext(processingCtx)
+
+	_, analysisErr := executeAnalysisWorkflow(ctx, processingCtx)
+	return analysisErr
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// ============================================================================
+// COMPLETION - Shell completion scripts and flag value completions
+// ============================================================================
+
+// Shells supported by the completion command
+const (
+	ShellBash       = "bash"
+	ShellZsh        = "zsh"
+	ShellFish       = "fish"
+	ShellPowerShell = "powershell"
+)
+
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "Generate a shell completion script",
+	Long: `
+# Shell Completion
+
+Print a completion script covering every command, the analyze flags and the
+values of enumerated flags such as --format.
+
+## Examples
+
+	# Bash (requires bash-completion)
+	source <(tf-analyzer completion bash)
+	
+	# Zsh
+	tf-analyzer completion zsh > "${fpath[1]}/_tf-analyzer"
+	
+	# Fish
+	tf-analyzer completion fish > ~/.config/fish/completions/tf-analyzer.fish
+	
+	# PowerShell
+	tf-analyzer completion powershell | Out-String | Invoke-Expression
+	`,
+	ValidArgs:             []string{ShellBash, ShellZsh, ShellFish, ShellPowerShell},
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	DisableFlagsInUseLine: true,
+	RunE:                  generateCompletion,
+}
+
+func generateCompletion(cmd *cobra.Command, args []string) error {
+	out := cmd.OutOrStdout()
+	switch args[0] {
+	case ShellBash:
+		return cmd.Root().GenBashCompletionV2(out, true)
+	case ShellZsh:
+		return cmd.Root().GenZshCompletion(out)
+	case ShellFish:
+		return cmd.Root().GenFishCompletion(out, true)
+	case ShellPowerShell:
+		return cmd.Root().GenPowerShellCompletionWithDesc(out)
+	}
+	return fmt.Errorf("unsupported shell %q", args[0])
+}
+
+// outputFormats lists the --format values offered for completion
+var outputFormats = []string{"all", "json", "csv", "markdown", "html", "findings-json", "sarif", "prometheus", "summary", "yaml", "toml"}
+
+// analyzeFlagValues maps enumerated analyze flags to their completion values
+var analyzeFlagValues = map[string][]string{
+	"format":          outputFormats,
+	"sort-reports-by": validSortKeys,
+	"scm-provider":    {SCMProviderGitHub, SCMProviderGitLab},
+	"markdown-style":  {"auto", "dark", "light", "notty"},
+	"org-order":       {OrgOrderAsListed, OrgOrderAlpha},
+}
+
+// registerFlagCompletions offers the fixed values of enumerated flags
+func registerFlagCompletions() {
+	for flag, values := range analyzeFlagValues {
+		if err := analyzeCmd.RegisterFlagCompletionFunc(flag, cobra.FixedCompletions(values, cobra.ShellCompDirectiveNoFileComp)); err != nil {
+			panic(fmt.Sprintf("Failed to register completion for %s flag: %v", flag, err))
+		}
+	}
+}
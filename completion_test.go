@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCompletionCommand(t *testing.T) {
+	t.Run("generates a script for each shell", func(t *testing.T) {
+		tests := map[string]string{
+			ShellBash:       "__start_tf-analyzer",
+			ShellZsh:        "#compdef tf-analyzer",
+			ShellFish:       "complete -c tf-analyzer",
+			ShellPowerShell: "Register-ArgumentCompleter",
+		}
+		for shell, marker := range tests {
+			t.Run(shell, func(t *testing.T) {
+				// Given: the completion command writing to a buffer
+				var out bytes.Buffer
+				completionCmd.SetOut(&out)
+				defer completionCmd.SetOut(nil)
+
+				// When: a script is generated for the shell
+				if err := generateCompletion(completionCmd, []string{shell}); err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+
+				// Then: the output should be that shell's completion script
+				if out.Len() == 0 || !strings.Contains(out.String(), marker) {
+					t.Errorf("Expected %s script containing %q, got %d bytes", shell, marker, out.Len())
+				}
+			})
+		}
+	})
+
+	t.Run("completes analyze flags, flag values and config subcommands", func(t *testing.T) {
+		tests := []struct {
+			args     []string
+			expected []string
+		}{
+			{[]string{"analyze", "--"}, []string{"--orgs", "--repo", "--local-path"}},
+			{[]string{"analyze", "--format", ""}, outputFormats},
+			{[]string{"analyze", "--scm-provider", ""}, []string{SCMProviderGitHub, SCMProviderGitLab}},
+			{[]string{"config", ""}, []string{"show", "init", "validate"}},
+			{[]string{"completion", ""}, []string{ShellBash, ShellZsh, ShellFish, ShellPowerShell}},
+		}
+		for _, tt := range tests {
+			// Given: cobra's hidden completion request for the arguments
+			var out bytes.Buffer
+			rootCmd.SetOut(&out)
+			rootCmd.SetErr(&bytes.Buffer{})
+			rootCmd.SetArgs(append([]string{"__complete"}, tt.args...))
+
+			// When: the root command handles it
+			err := rootCmd.Execute()
+			rootCmd.SetOut(nil)
+			rootCmd.SetErr(nil)
+			rootCmd.SetArgs(nil)
+
+			// Then: every expected candidate should be offered
+			if err != nil {
+				t.Fatalf("Expected no error completing %v, got %v", tt.args, err)
+			}
+			candidates := make(map[string]bool)
+			for _, line := range strings.Split(out.String(), "\n") {
+				candidates[strings.SplitN(line, "\t", 2)[0]] = true
+			}
+			for _, candidate := range tt.expected {
+				if !candidates[candidate] {
+					t.Errorf("Expected %q among completions for %v, got %q", candidate, tt.args, out.String())
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+	"gopkg.in/yaml.v3"
+)
+
+// ============================================================================
+// COMPLIANCE - Policy document loading and evaluation
+// ============================================================================
+
+// Compliance policy names used in violations
+const (
+	PolicyAllowedProviders = "allowed_providers"
+	PolicyAllowedBackends  = "allowed_backends"
+	PolicyNamingRegex      = "naming_regex"
+	PolicyMinVersions      = "min_versions"
+)
+
+// defaultBackendType is what Terraform uses when no backend block is declared
+const defaultBackendType = "local"
+
+// CompliancePolicy declares every compliance rule in a single document
+type CompliancePolicy struct {
+	RequiredTags     []string          `yaml:"required_tags"`     // Tags every resource must carry
+	AllowedProviders []string          `yaml:"allowed_providers"` // Provider sources permitted in repositories
+	AllowedBackends  []string          `yaml:"allowed_backends"`  // Backend types permitted for state storage
+	NamingRegex      string            `yaml:"naming_regex"`      // Pattern resource names must match
+	MinVersions      map[string]string `yaml:"min_versions"`      // Minimum version per provider source
+	TagValueRules    map[string]string `yaml:"tag_value_rules"`   // Pattern each tag's value must match
+
+	namingPattern    *regexp.Regexp
+	tagValuePatterns map[string]*regexp.Regexp
+}
+
+// ComplianceViolation records a single policy breach within a repository
+type ComplianceViolation struct {
+	Policy  string `json:"policy"`
+	Subject string `json:"subject"`
+	Message string `json:"message"`
+}
+
+func loadCompliancePolicy(path string) (CompliancePolicy, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return CompliancePolicy{}, fmt.Errorf("failed to read compliance config %s: %w", path, err)
+	}
+
+	policy, err := parseCompliancePolicy(content)
+	if err != nil {
+		return CompliancePolicy{}, fmt.Errorf("invalid compliance config %s: %w", path, err)
+	}
+	return policy, nil
+}
+
+func parseCompliancePolicy(content []byte) (CompliancePolicy, error) {
+	var policy CompliancePolicy
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&policy); err != nil && !errors.Is(err, io.EOF) {
+		return CompliancePolicy{}, fmt.Errorf("failed to parse policy document: %w", err)
+	}
+
+	if err := validateCompliancePolicy(&policy); err != nil {
+		return CompliancePolicy{}, err
+	}
+	return policy, nil
+}
+
+func validateCompliancePolicy(policy *CompliancePolicy) error {
+	var errs []error
+
+	if lo.Contains(lo.Map(policy.RequiredTags, trimmed), "") {
+		errs = append(errs, fmt.Errorf("required_tags must not contain empty tag names"))
+	}
+	if lo.Contains(lo.Map(policy.AllowedProviders, trimmed), "") {
+		errs = append(errs, fmt.Errorf("allowed_providers must not contain empty provider sources"))
+	}
+	if lo.Contains(lo.Map(policy.AllowedBackends, trimmed), "") {
+		errs = append(errs, fmt.Errorf("allowed_backends must not contain empty backend types"))
+	}
+
+	if policy.NamingRegex != "" {
+		pattern, err := regexp.Compile(policy.NamingRegex)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("naming_regex is not a valid regular expression: %w", err))
+		}
+		policy.namingPattern = pattern
+	}
+
+	if len(policy.TagValueRules) > 0 {
+		policy.tagValuePatterns = make(map[string]*regexp.Regexp, len(policy.TagValueRules))
+	}
+	for tag, rule := range policy.TagValueRules {
+		pattern, err := regexp.Compile(rule)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("tag_value_rules entry for %q is not a valid regular expression: %w", tag, err))
+			continue
+		}
+		policy.tagValuePatterns[tag] = pattern
+	}
+
+	for source, minVersion := range policy.MinVersions {
+		if _, ok := parseVersionNumbers(minVersion); !ok {
+			errs = append(errs, fmt.Errorf("min_versions entry for %q has invalid version %q", source, minVersion))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func trimmed(value string, _ int) string {
+	return strings.TrimSpace(value)
+}
+
+// resourceNameAllowed reports whether a resource name satisfies the naming policy
+func (p CompliancePolicy) resourceNameAllowed(name string) bool {
+	return p.namingPattern == nil || p.namingPattern.MatchString(name)
+}
+
+// invalidTagValues checks present, non-empty tag values against the tag value
+// rules and reports each tag whose value does not match, in tag name order
+func (p CompliancePolicy) invalidTagValues(tags map[string]string) []InvalidTagValue {
+	var invalid []InvalidTagValue
+	for _, tag := range slices.Sorted(maps.Keys(p.tagValuePatterns)) {
+		value, exists := tags[tag]
+		if !exists || strings.TrimSpace(value) == "" {
+			continue
+		}
+		if pattern := p.tagValuePatterns[tag]; !pattern.MatchString(value) {
+			invalid = append(invalid, InvalidTagValue{Tag: tag, Value: value, Pattern: pattern.String()})
+		}
+	}
+	return invalid
+}
+
+func evaluateCompliancePolicy(analysis RepositoryAnalysis, policy CompliancePolicy) []ComplianceViolation {
+	var violations []ComplianceViolation
+	violations = append(violations, checkAllowedProviders(analysis.Providers, policy)...)
+	violations = append(violations, checkAllowedBackend(analysis.BackendConfig, policy)...)
+	violations = append(violations, checkMinVersions(analysis.Providers, policy)...)
+	return violations
+}
+
+func checkAllowedProviders(providers ProvidersAnalysis, policy CompliancePolicy) []ComplianceViolation {
+	if len(policy.AllowedProviders) == 0 {
+		return nil
+	}
+
+	disallowed := lo.Filter(providers.ProviderDetails, func(provider ProviderDetail, _ int) bool {
+		return !lo.Contains(policy.AllowedProviders, provider.Source)
+	})
+	return lo.Map(lo.Uniq(lo.Map(disallowed, func(provider ProviderDetail, _ int) string {
+		return provider.Source
+	})), func(source string, _ int) ComplianceViolation {
+		return ComplianceViolation{
+			Policy:  PolicyAllowedProviders,
+			Subject: source,
+			Message: fmt.Sprintf("provider %s is not in the allowed provider list", source),
+		}
+	})
+}
+
+func checkAllowedBackend(backend *BackendConfig, policy CompliancePolicy) []ComplianceViolation {
+	if len(policy.AllowedBackends) == 0 {
+		return nil
+	}
+
+	backendType := defaultBackendType
+	if backend != nil && backend.Type != nil {
+		backendType = *backend.Type
+	}
+	if lo.Contains(policy.AllowedBackends, backendType) {
+		return nil
+	}
+
+	return []ComplianceViolation{{
+		Policy:  PolicyAllowedBackends,
+		Subject: backendType,
+		Message: fmt.Sprintf("backend %s is not in the allowed backend list", backendType),
+	}}
+}
+
+func checkMinVersions(providers ProvidersAnalysis, policy CompliancePolicy) []ComplianceViolation {
+	var violations []ComplianceViolation
+	for _, provider := range providers.ProviderDetails {
+		minVersion, required := policy.MinVersions[provider.Source]
+		if !required {
+			continue
+		}
+		if constraintMeetsMinimum(provider.Version, minVersion) {
+			continue
+		}
+		violations = append(violations, ComplianceViolation{
+			Policy:  PolicyMinVersions,
+			Subject: provider.Source,
+			Message: fmt.Sprintf("provider %s version %q does not guarantee minimum version %s",
+				provider.Source, provider.Version, minVersion),
+		})
+	}
+	return violations
+}
+
+func checkResourceNaming(resourceType, resourceName string, policy CompliancePolicy) *ComplianceViolation {
+	if policy.resourceNameAllowed(resourceName) {
+		return nil
+	}
+	subject := resourceType + "." + resourceName
+	return &ComplianceViolation{
+		Policy:  PolicyNamingRegex,
+		Subject: subject,
+		Message: fmt.Sprintf("resource %s does not match naming pattern %s", subject, policy.NamingRegex),
+	}
+}
+
+// constraintMeetsMinimum reports whether the lowest version permitted by a
+// constraint such as "~> 5.0" or ">= 4.2, < 6.0" is at least minVersion.
+// Unpinned or unparseable constraints never meet a minimum.
+func constraintMeetsMinimum(constraint, minVersion string) bool {
+	lowest, ok := lowestConstraintVersion(constraint)
+	if !ok {
+		return false
+	}
+	minimum, ok := parseVersionNumbers(minVersion)
+	if !ok {
+		return false
+	}
+	return compareVersionNumbers(lowest, minimum) >= 0
+}
+
+func lowestConstraintVersion(constraint string) ([]int, bool) {
+	var lowest []int
+	for _, part := range strings.Split(constraint, ",") {
+		part = strings.TrimSpace(part)
+		if strings.HasPrefix(part, "<") || strings.HasPrefix(part, "!=") {
+			continue
+		}
+		version, ok := parseVersionNumbers(strings.TrimLeft(part, "~>=v "))
+		if !ok {
+			continue
+		}
+		if lowest == nil || compareVersionNumbers(version, lowest) < 0 {
+			lowest = version
+		}
+	}
+	return lowest, lowest != nil
+}
+
+func parseVersionNumbers(version string) ([]int, bool) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if version == "" {
+		return nil, false
+	}
+
+	// Drop pre-release and build metadata, e.g. 1.2.0-beta1
+	if idx := strings.IndexAny(version, "-+"); idx >= 0 {
+		version = version[:idx]
+	}
+
+	segments := strings.Split(version, ".")
+	numbers := make([]int, 0, len(segments))
+	for _, segment := range segments {
+		number, err := strconv.Atoi(segment)
+		if err != nil || number < 0 {
+			return nil, false
+		}
+		numbers = append(numbers, number)
+	}
+	return numbers, true
+}
+
+func compareVersionNumbers(a, b []int) int {
+	for i := 0; i < max(len(a), len(b)); i++ {
+		var left, right int
+		if i < len(a) {
+			left = a[i]
+		}
+		if i < len(b) {
+			right = b[i]
+		}
+		if left != right {
+			if left < right {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestLoadCompliancePolicy(t *testing.T) {
+	writePolicy := func(t *testing.T, content string) string {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "compliance.yaml")
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write compliance config: %v", err)
+		}
+		return path
+	}
+
+	t.Run("loads every policy from a single document", func(t *testing.T) {
+		// Given: a compliance config declaring all supported policies
+		path := writePolicy(t, `
+required_tags: ["Team", "Environment"]
+allowed_providers: ["hashicorp/aws"]
+allowed_backends: ["s3"]
+naming_regex: "^[a-z0-9_]+$"
+min_versions:
+  hashicorp/aws: "5.0.0"
+`)
+
+		// When: the policy document is loaded
+		policy, err := loadCompliancePolicy(path)
+
+		// Then: each policy should be populated
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(policy.RequiredTags) != 2 || policy.RequiredTags[0] != "Team" {
+			t.Errorf("Expected required tags [Team Environment], got %v", policy.RequiredTags)
+		}
+		if len(policy.AllowedProviders) != 1 || len(policy.AllowedBackends) != 1 {
+			t.Errorf("Expected allowed providers and backends, got %v and %v", policy.AllowedProviders, policy.AllowedBackends)
+		}
+		if policy.MinVersions["hashicorp/aws"] != "5.0.0" {
+			t.Errorf("Expected min version for hashicorp/aws, got %v", policy.MinVersions)
+		}
+		if policy.resourceNameAllowed("Bad-Name") {
+			t.Error("Expected naming regex to reject Bad-Name")
+		}
+	})
+
+	t.Run("rejects invalid policy documents", func(t *testing.T) {
+		tests := []struct {
+			name        string
+			content     string
+			expectedErr string
+		}{
+			{"unknown policy key", `required_tag: ["Owner"]`, "field required_tag not found"},
+			{"invalid naming regex", `naming_regex: "[unclosed"`, "naming_regex"},
+			{"invalid min version", "min_versions:\n  hashicorp/aws: latest", "invalid version"},
+			{"empty required tag", `required_tags: ["Owner", " "]`, "required_tags"},
+			{"invalid tag value rule", "tag_value_rules:\n  Environment: \"(prod\"", "tag_value_rules"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				// Given: a malformed compliance config
+				path := writePolicy(t, tt.content)
+
+				// When: the policy document is loaded
+				_, err := loadCompliancePolicy(path)
+
+				// Then: validation should fail with a descriptive error
+				if err == nil || !strings.Contains(err.Error(), tt.expectedErr) {
+					t.Errorf("Expected error containing %q, got %v", tt.expectedErr, err)
+				}
+			})
+		}
+	})
+
+	t.Run("missing file returns an error", func(t *testing.T) {
+		_, err := loadCompliancePolicy(filepath.Join(t.TempDir(), "missing.yaml"))
+		if err == nil {
+			t.Error("Expected error for missing compliance config")
+		}
+	})
+}
+
+func TestCompliancePolicyAppliedDuringAnalysis(t *testing.T) {
+	// Given: a policy document and a repository breaking several of its rules
+	policy, err := parseCompliancePolicy([]byte(`
+required_tags: ["Team"]
+allowed_providers: ["hashicorp/aws"]
+allowed_backends: ["s3"]
+naming_regex: "^[a-z0-9_]+$"
+min_versions:
+  hashicorp/aws: "5.0.0"
+`))
+	if err != nil {
+		t.Fatalf("Failed to parse policy: %v", err)
+	}
+	repoDir := createTempTerraformRepo(t, map[string]string{
+		"main.tf": `
+terraform {
+  backend "gcs" {}
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 4.0"
+    }
+    random = {
+      source  = "hashicorp/random"
+      version = "3.5.1"
+    }
+  }
+}
+
+resource "aws_s3_bucket" "Bad-Name" {
+  tags = {
+    Team = "platform"
+  }
+}
+
+resource "aws_sqs_queue" "jobs" {}
+`,
+	})
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	options := analysisOptionsFromConfig(Config{Compliance: policy})
+
+	// When: the repository is analyzed with the policy
+	analysis, err := analyzeRepositoryWithOptions(repoDir, options, logger)
+
+	// Then: every policy should be applied
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	untagged := analysis.ResourceAnalysis.UntaggedResources
+	if len(untagged) != 1 || untagged[0].Name != "jobs" || len(untagged[0].MissingTags) != 1 || untagged[0].MissingTags[0] != "Team" {
+		t.Errorf("Expected only aws_sqs_queue.jobs to miss the Team tag, got %+v", untagged)
+	}
+
+	violations := make(map[string]string)
+	for _, violation := range analysis.ComplianceViolations {
+		violations[violation.Policy] = violation.Subject
+	}
+	expected := map[string]string{
+		PolicyAllowedProviders: "hashicorp/random",
+		PolicyAllowedBackends:  "gcs",
+		PolicyNamingRegex:      "aws_s3_bucket.Bad-Name",
+		PolicyMinVersions:      "hashicorp/aws",
+	}
+	if len(analysis.ComplianceViolations) != len(expected) {
+		t.Errorf("Expected %d violations, got %+v", len(expected), analysis.ComplianceViolations)
+	}
+	for policyName, subject := range expected {
+		if violations[policyName] != subject {
+			t.Errorf("Expected %s violation for %s, got %q", policyName, subject, violations[policyName])
+		}
+	}
+}
+
+func TestTagValueRules(t *testing.T) {
+	policy, err := parseCompliancePolicy([]byte(`
+tag_value_rules:
+  Environment: "^(prod|staging|dev)$"
+  CostCenter: "^CC-\\d{4}$"
+`))
+	if err != nil {
+		t.Fatalf("Failed to parse policy: %v", err)
+	}
+	options := AnalysisOptions{Policy: policy}
+
+	t.Run("matching values are accepted", func(t *testing.T) {
+		// Given: a resource whose tag values satisfy every rule
+		content := `
+resource "aws_instance" "web" {
+  tags = {
+    Environment = "prod"
+    CostCenter  = "CC-1234"
+  }
+}
+`
+		// When: resources are parsed
+		result := parseResourcesWithOptions(content, "main.tf", options)
+
+		// Then: no invalid tag values should be recorded
+		if len(result.InvalidTagResources) != 0 {
+			t.Errorf("Expected no invalid tags, got %+v", result.InvalidTagResources)
+		}
+	})
+
+	t.Run("violating values are recorded with tag, value and pattern", func(t *testing.T) {
+		// Given: a resource with a CostCenter in the wrong format
+		content := `
+resource "aws_instance" "web" {
+  tags = {
+    Environment = "prod"
+    CostCenter  = "1234"
+  }
+}
+`
+		// When: resources are parsed
+		result := parseResourcesWithOptions(content, "main.tf", options)
+
+		// Then: the CostCenter value should be reported against its pattern
+		if len(result.InvalidTagResources) != 1 {
+			t.Fatalf("Expected one invalid resource, got %+v", result.InvalidTagResources)
+		}
+		resource := result.InvalidTagResources[0]
+		expected := []InvalidTagValue{{Tag: "CostCenter", Value: "1234", Pattern: `^CC-\d{4}$`}}
+		if resource.ResourceType != "aws_instance" || resource.Name != "web" || !slices.Equal(resource.InvalidTags, expected) {
+			t.Errorf("Expected aws_instance.web with %+v, got %+v", expected, resource)
+		}
+	})
+
+	t.Run("invalid values reach the repository analysis", func(t *testing.T) {
+		// Given: a repository with an unexpected Environment value
+		repoDir := createTempTerraformRepo(t, map[string]string{
+			"main.tf": `
+resource "aws_s3_bucket" "logs" {
+  tags = { Environment = "production" }
+}
+`,
+		})
+		logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+		// When: the repository is analyzed
+		analysis, err := analyzeRepositoryWithOptions(repoDir, options, logger)
+
+		// Then: the resource analysis should list the invalid tag
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		invalid := analysis.ResourceAnalysis.InvalidTagResources
+		if len(invalid) != 1 || invalid[0].InvalidTags[0].Value != "production" {
+			t.Errorf("Expected Environment=production to be invalid, got %+v", invalid)
+		}
+	})
+}
+
+func TestConstraintMeetsMinimum(t *testing.T) {
+	tests := []struct {
+		constraint string
+		minimum    string
+		expected   bool
+	}{
+		{"~> 5.0", "5.0.0", true},
+		{"~> 4.67", "5.0", false},
+		{">= 4.2, < 6.0", "4.0", true},
+		{"5.31.0", "5.31", true},
+		{"v1.2.0-beta1", "1.2.0", true},
+		{"", "1.0", false},
+		{"< 3.0", "1.0", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint+" vs "+tt.minimum, func(t *testing.T) {
+			if result := constraintMeetsMinimum(tt.constraint, tt.minimum); result != tt.expected {
+				t.Errorf("constraintMeetsMinimum(%q, %q) = %v, want %v", tt.constraint, tt.minimum, result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/samber/lo"
+)
+
+// ============================================================================
+// DRY RUN - Listing the repositories a run would analyze (--dry-run)
+// ============================================================================
+
+// dryRunRepositories resolves the repositories the configuration selects
+// without analyzing them. --local-path and explicit repository lists are
+// resolved without cloning; match and exclude filters are applied by ghorg,
+// so those organizations are cloned into a temporary workspace and removed.
+func dryRunRepositories(ctx context.Context, config Config, clone cloneRunner, logger *slog.Logger) ([]Repository, error) {
+	if config.LocalPath != "" {
+		repositories, err := discoverLocalRepositories(config.LocalPath, config.SingleRepo)
+		if err != nil {
+			return nil, err
+		}
+		return filterRecentRepositories(ctx, repositories, config.Since, gitLatestCommitTime, logger), nil
+	}
+
+	targets, err := explicitTargetRepos(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var repositories []Repository
+	for _, org := range config.Organizations {
+		if len(targets) > 0 {
+			repositories = append(repositories, lo.Map(targets, func(name string, _ int) Repository {
+				return Repository{Name: name, Organization: org}
+			})...)
+			continue
+		}
+
+		cloned, err := dryRunCloneOrganization(ctx, org, config, clone, logger)
+		if err != nil {
+			return nil, err
+		}
+		repositories = append(repositories, cloned...)
+	}
+	return repositories, nil
+}
+
+// explicitTargetRepos returns the --repo, --target-repos or --target-repos-file list, if any
+func explicitTargetRepos(config Config) ([]string, error) {
+	if len(config.TargetRepos) > 0 {
+		return config.TargetRepos, nil
+	}
+	if config.TargetReposFile != "" {
+		return readTargetReposFromFile(config.TargetReposFile)
+	}
+	return nil, nil
+}
+
+func dryRunCloneOrganization(ctx context.Context, org string, config Config, clone cloneRunner, logger *slog.Logger) ([]Repository, error) {
+	// The clones are only needed for their names, so --keep-clones does not apply
+	workspaceConfig := config
+	workspaceConfig.KeepClones = false
+	tempDir, cleanup, err := setupWorkspaceWithRetry(logger, workspaceConfig)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanupWorkspace(cleanup, tempDir, org, logger)
+
+	operation := createCloneOperation(org, tempDir, config)
+	if err := executeCloneWithRetry(ctx, operation, logger, clone); err != nil {
+		return nil, err
+	}
+
+	repositories, err := discoverRepositoriesWrapper(tempDir, org)
+	if err != nil {
+		return nil, err
+	}
+	// The workspace is removed on return, so only names are reported
+	return lo.Map(filterRecentRepositories(ctx, repositories, config.Since, gitLatestCommitTime, logger), func(repo Repository, _ int) Repository {
+		return Repository{Name: repo.Name, Organization: repo.Organization}
+	}), nil
+}
+
+// printDryRunPlan writes one org/repo line per repository followed by the count
+func printDryRunPlan(w io.Writer, repositories []Repository) error {
+	for _, repo := range repositories {
+		if _, err := fmt.Fprintf(w, "%s/%s\n", repo.Organization, repo.Name); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "%d repositories would be analyzed\n", len(repositories))
+	return err
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func repositoryReferences(repositories []Repository) []string {
+	references := make([]string, 0, len(repositories))
+	for _, repo := range repositories {
+		references = append(references, repo.Organization+"/"+repo.Name)
+	}
+	return references
+}
+
+func TestDryRunRepositories(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("lists local repositories without analyzing them", func(t *testing.T) {
+		// Given: a local path with two repositories and a hidden directory
+		localPath := t.TempDir()
+		for _, name := range []string{"network", "storage", ".cache"} {
+			if err := os.MkdirAll(filepath.Join(localPath, name), 0755); err != nil {
+				t.Fatalf("Failed to create %s: %v", name, err)
+			}
+		}
+
+		// When: the dry run resolves repositories
+		repositories, err := dryRunRepositories(context.Background(), Config{LocalPath: localPath}, executeClonePhase, logger)
+
+		// Then: both repositories are listed under the local organization
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if references := repositoryReferences(repositories); !reflect.DeepEqual(references, []string{"local/network", "local/storage"}) {
+			t.Errorf("Expected local/network and local/storage, got %v", references)
+		}
+	})
+
+	t.Run("resolves explicit target lists without cloning", func(t *testing.T) {
+		clone := func(context.Context, CloneOperation, *slog.Logger) error {
+			t.Fatal("Expected no clone for an explicit repository list")
+			return nil
+		}
+		config := Config{Organizations: []string{"acme", "globex"}, TargetRepos: []string{"api", "web"}}
+
+		repositories, err := dryRunRepositories(context.Background(), config, clone, logger)
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := []string{"acme/api", "acme/web", "globex/api", "globex/web"}
+		if references := repositoryReferences(repositories); !reflect.DeepEqual(references, expected) {
+			t.Errorf("Expected %v, got %v", expected, references)
+		}
+	})
+
+	t.Run("lists what ghorg selected for name filters", func(t *testing.T) {
+		// Given: a clone that yields the repositories matching the prefix
+		clone := func(_ context.Context, operation CloneOperation, _ *slog.Logger) error {
+			for _, name := range []string{"terraform-network", "terraform-storage"} {
+				if err := os.MkdirAll(filepath.Join(operation.TempDir, operation.Org, name), 0755); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		config := Config{Organizations: []string{"acme"}, MatchPrefix: []string{"terraform-"}}
+
+		repositories, err := dryRunRepositories(context.Background(), config, clone, logger)
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if references := repositoryReferences(repositories); !reflect.DeepEqual(references, []string{"acme/terraform-network", "acme/terraform-storage"}) {
+			t.Errorf("Expected the cloned repositories, got %v", references)
+		}
+	})
+}
+
+func TestPrintDryRunPlan(t *testing.T) {
+	var out bytes.Buffer
+	repositories := []Repository{{Name: "api", Organization: "acme"}, {Name: "web", Organization: "acme"}}
+
+	if err := printDryRunPlan(&out, repositories); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := "acme/api\nacme/web\n2 repositories would be analyzed\n"
+	if out.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, out.String())
+	}
+}
+
+func TestDryRunWritesNoReports(t *testing.T) {
+	// Given: a local repository and an output directory
+	viper.Reset()
+	defer viper.Reset()
+	localPath := createTempTerraformRepo(t, map[string]string{"repo/main.tf": `resource "aws_s3_bucket" "data" {}`})
+	outputDir := t.TempDir()
+	viper.Set("local.path", localPath)
+	viper.Set("processing.max_goroutines", 1)
+	viper.Set("processing.clone_concurrency", 1)
+	viper.Set("processing.timeout", "10s")
+	viper.Set("analysis.dry_run", true)
+	viper.Set("output.format", "all")
+	viper.Set("output.directory", outputDir)
+
+	// When: analyze runs with --dry-run
+	if err := runAnalyze(&cobra.Command{}, []string{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Then: no report files are written
+	entries, err := os.ReadDir(outputDir)
+	if err != nil || len(entries) != 0 {
+		t.Errorf("Expected an empty output directory, got %v (%v)", entries, err)
+	}
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/bitfield/script"
+	"github.com/samber/lo"
+)
+
+// ============================================================================
+// FINDINGS - Flat, uniform export of every finding for integrations
+// ============================================================================
+
+// Finding types
+const (
+	FindingUntagged     = "untagged"
+	FindingUnpinned     = "unpinned"
+	FindingInvalidTag   = "invalid_tag_value"
+	FindingSecret       = "secret"
+	FindingViolation    = "policy_violation"
+	FindingTerraformDir = "committed_terraform_dir"
+	FindingLegacyHCL    = "legacy_hcl"
+)
+
+// Finding severities
+const (
+	SeverityHigh   = "high"
+	SeverityMedium = "medium"
+	SeverityLow    = "low"
+)
+
+// Finding is one issue in a repository. File and Line are left empty when
+// the underlying check does not track a location.
+type Finding struct {
+	Type         string `json:"type"`
+	Severity     string `json:"severity"`
+	Organization string `json:"organization"`
+	Repo         string `json:"repo"`
+	File         string `json:"file"`
+	Line         int    `json:"line"`
+	Message      string `json:"message"`
+}
+
+// FindingsSummary counts every finding, including those left out of the
+// detail list once the global cap is reached
+type FindingsSummary struct {
+	TotalFindings             int            `json:"total_findings"`
+	CountsByType              map[string]int `json:"counts_by_type"`
+	FindingsTruncatedGlobally bool           `json:"findings_truncated_globally"`
+	OmittedFindings           int            `json:"omitted_findings"`
+}
+
+// FindingsExport is the findings-json file. The summary counts every finding,
+// so a list truncated by --max-total-findings is not mistaken for the total.
+type FindingsExport struct {
+	Summary  FindingsSummary `json:"summary"`
+	Findings []Finding       `json:"findings"`
+}
+
+// SetMaxTotalFindings caps the findings detail list across all repositories; 0 means unlimited
+func (r *Reporter) SetMaxTotalFindings(limit int) {
+	r.maxTotalFindings = limit
+}
+
+// Findings flattens the untagged resources, invalid tag values, unpinned
+// providers and modules, secret findings, committed .terraform directories, legacy HCL1
+// files and policy violations of every successfully analyzed repository,
+// up to the global cap
+func (r *Reporter) Findings() []Finding {
+	findings, _ := r.collectFindings()
+	return findings
+}
+
+// FindingsSummary reports accurate finding totals regardless of the cap
+func (r *Reporter) FindingsSummary() FindingsSummary {
+	_, summary := r.collectFindings()
+	return summary
+}
+
+// collectFindings stops collecting detail once the cap is reached but keeps counting
+func (r *Reporter) collectFindings() ([]Finding, FindingsSummary) {
+	findings := []Finding{}
+	summary := FindingsSummary{CountsByType: make(map[string]int)}
+	for _, result := range r.getSuccessfulResults() {
+		for _, finding := range repositoryFindings(result) {
+			summary.TotalFindings++
+			summary.CountsByType[finding.Type]++
+			if r.maxTotalFindings > 0 && len(findings) >= r.maxTotalFindings {
+				summary.OmittedFindings++
+				continue
+			}
+			findings = append(findings, finding)
+		}
+	}
+	summary.FindingsTruncatedGlobally = summary.OmittedFindings > 0
+	return findings, summary
+}
+
+func repositoryFindings(result AnalysisResult) []Finding {
+	analysis := result.Analysis
+	newFinding := func(findingType, severity, message string) Finding {
+		return Finding{
+			Type:         findingType,
+			Severity:     severity,
+			Organization: result.Organization,
+			Repo:         result.RepoName,
+			Message:      message,
+		}
+	}
+
+	var findings []Finding
+	for _, resource := range analysis.ResourceAnalysis.UntaggedResources {
+		findings = append(findings, newFinding(FindingUntagged, SeverityLow,
+			fmt.Sprintf("%s.%s is missing tags: %s", resource.ResourceType, resource.Name, strings.Join(resource.MissingTags, ", "))))
+	}
+	for _, resource := range analysis.ResourceAnalysis.InvalidTagResources {
+		for _, tag := range resource.InvalidTags {
+			findings = append(findings, newFinding(FindingInvalidTag, SeverityLow,
+				fmt.Sprintf("%s.%s tag %s=%q does not match %s", resource.ResourceType, resource.Name, tag.Tag, tag.Value, tag.Pattern)))
+		}
+	}
+	for _, provider := range unpinnedProviders(analysis.Providers.ProviderDetails) {
+		findings = append(findings, newFinding(FindingUnpinned, SeverityLow,
+			fmt.Sprintf("provider %s has no version constraint", provider)))
+	}
+	for _, module := range analysis.Modules.UnpinnedModules {
+		findings = append(findings, newFinding(FindingUnpinned, SeverityLow, unpinnedModuleMessage(module)))
+	}
+	for _, secret := range analysis.SecretFindings {
+		finding := newFinding(FindingSecret, SeverityHigh,
+			fmt.Sprintf("%s.%s %s: possible %s (%s)", secret.ResourceType, secret.ResourceName, secret.Attribute, secret.Rule, secret.Match))
+		finding.File, finding.Line = secret.File, secret.Line
+		findings = append(findings, finding)
+	}
+	for _, dir := range analysis.CommittedTerraformDirs {
+		finding := newFinding(FindingTerraformDir, SeverityMedium,
+			"committed .terraform directory contains downloaded providers and modules")
+		finding.File = dir.Path
+		findings = append(findings, finding)
+	}
+	for _, legacy := range analysis.LegacyHCLFiles {
+		finding := newFinding(FindingLegacyHCL, SeverityMedium,
+			fmt.Sprintf("legacy HCL1 syntax (%s) is skipped by analysis; migrate with terraform 0.12upgrade", legacy.Reason))
+		finding.File = legacy.File
+		findings = append(findings, finding)
+	}
+	for _, violation := range analysis.ComplianceViolations {
+		findings = append(findings, newFinding(FindingViolation, SeverityMedium,
+			fmt.Sprintf("%s: %s: %s", violation.Policy, violation.Subject, violation.Message)))
+	}
+	return findings
+}
+
+// unpinnedModuleMessage says what pin an unpinned module call is missing
+func unpinnedModuleMessage(module ModuleDetail) string {
+	if module.SourceKind == ModuleSourceGit {
+		return fmt.Sprintf("module %s does not pin a git ref", module.Source)
+	}
+	return fmt.Sprintf("module %s has no version constraint", module.Source)
+}
+
+// unpinnedProviders returns providers that have no version constraint in any
+// declaration, so a provider block alongside a pinned required_providers
+// entry is not reported
+func unpinnedProviders(providers []ProviderDetail) []string {
+	pinned := providerSourcesMatching(providers, hasVersionConstraint)
+
+	return lo.Uniq(lo.FilterMap(providers, func(p ProviderDetail, _ int) (string, bool) {
+		return p.Source, p.Version == "" && !pinned[canonicalProviderSource(p.Source, nil)]
+	}))
+}
+
+// providerPinningCoverage counts the distinct providers of each repository
+// and how many of them are pinned; like unpinnedProviders, a provider counts
+// as pinned when any of its declarations in the repository is
+func providerPinningCoverage(repositories []RepositoryAnalysis) (pinned, total int) {
+	for _, repo := range repositories {
+		providerPinned := providerSourcesMatching(repo.Providers.ProviderDetails, func(provider ProviderDetail) bool {
+			classification, _ := classifyProviderVersion(provider.Version)
+			return classification == VersionPinned
+		})
+		total += len(providerPinned)
+		pinned += len(lo.PickByValues(providerPinned, []bool{true}))
+	}
+	return pinned, total
+}
+
+func (r *Reporter) ExportFindingsJSON(filename string) error {
+	findings, summary := r.collectFindings()
+	if summary.FindingsTruncatedGlobally {
+		slog.Warn("Findings detail truncated by --max-total-findings",
+			"total_findings", summary.TotalFindings,
+			"omitted_findings", summary.OmittedFindings)
+	}
+
+	jsonData, err := json.MarshalIndent(FindingsExport{Summary: summary, Findings: findings}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal findings JSON: %w", err)
+	}
+
+	_, err = script.Echo(string(jsonData)).WriteFile(filename)
+	if err != nil {
+		return fmt.Errorf("failed to write findings JSON file: %w", err)
+	}
+
+	slog.Info("Findings JSON exported", "file", filename)
+	return nil
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestFindingsJSON(t *testing.T) {
+	reporter := NewReporter()
+	reporter.AddResults([]AnalysisResult{
+		{
+			RepoName:     "network",
+			Organization: "acme",
+			Analysis: RepositoryAnalysis{
+				RepositoryPath: "/repos/network",
+				Providers: ProvidersAnalysis{ProviderDetails: []ProviderDetail{
+					{Source: "aws", Regions: []string{"us-east-1"}},
+					{Source: "hashicorp/aws", Version: "~> 5.0"},
+					{Source: "hashicorp/random"},
+				}},
+				Modules: ModulesAnalysis{UnpinnedModules: []ModuleDetail{
+					{Source: "terraform-aws-modules/vpc/aws", SourceKind: ModuleSourceRegistry, Count: 1},
+					{Source: "git::https://github.com/acme/dns.git", SourceKind: ModuleSourceGit, Count: 1},
+				}},
+				ResourceAnalysis: ResourceAnalysis{
+					UntaggedResources: []UntaggedResource{
+						{ResourceType: "aws_vpc", Name: "main", MissingTags: []string{"Owner"}},
+						{ResourceType: "aws_subnet", Name: "private", MissingTags: []string{"Owner", "Project"}},
+					},
+					InvalidTagResources: []InvalidTagResource{
+						{ResourceType: "aws_vpc", Name: "main", InvalidTags: []InvalidTagValue{{Tag: "Environment", Value: "qa", Pattern: "^(prod|dev)$"}}},
+					},
+				},
+				SecretFindings: []SecretFinding{
+					{ResourceType: "aws_instance", ResourceName: "web", Attribute: "user_data", File: "main.tf", Line: 12, Rule: "aws-access-key", Match: "AKIA****"},
+				},
+				CommittedTerraformDirs: []CommittedTerraformDirFinding{{Path: "modules/.terraform"}},
+				ComplianceViolations: []ComplianceViolation{
+					{Policy: PolicyAllowedBackends, Subject: "local", Message: "backend is not allowed"},
+				},
+			},
+		},
+		{RepoName: "broken", Organization: "acme", Error: os.ErrNotExist},
+	})
+
+	t.Run("every finding is flattened into the uniform schema", func(t *testing.T) {
+		// When: findings are collected
+		findings := reporter.Findings()
+
+		// Then: counts should match the underlying findings per type
+		counts := make(map[string]int)
+		for _, finding := range findings {
+			counts[finding.Type]++
+			if finding.Organization != "acme" || finding.Repo != "network" || finding.Severity == "" || finding.Message == "" {
+				t.Errorf("Expected organization, repo, severity and message on %+v", finding)
+			}
+		}
+		expected := map[string]int{FindingUntagged: 2, FindingInvalidTag: 1, FindingUnpinned: 3, FindingSecret: 1, FindingTerraformDir: 1, FindingViolation: 1}
+		for findingType, count := range expected {
+			if counts[findingType] != count {
+				t.Errorf("Expected %d %s findings, got %d", count, findingType, counts[findingType])
+			}
+		}
+
+		if !slices.ContainsFunc(findings, func(f Finding) bool {
+			return f.Type == FindingUnpinned && f.Message == "module terraform-aws-modules/vpc/aws has no version constraint"
+		}) {
+			t.Errorf("Expected an unpinned finding for the vpc module, got %+v", findings)
+		}
+
+		secretIndex := slices.IndexFunc(findings, func(f Finding) bool { return f.Type == FindingSecret })
+		if findings[secretIndex].File != "main.tf" || findings[secretIndex].Line != 12 || findings[secretIndex].Severity != SeverityHigh {
+			t.Errorf("Expected secret finding at main.tf:12 with high severity, got %+v", findings[secretIndex])
+		}
+	})
+
+	t.Run("--format findings-json writes only the findings export", func(t *testing.T) {
+		// Given: the findings-json output format
+		viper.Reset()
+		tempDir := t.TempDir()
+		viper.Set("output.format", "findings-json")
+		viper.Set("output.directory", tempDir)
+
+		// When: reports are generated
+		if err := generateReports(reporter, Config{}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then: the file should hold the summary and the findings with every schema key
+		content, err := os.ReadFile(filepath.Join(tempDir, FindingsJSONFileName))
+		if err != nil {
+			t.Fatalf("Expected findings file: %v", err)
+		}
+		var export struct {
+			Summary  FindingsSummary  `json:"summary"`
+			Findings []map[string]any `json:"findings"`
+		}
+		if err := json.Unmarshal(content, &export); err != nil {
+			t.Fatalf("Expected a JSON object: %v", err)
+		}
+		if len(export.Findings) != 9 || export.Summary.TotalFindings != 9 || export.Summary.FindingsTruncatedGlobally {
+			t.Errorf("Expected 9 findings and an untruncated summary, got %d and %+v", len(export.Findings), export.Summary)
+		}
+		for _, record := range export.Findings {
+			for _, key := range []string{"type", "severity", "organization", "repo", "file", "line", "message"} {
+				if _, ok := record[key]; !ok {
+					t.Errorf("Expected key %q in %v", key, record)
+				}
+			}
+		}
+		if _, err := os.Stat(filepath.Join(tempDir, JSONReportFileName)); !os.IsNotExist(err) {
+			t.Errorf("Expected no full JSON report, got stat error %v", err)
+		}
+	})
+}
+
+func TestMaxTotalFindings(t *testing.T) {
+	// Given: two repositories with five findings in total and a global cap of 2
+	untagged := func(names ...string) []UntaggedResource {
+		resources := make([]UntaggedResource, 0, len(names))
+		for _, name := range names {
+			resources = append(resources, UntaggedResource{ResourceType: "aws_vpc", Name: name, MissingTags: []string{"Owner"}})
+		}
+		return resources
+	}
+	reporter := NewReporter()
+	reporter.AddResults([]AnalysisResult{
+		{RepoName: "network", Organization: "acme", Analysis: RepositoryAnalysis{
+			ResourceAnalysis: ResourceAnalysis{UntaggedResources: untagged("a", "b", "c")},
+		}},
+		{RepoName: "storage", Organization: "acme", Analysis: RepositoryAnalysis{
+			ResourceAnalysis: ResourceAnalysis{UntaggedResources: untagged("d")},
+			ComplianceViolations: []ComplianceViolation{
+				{Policy: PolicyAllowedBackends, Subject: "local", Message: "backend is not allowed"},
+			},
+		}},
+	})
+	reporter.SetMaxTotalFindings(2)
+
+	// When: findings and their summary are collected
+	findings := reporter.Findings()
+	summary := reporter.FindingsSummary()
+
+	// Then: the detail list is truncated while totals stay accurate
+	if len(findings) != 2 {
+		t.Errorf("Expected 2 findings in detail, got %d", len(findings))
+	}
+	if summary.TotalFindings != 5 || summary.OmittedFindings != 3 || !summary.FindingsTruncatedGlobally {
+		t.Errorf("Expected 5 total, 3 omitted and truncation flagged, got %+v", summary)
+	}
+	if summary.CountsByType[FindingUntagged] != 4 || summary.CountsByType[FindingViolation] != 1 {
+		t.Errorf("Expected counts by type to include omitted findings, got %v", summary.CountsByType)
+	}
+	if global := reporter.generateGlobalSummary(); global.Findings.TotalFindings != 5 {
+		t.Errorf("Expected global summary to report 5 findings, got %+v", global.Findings)
+	}
+	markdown := reporter.generateMarkdownContent()
+	for _, expected := range []string{"**Total findings**: 5", "(--max-total-findings)**: 3"} {
+		if !strings.Contains(markdown, expected) {
+			t.Errorf("Expected markdown to contain %q", expected)
+		}
+	}
+	exportPath := filepath.Join(t.TempDir(), FindingsJSONFileName)
+	if err := reporter.ExportFindingsJSON(exportPath); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var export FindingsExport
+	if content, err := os.ReadFile(exportPath); err != nil || json.Unmarshal(content, &export) != nil {
+		t.Fatalf("Expected a readable findings export: %v", err)
+	}
+	if len(export.Findings) != 2 || !export.Summary.FindingsTruncatedGlobally || export.Summary.OmittedFindings != 3 {
+		t.Errorf("Expected the export to flag 3 omitted findings, got %d findings and %+v", len(export.Findings), export.Summary)
+	}
+
+	// Then: without a cap nothing is omitted
+	reporter.SetMaxTotalFindings(0)
+	if summary := reporter.FindingsSummary(); len(reporter.Findings()) != 5 || summary.FindingsTruncatedGlobally {
+		t.Errorf("Expected all 5 findings without a cap, got %+v", summary)
+	}
+}
+
+func TestProviderPinningCoverage(t *testing.T) {
+	// Given: a repository pinning aws through required_providers and a repository with a range and a missing constraint
+	repositories := []RepositoryAnalysis{
+		{Providers: ProvidersAnalysis{ProviderDetails: []ProviderDetail{{Source: "aws"}, {Source: "hashicorp/aws", Version: "~> 5.0"}}}},
+		{Providers: ProvidersAnalysis{ProviderDetails: []ProviderDetail{{Source: "hashicorp/google", Version: ">= 4.0"}, {Source: "hashicorp/random"}}}},
+		{Providers: ProvidersAnalysis{ProviderDetails: []ProviderDetail{{Source: "integrations/github", Version: "6.2.1"}, {Source: "hashicorp/github"}}}},
+		{},
+	}
+
+	// When: coverage is computed
+	pinned, total := providerPinningCoverage(repositories)
+
+	// Then: each repository's providers are counted once, by canonical source
+	if pinned != 2 || total != 5 {
+		t.Errorf("Expected 2 of 5 providers pinned, got %d of %d", pinned, total)
+	}
+
+	// Then: a pinned integrations/github does not cover hashicorp/github
+	if unpinned := unpinnedProviders(repositories[2].Providers.ProviderDetails); !slices.Equal(unpinned, []string{"hashicorp/github"}) {
+		t.Errorf("Expected hashicorp/github to be unpinned, got %v", unpinned)
+	}
+	if unpinned := unpinnedProviders(repositories[0].Providers.ProviderDetails); len(unpinned) != 0 {
+		t.Errorf("Expected aws to be covered by hashicorp/aws, got %v", unpinned)
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bitfield/script"
+	"github.com/samber/lo"
+)
+
+// ============================================================================
+// MODULE GRAPH - Graphviz DOT export of module usage across repositories (--graph)
+// ============================================================================
+
+// ExportModuleGraph writes the module usage graph as a Graphviz DOT file,
+// e.g. for dot -Tsvg. It is written for every --format.
+func (r *Reporter) ExportModuleGraph(filename string) error {
+	var builder strings.Builder
+	writeModuleGraph(&builder, r.getSuccessfulResults())
+
+	if _, err := script.Echo(builder.String()).WriteFile(filename); err != nil {
+		return fmt.Errorf("failed to write module graph: %w", err)
+	}
+
+	slog.Info("Module graph exported", "file", filename)
+	return nil
+}
+
+// writeModuleGraph renders repositories as boxes and the modules they call
+// as ellipses. Remote modules are a single node however many repositories
+// call them, so modules shared by several repositories are filled as hubs.
+// Local modules are scoped to their repository and follow LocalModuleTree.
+func writeModuleGraph(w io.Writer, results []AnalysisResult) {
+	callers := make(map[string][]string)
+	for _, result := range results {
+		for _, module := range result.Analysis.Modules.UniqueModules {
+			if module.SourceKind != ModuleSourceLocal {
+				callers[module.Source] = lo.Union(callers[module.Source], []string{graphRepositoryName(result)})
+			}
+		}
+	}
+
+	fmt.Fprintln(w, "digraph modules {")
+	fmt.Fprintln(w, "  rankdir=LR;")
+	fmt.Fprintln(w, "  node [shape=ellipse];")
+
+	for _, result := range results {
+		repoName := graphRepositoryName(result)
+		repoID := "repo:" + repoName
+		fmt.Fprintf(w, "  %s [label=%s, shape=box];\n", strconv.Quote(repoID), strconv.Quote(repoName))
+
+		for _, module := range result.Analysis.Modules.UniqueModules {
+			if module.SourceKind == ModuleSourceLocal {
+				continue
+			}
+			attributes := ""
+			if module.Version != "" {
+				attributes = " [label=" + strconv.Quote(module.Version) + "]"
+			}
+			fmt.Fprintf(w, "  %s -> %s%s;\n", strconv.Quote(repoID), strconv.Quote("module:"+module.Source), attributes)
+		}
+
+		localID := func(dir string) string {
+			if dir == "." {
+				return repoID
+			}
+			return repoID + "//" + dir
+		}
+		declared := make(map[string]bool)
+		declareLocal := func(dir string, missing bool) {
+			if id := localID(dir); id != repoID && !declared[id] {
+				declared[id] = true
+				style := ""
+				if missing {
+					style = ", style=dashed"
+				}
+				fmt.Fprintf(w, "  %s [label=%s, shape=folder%s];\n", strconv.Quote(id), strconv.Quote(dir), style)
+			}
+		}
+		for _, node := range result.Analysis.Modules.LocalModuleTree {
+			if node.External {
+				continue
+			}
+			// Root modules outside the repository root hang off the repository
+			if node.Depth == 1 && node.CalledFrom != "." && !declared[localID(node.CalledFrom)] {
+				declareLocal(node.CalledFrom, false)
+				fmt.Fprintf(w, "  %s -> %s;\n", strconv.Quote(repoID), strconv.Quote(localID(node.CalledFrom)))
+			}
+			declareLocal(node.Path, node.Missing)
+			fmt.Fprintf(w, "  %s -> %s;\n", strconv.Quote(localID(node.CalledFrom)), strconv.Quote(localID(node.Path)))
+		}
+	}
+
+	for _, source := range slices.Sorted(maps.Keys(callers)) {
+		hub := ""
+		if len(callers[source]) > 1 {
+			hub = ", style=filled, fillcolor=lightblue"
+		}
+		fmt.Fprintf(w, "  %s [label=%s%s];\n", strconv.Quote("module:"+source), strconv.Quote(source), hub)
+	}
+	fmt.Fprintln(w, "}")
+}
+
+// graphRepositoryName is org/repo, or the repository alone for local analysis
+func graphRepositoryName(result AnalysisResult) string {
+	if result.Organization == "" {
+		return result.RepoName
+	}
+	return result.Organization + "/" + result.RepoName
+}
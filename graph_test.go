@@ -0,0 +1,66 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestModuleGraph(t *testing.T) {
+	// Given: two repositories sharing a registry module, one with a nested local module
+	vpc := ModuleDetail{Source: "terraform-aws-modules/vpc/aws", Version: "5.1.0", SourceKind: ModuleSourceRegistry, Count: 1}
+	reporter := NewReporter()
+	reporter.AddResults([]AnalysisResult{
+		{RepoName: "network", Organization: "acme", Analysis: RepositoryAnalysis{Modules: ModulesAnalysis{
+			UniqueModules: []ModuleDetail{
+				vpc,
+				{Source: "git::https://github.com/acme/dns.git?ref=v1", SourceKind: ModuleSourceGit, Count: 1},
+				{Source: "./modules/app", SourceKind: ModuleSourceLocal, Count: 1},
+			},
+			LocalModuleTree: []LocalModuleNode{
+				{Path: "modules/app", CalledFrom: ".", Source: "./modules/app", Depth: 1},
+				{Path: "modules/shared", CalledFrom: "modules/app", Source: "../shared", Depth: 2, Missing: true},
+			},
+		}}},
+		{RepoName: "platform", Organization: "acme", Analysis: RepositoryAnalysis{Modules: ModulesAnalysis{
+			UniqueModules: []ModuleDetail{vpc},
+		}}},
+	})
+
+	// When: the graph is exported
+	path := filepath.Join(t.TempDir(), "modules.dot")
+	if err := reporter.ExportModuleGraph(path); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected the graph file to be written: %v", err)
+	}
+	dot := string(content)
+
+	// Then: repositories and modules are nodes and module calls are edges
+	for _, line := range []string{
+		`digraph modules {`,
+		`"repo:acme/network" [label="acme/network", shape=box];`,
+		`"repo:acme/platform" [label="acme/platform", shape=box];`,
+		`"repo:acme/network" -> "module:terraform-aws-modules/vpc/aws" [label="5.1.0"];`,
+		`"repo:acme/platform" -> "module:terraform-aws-modules/vpc/aws" [label="5.1.0"];`,
+		`"repo:acme/network" -> "module:git::https://github.com/acme/dns.git?ref=v1";`,
+		`"module:terraform-aws-modules/vpc/aws" [label="terraform-aws-modules/vpc/aws", style=filled, fillcolor=lightblue];`,
+		`"module:git::https://github.com/acme/dns.git?ref=v1" [label="git::https://github.com/acme/dns.git?ref=v1"];`,
+		`"repo:acme/network//modules/app" [label="modules/app", shape=folder];`,
+		`"repo:acme/network" -> "repo:acme/network//modules/app";`,
+		`"repo:acme/network//modules/shared" [label="modules/shared", shape=folder, style=dashed];`,
+		`"repo:acme/network//modules/app" -> "repo:acme/network//modules/shared";`,
+	} {
+		if !strings.Contains(dot, "  "+line) && !strings.HasPrefix(dot, line) {
+			t.Errorf("Expected DOT output to contain %q, got:\n%s", line, dot)
+		}
+	}
+
+	// Then: local modules are not shared between repositories
+	if strings.Contains(dot, `"module:./modules/app"`) {
+		t.Errorf("Expected local modules to be scoped to their repository, got:\n%s", dot)
+	}
+}
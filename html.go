@@ -0,0 +1,200 @@
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/bitfield/script"
+	"github.com/samber/lo"
+)
+
+// ============================================================================
+// HTML - Self-contained HTML report with inline styling
+// ============================================================================
+
+type htmlSummaryItem struct {
+	Label string
+	Value int
+}
+
+type htmlRepositoryRow struct {
+	Name           string
+	Classification string
+	Complexity     int
+	Providers      int
+	Modules        int
+	Resources      int
+	DataSources    int
+	Variables      int
+	Outputs        int
+	Backend        string
+	Region         string
+}
+
+type htmlUntaggedRow struct {
+	Repository  string
+	Resource    string
+	MissingTags string
+}
+
+type htmlReportData struct {
+	GeneratedOn  string
+	Summary      []htmlSummaryItem
+	Repositories []htmlRepositoryRow
+	RequiredTags string
+	Untagged     []htmlUntaggedRow
+	SkippedRepos []string
+}
+
+// htmlReportTemplate keeps all styling inline so the file can be shared
+// without any external assets
+var htmlReportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Terraform Analysis Report</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2rem; color: #1f2328; }
+h1 { border-bottom: 2px solid #d0d7de; padding-bottom: .3rem; }
+h2 { margin-top: 2rem; }
+ul.summary { list-style: none; padding: 0; display: flex; flex-wrap: wrap; gap: .75rem; }
+ul.summary li { background: #f6f8fa; border: 1px solid #d0d7de; border-radius: 6px; padding: .5rem .75rem; }
+ul.summary strong { display: block; font-size: 1.25rem; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border: 1px solid #d0d7de; padding: .4rem .6rem; text-align: left; }
+th { background: #f6f8fa; }
+tr:nth-child(even) td { background: #fbfcfd; }
+.meta { color: #656d76; }
+</style>
+</head>
+<body>
+<h1>Terraform Analysis Report</h1>
+<p class="meta">Generated on: {{.GeneratedOn}}</p>
+
+<h2>Executive Summary</h2>
+<ul class="summary">
+{{- range .Summary}}
+<li><strong>{{.Value}}</strong>{{.Label}}</li>
+{{- end}}
+</ul>
+{{if .Repositories}}
+<h2>Repository Analysis Details</h2>
+<table>
+<thead><tr><th>Repository</th><th>Classification</th><th>Complexity</th><th>Providers</th><th>Modules</th><th>Resources</th><th>Data Sources</th><th>Variables</th><th>Outputs</th><th>Backend</th><th>Region</th></tr></thead>
+<tbody>
+{{- range .Repositories}}
+<tr><td>{{.Name}}</td><td>{{.Classification}}</td><td>{{.Complexity}}</td><td>{{.Providers}}</td><td>{{.Modules}}</td><td>{{.Resources}}</td><td>{{.DataSources}}</td><td>{{.Variables}}</td><td>{{.Outputs}}</td><td>{{.Backend}}</td><td>{{.Region}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{end}}
+{{- if .Untagged}}
+<h2>Resource Tagging Compliance</h2>
+<p>Found <strong>{{len .Untagged}}</strong> resources missing mandatory tags{{if .RequiredTags}} ({{.RequiredTags}}){{end}}.</p>
+<table>
+<thead><tr><th>Repository</th><th>Resource</th><th>Missing Tags</th></tr></thead>
+<tbody>
+{{- range .Untagged}}
+<tr><td>{{.Repository}}</td><td>{{.Resource}}</td><td>{{.MissingTags}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{end}}
+{{- if .SkippedRepos}}
+<h2>Repositories with No Relevant Content</h2>
+<ul>
+{{- range .SkippedRepos}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{end}}
+</body>
+</html>
+`))
+
+func (r *Reporter) ExportHTML(filename string) error {
+	htmlContent, err := r.generateHTMLContent()
+	if err != nil {
+		return err
+	}
+
+	_, err = script.Echo(htmlContent).WriteFile(filename)
+	if err != nil {
+		return fmt.Errorf("failed to write HTML file: %w", err)
+	}
+
+	slog.Info("HTML report exported", "file", filename, "type", "HTML")
+	return nil
+}
+
+func (r *Reporter) generateHTMLContent() (string, error) {
+	return renderHTMLReport(r.GenerateReport(), r.requiredTags())
+}
+
+// renderHTMLReport renders a report, including one loaded from a JSON report
+// file; requiredTags may be empty when the tag set is not known
+func renderHTMLReport(report ComprehensiveReport, requiredTags []string) (string, error) {
+	var builder strings.Builder
+	if err := htmlReportTemplate.Execute(&builder, buildHTMLReportData(report, requiredTags)); err != nil {
+		return "", fmt.Errorf("failed to render HTML report: %w", err)
+	}
+	return builder.String(), nil
+}
+
+// buildHTMLReportData gathers the same sections the Markdown report renders
+func buildHTMLReportData(report ComprehensiveReport, requiredTags []string) htmlReportData {
+	repositories := lo.Map(report.Repositories, func(repo RepositoryForJSON, _ int) RepositoryAnalysis {
+		return repo.RepositoryAnalysis
+	})
+	skippedRepos := lo.Map(report.FailedRepositories, func(failed FailedRepository, _ int) string {
+		return failed.Repository
+	})
+
+	data := htmlReportData{
+		GeneratedOn: time.Now().Format("2006-01-02 15:04:05 UTC"),
+		Summary: []htmlSummaryItem{
+			{Label: "Total repositories scanned", Value: report.GlobalSummary.TotalReposScanned},
+			{Label: "Repositories with content", Value: len(report.Repositories)},
+			{Label: "Repositories without Terraform files", Value: report.GlobalSummary.EmptyRepos},
+			{Label: "Repositories skipped", Value: len(skippedRepos)},
+			{Label: "Total providers found", Value: calculateTotalProviders(repositories)},
+			{Label: "Total modules found", Value: calculateTotalModules(repositories)},
+			{Label: "Total resources found", Value: calculateTotalResources(repositories)},
+			{Label: "Total data sources found", Value: calculateTotalDataSources(repositories)},
+			{Label: "Untagged resources", Value: lo.SumBy(repositories, func(repo RepositoryAnalysis) int {
+				return len(repo.ResourceAnalysis.UntaggedResources)
+			})},
+			{Label: "Total findings", Value: report.GlobalSummary.Findings.TotalFindings},
+		},
+		RequiredTags: strings.Join(requiredTags, ", "),
+		SkippedRepos: skippedRepos,
+	}
+
+	for _, repo := range repositories {
+		repoName := extractRepoName(repo.RepositoryPath)
+		data.Repositories = append(data.Repositories, htmlRepositoryRow{
+			Name:           repoName,
+			Classification: repo.Classification,
+			Complexity:     repo.ComplexityScore,
+			Providers:      repo.Providers.UniqueProviderCount,
+			Modules:        repo.Modules.TotalModuleCalls,
+			Resources:      repo.ResourceAnalysis.TotalResourceCount,
+			DataSources:    repo.DataSources.TotalCount,
+			Variables:      len(repo.VariableAnalysis.DefinedVariables),
+			Outputs:        repo.OutputAnalysis.OutputCount,
+			Backend:        getBackendType(repo.BackendConfig),
+			Region:         getBackendRegion(repo.BackendConfig),
+		})
+		for _, resource := range repo.ResourceAnalysis.UntaggedResources {
+			data.Untagged = append(data.Untagged, htmlUntaggedRow{
+				Repository:  repoName,
+				Resource:    resource.ResourceType + "." + resource.Name,
+				MissingTags: strings.Join(resource.MissingTags, ", "),
+			})
+		}
+	}
+	return data
+}
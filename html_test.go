@@ -0,0 +1,61 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestHTMLReport(t *testing.T) {
+	// Given: two analyzed repositories, one with an untagged resource whose name needs escaping
+	reporter := NewReporter()
+	reporter.AddResults([]AnalysisResult{
+		{RepoName: "network", Organization: "acme", Analysis: RepositoryAnalysis{
+			RepositoryPath: "/repos/network",
+			ResourceAnalysis: ResourceAnalysis{
+				TotalResourceCount: 2,
+				UntaggedResources:  []UntaggedResource{{ResourceType: "aws_vpc", Name: "<main>", MissingTags: []string{"Owner"}}},
+			},
+		}},
+		{RepoName: "storage", Organization: "acme", Analysis: RepositoryAnalysis{RepositoryPath: "/repos/storage"}},
+	})
+	viper.Reset()
+	tempDir := t.TempDir()
+	viper.Set("output.format", "html")
+	viper.Set("output.directory", tempDir)
+
+	// When: reports are generated with --format html
+	if err := generateReports(reporter, Config{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Then: a standalone document with inline styling and a row per repository is written
+	content, err := os.ReadFile(filepath.Join(tempDir, HTMLReportFileName))
+	if err != nil {
+		t.Fatalf("Expected HTML file: %v", err)
+	}
+	html := string(content)
+
+	style := regexp.MustCompile(`(?s)<style>(.*?)</style>`).FindStringSubmatch(html)
+	if style == nil || strings.TrimSpace(style[1]) == "" {
+		t.Error("Expected a non-empty <style> block")
+	}
+	if strings.Contains(html, "<link") || strings.Contains(html, "<script") {
+		t.Error("Expected no external assets")
+	}
+	if !strings.Contains(html, "<table>") {
+		t.Error("Expected a <table>")
+	}
+	for _, expected := range []string{"<td>network</td>", "<td>storage</td>", "aws_vpc.&lt;main&gt;", "<td>Owner</td>"} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("Expected HTML to contain %q", expected)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, MarkdownReportFileName)); !os.IsNotExist(err) {
+		t.Errorf("Expected no Markdown report, got stat error %v", err)
+	}
+}
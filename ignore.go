@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ============================================================================
+// IGNORE - gitignore-style exclusion of repository paths
+// ============================================================================
+
+// IgnoreFileName is read from each repository root
+const IgnoreFileName = ".tfanalyzerignore"
+
+// ignoreRule is one parsed pattern line
+type ignoreRule struct {
+	segments []string // Pattern split on "/"; "**" matches any number of segments
+	negate   bool     // "!pattern" re-includes a path an earlier rule excluded
+	dirOnly  bool     // "pattern/" only matches directories
+	anchored bool     // Patterns containing "/" match from the repository root
+}
+
+// pathIgnorer matches repository-relative paths against ignore rules with
+// gitignore semantics: the last matching rule wins, and a file inside an
+// excluded directory cannot be re-included because the directory is never walked.
+type pathIgnorer struct {
+	rules []ignoreRule
+}
+
+func parseIgnoreRule(line string) (ignoreRule, bool) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ignoreRule{}, false
+	}
+
+	var rule ignoreRule
+	if strings.HasPrefix(line, "!") {
+		rule.negate = true
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	rule.anchored = strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+	if line == "" {
+		return ignoreRule{}, false
+	}
+	rule.segments = strings.Split(line, "/")
+	return rule, true
+}
+
+func newPathIgnorer(patterns []string) *pathIgnorer {
+	ignorer := &pathIgnorer{}
+	for _, pattern := range patterns {
+		if rule, ok := parseIgnoreRule(pattern); ok {
+			ignorer.rules = append(ignorer.rules, rule)
+		}
+	}
+	return ignorer
+}
+
+// loadRepositoryIgnorer combines the global --ignore patterns with the
+// repository's .tfanalyzerignore, whose rules come last and so take precedence
+func loadRepositoryIgnorer(repoPath string, globalPatterns []string) (*pathIgnorer, error) {
+	patterns := append([]string{}, globalPatterns...)
+	file, err := os.Open(filepath.Join(repoPath, IgnoreFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return newPathIgnorer(patterns), nil
+	}
+	if err != nil {
+		return newPathIgnorer(patterns), fmt.Errorf("failed to open %s: %w", IgnoreFileName, err)
+	}
+	defer func() {
+		_ = file.Close() // Ignore close error for read-only operations
+	}()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		patterns = append(patterns, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return newPathIgnorer(globalPatterns), fmt.Errorf("failed to read %s: %w", IgnoreFileName, err)
+	}
+	return newPathIgnorer(patterns), nil
+}
+
+// validateIgnorePatterns rejects --ignore patterns path.Match cannot compile
+func validateIgnorePatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		rule, ok := parseIgnoreRule(pattern)
+		if !ok {
+			continue
+		}
+		for _, segment := range rule.segments {
+			if _, err := path.Match(segment, ""); err != nil {
+				return fmt.Errorf("invalid --ignore pattern %q: %w", pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Ignored reports whether relPath, relative to the repository root and
+// using "/" separators, is excluded. A nil ignorer excludes nothing.
+func (i *pathIgnorer) Ignored(relPath string, isDir bool) bool {
+	if i == nil || relPath == "." || relPath == "" {
+		return false
+	}
+	segments := strings.Split(relPath, "/")
+	ignored := false
+	for _, rule := range i.rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+		if rule.matches(segments) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+func (r ignoreRule) matches(segments []string) bool {
+	if r.anchored {
+		return matchSegments(r.segments, segments)
+	}
+	// Unanchored patterns such as "*.tfvars" match the name at any depth
+	return matchSegments(r.segments, segments[len(segments)-1:])
+}
+
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+	if pattern[0] == "**" {
+		for skip := 0; skip <= len(segments); skip++ {
+			if matchSegments(pattern[1:], segments[skip:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	matched, err := path.Match(pattern[0], segments[0])
+	return err == nil && matched && matchSegments(pattern[1:], segments[1:])
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPathIgnorer(t *testing.T) {
+	ignorer := newPathIgnorer([]string{
+		"# vendored code",
+		"examples/",
+		"/legacy",
+		"modules/*/test/",
+		"**/fixtures/**",
+		"*.auto.tfvars",
+		"!keep.auto.tfvars",
+		"scratch.tf",
+		"",
+	})
+
+	tests := []struct {
+		path     string
+		isDir    bool
+		expected bool
+	}{
+		// Directory globs
+		{"examples", true, true},
+		{"modules/vpc/examples", true, true},
+		{"examples", false, false}, // "examples/" only matches directories
+		{"legacy", true, true},
+		{"modules/legacy", true, false}, // "/legacy" is anchored to the root
+		{"modules/vpc/test", true, true},
+		{"modules/vpc/nested/test", true, false},
+		{"tests/fixtures/basic/main.tf", false, true},
+		{"fixtures/main.tf", false, true},
+		// Single-file patterns
+		{"prod.auto.tfvars", false, true},
+		{"envs/prod/prod.auto.tfvars", false, true},
+		{"envs/keep.auto.tfvars", false, false},
+		{"scratch.tf", false, true},
+		{"modules/scratch.tf", false, true},
+		{"main.tf", false, false},
+		{".", true, false},
+	}
+	for _, tt := range tests {
+		if got := ignorer.Ignored(tt.path, tt.isDir); got != tt.expected {
+			t.Errorf("Ignored(%q, dir=%v) = %v, expected %v", tt.path, tt.isDir, got, tt.expected)
+		}
+	}
+
+	var nilIgnorer *pathIgnorer
+	if nilIgnorer.Ignored("main.tf", false) {
+		t.Error("Expected a nil ignorer to ignore nothing")
+	}
+}
+
+func TestValidateIgnorePatterns(t *testing.T) {
+	if err := validateIgnorePatterns([]string{"examples/", "**/*.tf", "!keep.tf"}); err != nil {
+		t.Errorf("Expected valid patterns, got %v", err)
+	}
+	if err := validateIgnorePatterns([]string{"modules/[a-"}); err == nil {
+		t.Error("Expected an error for a malformed character class")
+	}
+}
+
+func TestIgnoreFileInAnalysis(t *testing.T) {
+	// Given: a repository with examples, a fixture file and an ignore file
+	repoDir := createTempTerraformRepo(t, map[string]string{
+		"main.tf":                     `resource "aws_s3_bucket" "data" {}`,
+		"examples/complete/main.tf":   `resource "aws_instance" "example" {}`,
+		"test/fixtures/fixture.tf":    `resource "aws_instance" "fixture" {}`,
+		"modules/vpc/main.tf":         `resource "aws_vpc" "main" {}`,
+		"modules/vpc/experimental.tf": `resource "aws_vpc" "experimental" {}`,
+	})
+	ignoreFile := "# Vendored examples do not count toward compliance\nexamples/\nmodules/vpc/experimental.tf\n"
+	if err := os.WriteFile(filepath.Join(repoDir, IgnoreFileName), []byte(ignoreFile), 0644); err != nil {
+		t.Fatalf("Failed to write ignore file: %v", err)
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	// When: the repository is analyzed with a global --ignore pattern
+	options := analysisOptionsFromConfig(Config{IgnorePatterns: []string{"test/fixtures/"}})
+	analysis, err := analyzeRepositoryWithOptions(repoDir, options, logger)
+
+	// Then: only main.tf and modules/vpc/main.tf should be counted
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	counts := make(map[string]int)
+	for _, resourceType := range analysis.ResourceAnalysis.ResourceTypes {
+		counts[resourceType.Type] = resourceType.Count
+	}
+	if len(counts) != 2 || counts["aws_s3_bucket"] != 1 || counts["aws_vpc"] != 1 {
+		t.Errorf("Expected one aws_s3_bucket and one aws_vpc, got %v", counts)
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ============================================================================
+// MANIFEST - Run manifest recording inputs and produced reports
+// ============================================================================
+
+// RunManifestFileName is written next to the generated reports
+const RunManifestFileName = "run-manifest.json"
+
+type ManifestFilters struct {
+	TargetRepos     []string `json:"target_repos,omitempty"`
+	TargetReposFile string   `json:"target_repos_file,omitempty"`
+	MatchRegex      string   `json:"match_regex,omitempty"`
+	MatchPrefix     []string `json:"match_prefix,omitempty"`
+	ExcludeRegex    string   `json:"exclude_regex,omitempty"`
+	ExcludePrefix   []string `json:"exclude_prefix,omitempty"`
+	SkipArchived    bool     `json:"skip_archived"`
+	SkipForks       bool     `json:"skip_forks"`
+}
+
+type ManifestConcurrency struct {
+	MaxGoroutines    int    `json:"max_goroutines"`
+	CloneConcurrency int    `json:"clone_concurrency"`
+	ProcessTimeout   string `json:"process_timeout"`
+}
+
+type ManifestReportFile struct {
+	Name   string `json:"name"`
+	SHA256 string `json:"sha256"`
+}
+
+// RunManifest captures what a run was asked to do and what it produced.
+// The GitHub token is deliberately never recorded.
+type RunManifest struct {
+	ToolVersion   string               `json:"tool_version"`
+	GeneratedAt   time.Time            `json:"generated_at"`
+	Organizations []string             `json:"organizations"`
+	Filters       ManifestFilters      `json:"filters"`
+	Concurrency   ManifestConcurrency  `json:"concurrency"`
+	ReportFiles   []ManifestReportFile `json:"report_files"`
+}
+
+// buildRunManifest hashes each report once, however many times its path is listed
+func buildRunManifest(config Config, reportPaths []string, generatedAt time.Time) (RunManifest, error) {
+	reportFiles := make([]ManifestReportFile, 0, len(reportPaths))
+	seen := make(map[string]bool)
+	for _, path := range reportPaths {
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+		hash, err := hashFile(path)
+		if err != nil {
+			return RunManifest{}, fmt.Errorf("failed to hash report %s: %w", path, err)
+		}
+		reportFiles = append(reportFiles, ManifestReportFile{Name: filepath.Base(path), SHA256: hash})
+	}
+
+	return RunManifest{
+		ToolVersion:   ToolVersion,
+		GeneratedAt:   generatedAt.UTC(),
+		Organizations: config.Organizations,
+		Filters: ManifestFilters{
+			TargetRepos:     config.TargetRepos,
+			TargetReposFile: config.TargetReposFile,
+			MatchRegex:      config.MatchRegex,
+			MatchPrefix:     config.MatchPrefix,
+			ExcludeRegex:    config.ExcludeRegex,
+			ExcludePrefix:   config.ExcludePrefix,
+			SkipArchived:    config.SkipArchived,
+			SkipForks:       config.SkipForks,
+		},
+		Concurrency: ManifestConcurrency{
+			MaxGoroutines:    config.MaxGoroutines,
+			CloneConcurrency: config.CloneConcurrency,
+			ProcessTimeout:   config.ProcessTimeout.String(),
+		},
+		ReportFiles: reportFiles,
+	}, nil
+}
+
+func writeRunManifest(config Config, reportPaths []string, outputDir string) error {
+	manifest, err := buildRunManifest(config, reportPaths, time.Now())
+	if err != nil {
+		return err
+	}
+
+	jsonData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal run manifest: %w", err)
+	}
+
+	manifestPath := filepath.Join(outputDir, RunManifestFileName)
+	if err := os.WriteFile(manifestPath, jsonData, 0644); err != nil {
+		return fmt.Errorf("failed to write run manifest: %w", err)
+	}
+	return nil
+}
+
+func hashFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = file.Close() }()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestRunManifest(t *testing.T) {
+	t.Run("manifest records version, organizations and report hashes", func(t *testing.T) {
+		// Given: a reporter with results and a config requesting a manifest
+		reporter := &Reporter{
+			results: []AnalysisResult{
+				{
+					RepoName:     "test-repo",
+					Organization: "org-a",
+					Analysis: RepositoryAnalysis{
+						ResourceAnalysis: ResourceAnalysis{TotalResourceCount: 2},
+					},
+				},
+			},
+		}
+		config := Config{
+			Organizations:    []string{"org-a", "org-b"},
+			GitHubToken:      "ghp_secret_token_value",
+			MaxGoroutines:    10,
+			CloneConcurrency: 5,
+			MatchPrefix:      []string{"terraform-"},
+			WriteManifest:    true,
+		}
+
+		viper.Reset()
+		tempDir := t.TempDir()
+		viper.Set("output.format", "all")
+		viper.Set("output.directory", tempDir)
+
+		// When: reports are generated
+		if err := generateReports(reporter, config); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then: the manifest should describe the run and every report file
+		content, err := os.ReadFile(filepath.Join(tempDir, RunManifestFileName))
+		if err != nil {
+			t.Fatalf("Expected manifest to be written: %v", err)
+		}
+		var manifest RunManifest
+		if err := json.Unmarshal(content, &manifest); err != nil {
+			t.Fatalf("Expected valid manifest JSON: %v", err)
+		}
+
+		if manifest.ToolVersion != ToolVersion {
+			t.Errorf("Expected tool version %s, got %s", ToolVersion, manifest.ToolVersion)
+		}
+		if strings.Join(manifest.Organizations, ",") != "org-a,org-b" {
+			t.Errorf("Expected organizations [org-a org-b], got %v", manifest.Organizations)
+		}
+		if manifest.Concurrency.MaxGoroutines != 10 || len(manifest.Filters.MatchPrefix) != 1 {
+			t.Errorf("Expected concurrency and filters to be recorded, got %+v %+v", manifest.Concurrency, manifest.Filters)
+		}
+
+		expectedReports := []string{JSONReportFileName, CSVReportFileName, ResourceCSVFileName, UntaggedCSVFileName, MarkdownReportFileName}
+		if len(manifest.ReportFiles) != len(expectedReports) {
+			t.Fatalf("Expected %d report hashes, got %+v", len(expectedReports), manifest.ReportFiles)
+		}
+		for i, name := range expectedReports {
+			reportContent, err := os.ReadFile(filepath.Join(tempDir, name))
+			if err != nil {
+				t.Fatalf("Failed to read report %s: %v", name, err)
+			}
+			sum := sha256.Sum256(reportContent)
+			if manifest.ReportFiles[i].Name != name || manifest.ReportFiles[i].SHA256 != hex.EncodeToString(sum[:]) {
+				t.Errorf("Expected hash entry for %s, got %+v", name, manifest.ReportFiles[i])
+			}
+		}
+
+		if strings.Contains(string(content), config.GitHubToken) {
+			t.Error("Manifest must not contain the GitHub token")
+		}
+	})
+
+	t.Run("manifest is not written unless requested", func(t *testing.T) {
+		// Given: a config without the manifest option
+		viper.Reset()
+		tempDir := t.TempDir()
+		viper.Set("output.format", "json")
+		viper.Set("output.directory", tempDir)
+
+		// When: reports are generated
+		if err := generateReports(NewReporter(), Config{Organizations: []string{"org-a"}}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then: no manifest should exist
+		if _, err := os.Stat(filepath.Join(tempDir, RunManifestFileName)); !os.IsNotExist(err) {
+			t.Errorf("Expected no manifest, got stat error %v", err)
+		}
+	})
+}
@@ -2,7 +2,9 @@ package main
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
+	"strings"
 
 	"github.com/charmbracelet/glamour"
 )
@@ -42,11 +44,13 @@ func (r *Reporter) PrintMarkdownToScreenWithStyle(style string) error {
 
 	renderer, err := createGlamourRenderer(style)
 	if err != nil {
+		logRenderFallback(style, err)
 		return fallbackToRawMarkdown(markdownContent)
 	}
 
 	rendered, err := renderer.Render(markdownContent)
 	if err != nil {
+		logRenderFallback(style, err)
 		return fallbackToRawMarkdown(markdownContent)
 	}
 
@@ -54,6 +58,27 @@ func (r *Reporter) PrintMarkdownToScreenWithStyle(style string) error {
 	return nil
 }
 
+// logRenderFallback reports a failed render; notty failures are expected
+// in PTY-less environments such as CI and are only logged at debug level
+func logRenderFallback(style string, err error) {
+	if style == "notty" {
+		slog.Debug("Markdown rendering unavailable, printing raw markdown", "style", style, "error", err)
+		return
+	}
+	slog.Warn("Markdown rendering failed, printing raw markdown", "style", style, "error", err)
+}
+
+// resolveMarkdownStyle maps the configured style to one the current
+// environment can render; auto and unknown styles are detected up front
+func resolveMarkdownStyle(style string) string {
+	switch style {
+	case "dark", "light", "notty":
+		return style
+	default:
+		return detectTerminalCapabilities()
+	}
+}
+
 // createGlamourRenderer creates a renderer based on style using a map-based approach
 func createGlamourRenderer(style string) (*glamour.TermRenderer, error) {
 	rendererConfigs := map[string]func() (*glamour.TermRenderer, error){
@@ -81,7 +106,10 @@ func createGlamourRenderer(style string) (*glamour.TermRenderer, error) {
 		return createFunc()
 	}
 
-	// Default case
+	// Default case: detect instead of letting glamour probe a missing terminal
+	if createFunc, exists := rendererConfigs[detectTerminalCapabilities()]; exists {
+		return createFunc()
+	}
 	return glamour.NewTermRenderer(
 		glamour.WithAutoStyle(),
 		glamour.WithWordWrap(120),
@@ -93,23 +121,26 @@ func fallbackToRawMarkdown(markdownContent string) error {
 	return nil
 }
 
-func detectTerminalCapabilities() string {
-	// Check if we're in a TTY
-	if !isTerminal() {
-		return "notty"
-	}
+// stdoutIsTerminal is swapped in tests to simulate TTY and non-TTY environments
+var stdoutIsTerminal = isTerminal
 
+func detectTerminalCapabilities() string {
 	// Check terminal color support
 	colorTerm := os.Getenv("COLORTERM")
 	term := os.Getenv("TERM")
 
+	// Check if we're in a TTY that can render styles
+	if !stdoutIsTerminal() || term == "dumb" || os.Getenv("NO_COLOR") != "" {
+		return "notty"
+	}
+
 	// Check for true color support
 	if colorTerm == "truecolor" || colorTerm == "24bit" {
 		return "dark"
 	}
 
 	// Check for 256 color support
-	if false || term == "screen-256color" {
+	if strings.HasSuffix(term, "256color") {
 		return "dark"
 	}
 
@@ -1,10 +1,14 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
+	"log/slog"
 	"os"
 	"strings"
 	"testing"
+
+	"github.com/spf13/viper"
 )
 
 func TestDetectTerminalCapabilities(t *testing.T) {
@@ -758,4 +762,50 @@ func TestArithmeticAndComparisonOperations(t *testing.T) {
 			}
 		}
 	})
-}
\ No newline at end of file
+}
+func TestMarkdownStyleInNonTTYEnvironment(t *testing.T) {
+	// Given: stdout is not a terminal, as in CI without a PTY
+	originalIsTerminal := stdoutIsTerminal
+	stdoutIsTerminal = func() bool { return false }
+	defer func() { stdoutIsTerminal = originalIsTerminal }()
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	originalDefault := slog.Default()
+	slog.SetDefault(logger)
+	defer slog.SetDefault(originalDefault)
+
+	t.Run("auto style resolves to notty", func(t *testing.T) {
+		for _, style := range []string{"auto", "", "unknown"} {
+			if resolved := resolveMarkdownStyle(style); resolved != "notty" {
+				t.Errorf("Expected notty for style %q in non-TTY environment, got %s", style, resolved)
+			}
+		}
+	})
+
+	t.Run("explicit styles are kept", func(t *testing.T) {
+		if resolved := resolveMarkdownStyle("dark"); resolved != "dark" {
+			t.Errorf("Expected explicit dark style to be kept, got %s", resolved)
+		}
+	})
+
+	t.Run("printing the report logs no errors", func(t *testing.T) {
+		// Given: auto style configured
+		viper.Set("ui.raw_markdown", false)
+		viper.Set("ui.markdown_style", "auto")
+		defer viper.Set("ui.markdown_style", nil)
+		reporter := NewReporter()
+		reporter.AddResults([]AnalysisResult{{RepoName: "repo", Analysis: RepositoryAnalysis{RepositoryPath: "/repos/repo"}}})
+
+		// When: the markdown report is printed
+		err := printMarkdownReport(reporter, logger)
+
+		// Then: no error should be returned or logged
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if logs.Len() > 0 {
+			t.Errorf("Expected no warnings or errors to be logged, got %s", logs.String())
+		}
+	})
+}
@@ -2,11 +2,14 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -21,34 +24,109 @@ import (
 
 // Configuration constants
 const (
-	DefaultMaxGoroutines    = 100
-	DefaultCloneConcurrency = 100
-	DefaultProcessTimeout   = 30 * time.Minute
-	DefaultRetryDelay       = 100 * time.Millisecond // Fast for tests
-	ProductionRetryDelay    = 1 * time.Second        // Production default
-	MaxSafeMaxGoroutines    = 10000
-	MaxSafeCloneConcurrency = 100
+	DefaultMaxGoroutines       = 100
+	DefaultCloneConcurrency    = 100
+	DefaultProcessTimeout      = 30 * time.Minute
+	DefaultRetryDelay          = 100 * time.Millisecond // Fast for tests
+	ProductionRetryDelay       = 1 * time.Second        // Production default
+	DefaultMaxRetries          = 2
+	DefaultProgressInterval    = 30 * time.Second
+	MaxSafeMaxRetries          = 10
+	MaxSafeMaxGoroutines       = 10000
+	MaxSafeCloneConcurrency    = 100
+	DefaultFileReadConcurrency = 1
+	MaxSafeFileReadConcurrency = 64
+	AutoConcurrencyPerCPU      = 4 // --auto-concurrency workers and clones per CPU
+	DefaultOrgConcurrency      = 1
+	MaxSafeOrgConcurrency      = 16
 )
 
+// autoConcurrency derives the goroutine and clone limits from the CPU count,
+// capped at the safety limits validateAnalysisConfiguration enforces
+func autoConcurrency(cpus int) (maxGoroutines, cloneConcurrency int) {
+	perCPU := max(cpus, 1) * AutoConcurrencyPerCPU
+	return min(perCPU, MaxSafeMaxGoroutines), min(perCPU, MaxSafeCloneConcurrency)
+}
+
 type Config struct {
-	MaxGoroutines    int
-	CloneConcurrency int
-	ProcessTimeout   time.Duration
-	RetryDelay       time.Duration
-	SkipArchived     bool
-	SkipForks        bool
-	GitHubToken      string
-	Organizations    []string
-	BaseURL          string
+	MaxGoroutines       int
+	CloneConcurrency    int
+	FileReadConcurrency int // --file-read-concurrency: Files read ahead of the parser per repository
+	ProcessTimeout      time.Duration
+	TimeoutAsWarning    bool   // --timeout-as-warning: Record timed-out repositories as warnings instead of failures
+	RequireRepos        bool   // --require-repos: Fail when an organization yields zero repositories
+	OrgOrder            string // --org-order: as-listed, alpha, or an explicit comma-separated priority list
+	OrgConcurrency      int    // --org-concurrency: Organizations cloned and analyzed at once; 0 means one at a time
+	ClonesDir           string // --clones-dir: Base directory for per-organization clone directories
+	KeepClones          bool   // --keep-clones: Leave cloned repositories on disk after analysis
+	RetryDelay          time.Duration
+	ProgressInterval    time.Duration // --progress-interval: How often to log completed/total repositories (0 disables)
+	RepoTimeout         time.Duration // --repo-timeout: Deadline for each repository's analysis within ProcessTimeout (0 disables)
+	MaxRetries          int           // --max-retries: Retries of a transient ghorg clone failure, with exponential backoff from RetryDelay
+	LocalPath           string        // --local-path: Analyze a directory on disk instead of cloning organizations
+	SingleRepo          bool          // --single-repo: Treat LocalPath itself as one repository
+	CacheEnabled        bool          // --cache/--no-cache: Reuse analyses of repositories whose HEAD commit is unchanged
+	CacheDir            string        // --cache-dir: Directory holding cached analyses
+	SkipArchived        bool
+	SkipForks           bool
+	SCMProvider         string // --scm-provider: github (default) or gitlab; selects the ghorg SCM type and token variable
+	GitHubToken         string
+	Organizations       []string
+	BaseURL             string
 	// Repository targeting options for ghorg
+	Repo            string   // --repo: Single repository to clone and analyze, as org/name
 	TargetRepos     []string // --target-repos: Comma-separated list of specific repositories
 	TargetReposFile string   // --target-repos-file: Path to file containing repository names
 	MatchRegex      string   // --match-regex: Regex pattern to match repository names
 	MatchPrefix     []string // --match-prefix: Comma-separated prefixes to match
 	ExcludeRegex    string   // --exclude-regex: Regex pattern to exclude repository names
 	ExcludePrefix   []string // --exclude-prefix: Comma-separated prefixes to exclude
+	// Analysis options
+	ListProviders bool // --list-providers: Only collect the provider inventory
+	ScanSecrets   bool // --scan-secrets: Report credentials embedded in resource attributes
+	ValidateOnly  bool // --validate-only: Only check that Terraform files parse
+	DryRun        bool // --dry-run: List the selected repositories without analyzing them
+	CheckLatest   bool // --check-latest: Look up each provider's latest release in the Terraform Registry
+	// --ignore: gitignore-style paths skipped in every repository, before each .tfanalyzerignore
+	IgnorePatterns []string
+	// --include-ext: Extensions analyzed alongside .tf, .tf.json, .tfvars and .hcl
+	IncludeExtensions []string
+	// --since: Skip repositories whose latest commit is older than this, after cloning or discovery
+	Since time.Duration
+	// analysis.provider_sources: Canonical sources for bare provider names, e.g. github: integrations/github
+	ProviderSources map[string]string
+	// --analyze: Analysis sections to run, e.g. tags,backend; empty runs every section
+	AnalyzeSections []string
+	// Output options
+	WriteManifest     bool          // --write-manifest: Write run-manifest.json next to the reports
+	SortReportsBy     string        // --sort-reports-by: Repository order key for reports
+	MaxTotalFindings  int           // --max-total-findings: Cap on findings detail across all repositories; 0 is unlimited
+	StreamOutput      string        // --stream-output: JSON Lines file receiving each repository result as it completes
+	GraphOutput       string        // --graph: Graphviz DOT file of module usage across repositories
+	TimestampedOutput bool          // --timestamped-output: Write reports into a new YYYYMMDD-HHMMSS subdirectory of the output directory
+	ReportPrefix      string        // --report-prefix: Base name of the JSON, CSV, Markdown, HTML and SARIF reports; {org} and {date} are expanded
+	SplitByOrg        bool          // --split-by-org: Also write the prefix-named reports once per organization
+	SplitOnly         bool          // --split-only: With SplitByOrg, skip the combined reports
+	WebhookURL        string        // --webhook-url: Receives the summary JSON in a POST once reports are written
+	WebhookHeaders    []string      // --webhook-header: "Name: Value" headers sent with the webhook, e.g. for auth
+	WebhookTimeout    time.Duration // --webhook-timeout: Limit on the webhook request
+	// Compliance options
+	ComplianceConfigFile         string              // --compliance-config: Path to the YAML policy document
+	MandatoryTags                []string            // --mandatory-tags: Tags every resource must carry
+	TagsCaseInsensitive          bool                // --tags-case-insensitive: Ignore case when matching tag keys
+	TagRules                     map[string][]string // compliance.tag_rules: Required tags per resource type glob
+	FailOnUntagged               int                 // --fail-on-untagged: Fail the run when untagged resources exceed this count; negative disables
+	FailOnMissingProviderVersion bool                // --fail-on-missing-provider-version: Fail the run when any provider is unpinned
+	RequirePinnedProviders       bool                // --require-pinned-providers: Fail the run when fewer than MinPinnedPct percent of providers are pinned
+	MinPinnedPct                 int                 // --min-pinned-pct: Share of providers, in percent, that must be pinned
+	DeprecatedResources          map[string]string   // compliance.deprecated_resources: Recommended replacements keyed by deprecated resource type
+	FailOnDeprecated             bool                // --fail-on-deprecated: Fail the run when any deprecated resource type is used
+	Compliance                   CompliancePolicy    // Policies loaded from ComplianceConfigFile
 }
 
+// ErrNoRepositoriesFound is returned by --require-repos runs when an organization yields no repositories
+var ErrNoRepositoriesFound = errors.New("no repositories found for organizations")
+
 type Repository struct {
 	Name         string
 	Path         string
@@ -73,6 +151,8 @@ type MultiOrgContext struct {
 	Ctx           context.Context
 	ProcessingCtx ProcessingContext
 	Reporter      *Reporter
+	// ProcessOrganization clones and analyzes one organization; nil uses processOrganizationSafely
+	ProcessOrganization func(OrgProcessContext) (int, error)
 }
 
 type FileContent struct {
@@ -86,6 +166,8 @@ type ProcessingStats struct {
 	TotalRepos     int
 	ProcessedRepos int
 	FailedRepos    int
+	WarningRepos   int
+	EmptyRepos     int // Analyzed repositories without any Terraform files
 	TotalFiles     int
 	Duration       time.Duration
 }
@@ -100,6 +182,7 @@ type OrganizationResult struct {
 type ProcessingContext struct {
 	Config Config
 	Pool   *ants.Pool
+	Stream *ResultStream // --stream-output; nil when streaming is disabled
 }
 
 func parseOrganizations(orgString string) []string {
@@ -141,6 +224,32 @@ func validateAnalysisConfiguration(config Config) error {
 		return fmt.Errorf("CloneConcurrency too high (max %d for safety), got %d", MaxSafeCloneConcurrency, config.CloneConcurrency)
 	}
 
+	// Each organization clones with up to CloneConcurrency repositories at once
+	if config.OrgConcurrency < 0 || config.OrgConcurrency > MaxSafeOrgConcurrency {
+		return fmt.Errorf("OrgConcurrency must be between 0 and %d, got %d", MaxSafeOrgConcurrency, config.OrgConcurrency)
+	}
+
+	if config.FileReadConcurrency < 0 || config.FileReadConcurrency > MaxSafeFileReadConcurrency {
+		return fmt.Errorf("FileReadConcurrency must be between 0 and %d, got %d", MaxSafeFileReadConcurrency, config.FileReadConcurrency)
+	}
+
+	if config.MaxRetries < 0 || config.MaxRetries > MaxSafeMaxRetries {
+		return fmt.Errorf("MaxRetries must be between 0 and %d, got %d", MaxSafeMaxRetries, config.MaxRetries)
+	}
+
+	if config.ProgressInterval < 0 {
+		return fmt.Errorf("ProgressInterval must not be negative, got %v", config.ProgressInterval)
+	}
+
+	if config.RepoTimeout < 0 {
+		return fmt.Errorf("RepoTimeout must not be negative, got %v", config.RepoTimeout)
+	}
+
+	// Local analysis never talks to GitHub
+	if config.LocalPath != "" {
+		return nil
+	}
+
 	if config.GitHubToken == "" {
 		return fmt.Errorf("GitHubToken is required")
 	}
@@ -185,9 +294,20 @@ func createProcessingContext(config Config) (ProcessingContext, error) {
 		return ProcessingContext{}, fmt.Errorf("failed to create goroutine pool: %w", poolErr)
 	}
 
+	var stream *ResultStream
+	if config.StreamOutput != "" {
+		var streamErr error
+		stream, streamErr = openResultStream(config.StreamOutput)
+		if streamErr != nil {
+			pool.Release()
+			return ProcessingContext{}, streamErr
+		}
+	}
+
 	return ProcessingContext{
 		Config: config,
 		Pool:   pool,
+		Stream: stream,
 	}, nil
 }
 
@@ -195,6 +315,9 @@ func releaseProcessingContext(ctx ProcessingContext) {
 	if ctx.Pool != nil {
 		ctx.Pool.Release()
 	}
+	if err := ctx.Stream.Close(); err != nil {
+		slog.Warn("Failed to close stream output", "error", err)
+	}
 }
 
 func discoverRepositories(tempDir, org string) ([]Repository, error) {
@@ -233,12 +356,20 @@ type JobSubmissionContext struct {
 	Pool         *pool.Pool
 	AntsPool     *ants.Pool
 	Results      chan AnalysisResult
-	Logger       *slog.Logger
+	Options      AnalysisOptions
+	// TimeoutAsWarning downgrades timed-out repositories from failures to warnings
+	TimeoutAsWarning bool
+	// RepoTimeout bounds each repository's analysis; zero leaves only Ctx's deadline
+	RepoTimeout time.Duration
+	Logger      *slog.Logger
+	// Analyze analyzes one repository; nil uses processRepositoryFilesWithContext
+	Analyze repositoryAnalyzer
 }
 
-func submitRepositoryJobsWithTimeout(jobCtx JobSubmissionContext) {
-	jobSubmitter := createJobSubmitterWithTimeoutRecovery(jobCtx.AntsPool, jobCtx.Logger)
+// repositoryAnalyzer analyzes one submitted repository
+type repositoryAnalyzer func(ctx context.Context, repo Repository, options AnalysisOptions, logger *slog.Logger) AnalysisResult
 
+func submitRepositoryJobsWithTimeout(jobCtx JobSubmissionContext) {
 	for _, repo := range jobCtx.Repositories {
 		repo := repo
 		jobCtx.Pool.Go(func() {
@@ -248,33 +379,64 @@ func submitRepositoryJobsWithTimeout(jobCtx JobSubmissionContext) {
 						"repository", repo.Name,
 						"organization", repo.Organization,
 						"panic", r)
-					jobCtx.Results <- AnalysisResult{
+					jobCtx.Results <- withErrorKind(AnalysisResult{
 						RepoName:     repo.Name,
 						Organization: repo.Organization,
-						Error:        fmt.Errorf("panic during processing: %v", r),
-					}
+						Error:        fmt.Errorf("%w: %v", ErrAnalysisPanic, r),
+					})
 				}
 			}()
 
 			// Check context before processing
 			select {
 			case <-jobCtx.Ctx.Done():
-				jobCtx.Results <- AnalysisResult{
+				jobCtx.Results <- withErrorKind(classifyTimeoutResult(AnalysisResult{
 					RepoName:     repo.Name,
 					Organization: repo.Organization,
-					Error:        fmt.Errorf("processing cancelled due to timeout: %v", jobCtx.Ctx.Err()),
-				}
+					Analysis:     RepositoryAnalysis{RepositoryPath: repo.Path},
+					Error:        cancellationError(jobCtx.Ctx),
+				}, jobCtx.TimeoutAsWarning))
 				return
 			default:
 			}
 
-			result := jobSubmitter(repo)
-			jobCtx.Results <- result
+			result := analyzeRepositoryWithTimeout(jobCtx, repo)
+			jobCtx.Results <- withErrorKind(classifyTimeoutResult(result, jobCtx.TimeoutAsWarning))
 		})
 	}
 }
 
+// analyzeRepositoryWithTimeout analyzes repo under its own RepoTimeout
+// deadline so one slow repository fails alone instead of consuming the run's
+// timeout. The deadline is checked between files, like the run's timeout.
+func analyzeRepositoryWithTimeout(jobCtx JobSubmissionContext, repo Repository) AnalysisResult {
+	analyze := jobCtx.Analyze
+	if analyze == nil {
+		analyze = processRepositoryFilesWithContext
+	}
+	if jobCtx.RepoTimeout <= 0 {
+		return createJobSubmitterWithOptions(jobCtx.Ctx, jobCtx.AntsPool, jobCtx.Options, analyze, jobCtx.Logger)(repo)
+	}
+
+	repoCtx, cancel := context.WithTimeout(jobCtx.Ctx, jobCtx.RepoTimeout)
+	defer cancel()
+	result := createJobSubmitterWithOptions(repoCtx, jobCtx.AntsPool, jobCtx.Options, analyze, jobCtx.Logger)(repo)
+	// Only the repository's own deadline is reported as a repository timeout
+	if isTimeoutError(result.Error) && isTimeoutError(repoCtx.Err()) && jobCtx.Ctx.Err() == nil {
+		jobCtx.Logger.Warn("Repository analysis exceeded the repository timeout",
+			"repository", repo.Name,
+			"organization", repo.Organization,
+			"repo_timeout", jobCtx.RepoTimeout)
+		result.Error = fmt.Errorf("repository timeout of %v exceeded: %w", jobCtx.RepoTimeout, result.Error)
+	}
+	return result
+}
+
 func createJobSubmitterWithTimeoutRecovery(pool *ants.Pool, logger *slog.Logger) func(Repository) AnalysisResult {
+	return createJobSubmitterWithOptions(context.Background(), pool, defaultAnalysisOptions(), processRepositoryFilesWithContext, logger)
+}
+
+func createJobSubmitterWithOptions(ctx context.Context, pool *ants.Pool, options AnalysisOptions, analyze repositoryAnalyzer, logger *slog.Logger) func(Repository) AnalysisResult {
 	return func(repo Repository) AnalysisResult {
 		repoLogger := logger.With("repository", repo.Name, "organization", repo.Organization)
 
@@ -291,8 +453,65 @@ func createJobSubmitterWithTimeoutRecovery(pool *ants.Pool, logger *slog.Logger)
 			}
 		}
 
-		return processRepositoryFilesWithRecovery(repo, repoLogger)
+		return analyze(ctx, repo, options, repoLogger)
+	}
+}
+
+// classifyTimeoutResult turns a timeout failure into a warning when requested,
+// keeping any partial analysis gathered before the deadline
+func classifyTimeoutResult(result AnalysisResult, timeoutAsWarning bool) AnalysisResult {
+	if !timeoutAsWarning || !isTimeoutError(result.Error) {
+		return result
 	}
+	result.Warnings = append(result.Warnings, result.Error.Error())
+	result.Error = nil
+	return result
+}
+
+// isTimeoutError reports whether err comes from a deadline. Errors that also
+// wrap context.Canceled, e.g. joined from an interrupted run, are not timeouts.
+func isTimeoutError(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, context.Canceled)
+}
+
+// ErrAnalysisInterrupted is returned when SIGINT or SIGTERM stops a run early
+var ErrAnalysisInterrupted = errors.New("analysis interrupted")
+
+// cancellationError records why a repository was never analyzed: the run
+// timed out or it was interrupted
+func cancellationError(ctx context.Context) error {
+	if isTimeoutError(ctx.Err()) {
+		return fmt.Errorf("processing cancelled due to timeout: %w", ctx.Err())
+	}
+	return fmt.Errorf("processing cancelled: %w", ctx.Err())
+}
+
+// analysisOptionsFromConfig derives the per-repository analysis options from the run configuration
+func analysisOptionsFromConfig(config Config) AnalysisOptions {
+	options := defaultAnalysisOptions()
+	options.Policy = config.Compliance
+	options.ScanSecrets = config.ScanSecrets
+	options.ValidateOnly = config.ValidateOnly
+	options.MandatoryTags = config.MandatoryTags
+	options.TagsCaseInsensitive = config.TagsCaseInsensitive
+	options.TagRules = config.TagRules
+	options.DeprecatedResources = config.DeprecatedResources
+	options.FileReadConcurrency = config.FileReadConcurrency
+	options.ProviderSources = config.ProviderSources
+	options.IgnorePatterns = config.IgnorePatterns
+	options.IncludeExtensions = config.IncludeExtensions
+	if config.CacheEnabled {
+		options.Cache = newAnalysisCache(config.CacheDir)
+	}
+	if len(config.AnalyzeSections) > 0 {
+		options.Sections = lo.SliceToMap(config.AnalyzeSections, func(section string) (string, bool) {
+			return section, true
+		})
+	}
+	if config.ListProviders {
+		options.Sections = map[string]bool{SectionProviders: true}
+	}
+	return options
 }
 
 func waitAndCloseChannel(p *pool.Pool, results chan AnalysisResult) {
@@ -320,13 +539,46 @@ func logRepositoryResultStructured(result AnalysisResult, logger *slog.Logger) {
 		"outputs", analysis.OutputAnalysis.OutputCount)
 }
 
-func collectResults(results chan AnalysisResult, logger *slog.Logger, totalRepos int) []AnalysisResult {
+// collectResults drains results as repositories complete, appending each to
+// the JSON Lines stream (if any) before the run finishes. Every tick logs how
+// many repositories are done; the logger carries the organization.
+func collectResults(results chan AnalysisResult, logger *slog.Logger, totalRepos int, stream *ResultStream, ticks <-chan time.Time) []AnalysisResult {
 	var allResults []AnalysisResult
 	successful := 0
 	failed := 0
 
-	for result := range results {
+	for {
+		var result AnalysisResult
+		select {
+		case <-ticks:
+			logger.Info(fmt.Sprintf("Processed %d/%d repos", len(allResults), totalRepos),
+				"completed", len(allResults),
+				"total", totalRepos,
+				"failed", failed)
+			continue
+		case next, ok := <-results:
+			if !ok {
+				logger.Info("Repository processing complete",
+					"total_processed", len(allResults),
+					"successful", successful,
+					"failed", failed)
+				return allResults
+			}
+			result = next
+		}
+
 		allResults = append(allResults, result)
+		if err := stream.Write(result); err != nil {
+			logger.Warn("Failed to stream repository result",
+				"repository", result.RepoName,
+				"error", err)
+		}
+		if len(result.Warnings) > 0 {
+			logger.Warn("Repository processed with warnings",
+				"repository", result.RepoName,
+				"organization", result.Organization,
+				"warnings", result.Warnings)
+		}
 		if result.Error != nil {
 			failed++
 			logger.Error("Repository processing failed",
@@ -337,21 +589,17 @@ func collectResults(results chan AnalysisResult, logger *slog.Logger, totalRepos
 			successful++
 			logRepositoryResultStructured(result, logger)
 		}
-
-		if len(allResults)%50 == 0 {
-			logger.Info("Processing progress",
-				"processed", len(allResults),
-				"successful", successful,
-				"failed", failed)
-		}
 	}
+}
 
-	logger.Info("Repository processing complete",
-		"total_processed", len(allResults),
-		"successful", successful,
-		"failed", failed)
-
-	return allResults
+// progressTicks returns a ticker channel for periodic progress logging and a
+// function to stop it; a zero interval yields a nil channel that never fires
+func progressTicks(interval time.Duration) (<-chan time.Time, func()) {
+	if interval <= 0 {
+		return nil, func() {}
+	}
+	ticker := time.NewTicker(interval)
+	return ticker.C, ticker.Stop
 }
 
 func calculateStats(allResults []AnalysisResult, duration time.Duration) ProcessingStats {
@@ -363,6 +611,14 @@ func calculateStats(allResults []AnalysisResult, duration time.Duration) Process
 		return r.Error != nil
 	})
 
+	warned := lo.Filter(allResults, func(r AnalysisResult, _ int) bool {
+		return r.Error == nil && len(r.Warnings) > 0
+	})
+
+	empty := lo.CountBy(successful, func(r AnalysisResult) bool {
+		return r.Analysis.IsEmpty
+	})
+
 	totalFiles := lo.Reduce(successful, func(acc int, result AnalysisResult, _ int) int {
 		return acc + result.Analysis.ResourceAnalysis.TotalResourceCount
 	}, 0)
@@ -372,6 +628,8 @@ func calculateStats(allResults []AnalysisResult, duration time.Duration) Process
 		TotalRepos:     len(allResults),
 		ProcessedRepos: len(successful),
 		FailedRepos:    len(failed),
+		WarningRepos:   len(warned),
+		EmptyRepos:     empty,
 		TotalFiles:     totalFiles,
 		Duration:       duration,
 	}
@@ -382,6 +640,8 @@ func logStats(stats ProcessingStats) {
 		"total_repositories", stats.TotalRepos,
 		"successfully_processed", stats.ProcessedRepos,
 		"failed", stats.FailedRepos,
+		"warnings", stats.WarningRepos,
+		"empty", stats.EmptyRepos,
 		"total_files_extracted", stats.TotalFiles,
 		"duration", stats.Duration)
 }
@@ -393,6 +653,7 @@ func finalizeProcessing(allResults []AnalysisResult, startTime time.Time) {
 
 func processRepositoriesConcurrently(repositories []Repository, ctx context.Context, processingCtx ProcessingContext, logger *slog.Logger) []AnalysisResult {
 	startTime := time.Now()
+	repositories = filterRecentRepositories(ctx, repositories, processingCtx.Config.Since, gitLatestCommitTime, logger)
 
 	logger.Info("Starting concurrent repository processing with timeout",
 		"repository_count", len(repositories),
@@ -417,12 +678,18 @@ func processRepositoriesConcurrently(repositories []Repository, ctx context.Cont
 		Pool:         p,
 		AntsPool:     processingCtx.Pool,
 		Results:      results,
-		Logger:       logger,
+		Options:      analysisOptionsFromConfig(processingCtx.Config),
+		// Per-repository timeouts may be expected for very large repositories
+		TimeoutAsWarning: processingCtx.Config.TimeoutAsWarning,
+		RepoTimeout:      processingCtx.Config.RepoTimeout,
+		Logger:           logger,
 	}
 	submitRepositoryJobsWithTimeout(jobCtx)
-	waitAndCloseChannel(p, results)
+	go waitAndCloseChannel(p, results)
 
-	allResults := collectResults(results, logger, len(repositories))
+	ticks, stopTicks := progressTicks(processingCtx.Config.ProgressInterval)
+	defer stopTicks()
+	allResults := collectResults(results, logger, len(repositories), processingCtx.Stream, ticks)
 	finalizeProcessing(allResults, startTime)
 
 	return allResults
@@ -438,21 +705,125 @@ func cloneAndAnalyzeMultipleOrgs(ctx context.Context, processingCtx ProcessingCo
 	return processMultipleOrganizations(multiCtx)
 }
 
+// LocalOrganization is the organization recorded for repositories found under --local-path
+const LocalOrganization = "local"
+
+// discoverLocalRepositories lists the repositories under a local path: each
+// non-hidden immediate subdirectory, or the path itself when singleRepo is set
+func discoverLocalRepositories(localPath string, singleRepo bool) ([]Repository, error) {
+	info, err := os.Stat(localPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read local path: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("local path %s is not a directory", localPath)
+	}
+
+	if singleRepo {
+		name := filepath.Base(filepath.Clean(localPath))
+		return []Repository{{Name: name, Path: localPath, Organization: LocalOrganization}}, nil
+	}
+
+	entries, err := readDirectory(localPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read local path: %w", err)
+	}
+	repoNames := lo.Reject(filterRepositoryDirs(entries), func(name string, _ int) bool {
+		return strings.HasPrefix(name, ".")
+	})
+	return lo.Map(repoNames, func(name string, _ int) Repository {
+		return createRepository(name, localPath, LocalOrganization)
+	}), nil
+}
+
+// analyzeLocalPath analyzes repositories already on disk, skipping the clone phase entirely
+func analyzeLocalPath(ctx context.Context, processingCtx ProcessingContext, reporter *Reporter) error {
+	config := processingCtx.Config
+	repositories, err := discoverLocalRepositories(config.LocalPath, config.SingleRepo)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Repositories discovered",
+		"repository_count", len(repositories),
+		"local_path", config.LocalPath)
+	if len(repositories) == 0 && config.RequireRepos {
+		return fmt.Errorf("%w: %s", ErrNoRepositoriesFound, config.LocalPath)
+	}
+
+	analysisCtx, analysisCancel := context.WithTimeout(ctx, config.ProcessTimeout)
+	defer analysisCancel()
+
+	repoLogger := slog.With("local_path", config.LocalPath)
+	reporter.AddResults(processRepositoriesConcurrently(repositories, analysisCtx, processingCtx, repoLogger))
+	return nil
+}
+
 func processMultipleOrganizations(multiCtx MultiOrgContext) error {
 	startTime := time.Now()
-	stats := initializeProcessingStats(multiCtx.ProcessingCtx.Config.Organizations)
+	processOrganization := multiCtx.ProcessOrganization
+	if processOrganization == nil {
+		processOrganization = processOrganizationSafely
+	}
+	orgs, err := orderOrganizations(multiCtx.ProcessingCtx.Config.Organizations, multiCtx.ProcessingCtx.Config.OrgOrder)
+	if err != nil {
+		return err
+	}
+	stats := initializeProcessingStats(orgs)
 	logProcessingStart(stats, multiCtx.ProcessingCtx.Config)
 
-	for i, org := range multiCtx.ProcessingCtx.Config.Organizations {
-		orgCtx := createOrgProcessContext(multiCtx, org, i, stats.TotalOrgs)
-		repoCount, err := processOrganizationSafely(orgCtx)
+	// Organizations start in processing order; with --org-concurrency above 1
+	// they may finish, and so update stats, in any order
+	var statsMu sync.Mutex
+	orgPool := pool.New().WithMaxGoroutines(max(multiCtx.ProcessingCtx.Config.OrgConcurrency, 1))
+	for i, org := range orgs {
+		orgPool.Go(func() {
+			orgCtx := createOrgProcessContext(multiCtx, org, i, stats.TotalOrgs)
+			repoCount, err := processOrganization(orgCtx)
+
+			statsMu.Lock()
+			defer statsMu.Unlock()
+			updateProcessingStats(&stats, repoCount, err != nil)
+			if err == nil && repoCount == 0 {
+				stats.EmptyOrgs = append(stats.EmptyOrgs, org)
+			}
 
-		updateProcessingStats(&stats, repoCount, err != nil)
+			logOrganizationCompletion(orgCtx.Logger, repoCount, stats)
+		})
+	}
+	orgPool.Wait()
 
-		logOrganizationCompletion(orgCtx.Logger, repoCount, stats)
+	if err := finalizeMutliOrgProcessing(startTime, stats, multiCtx.ProcessingCtx.Config.Organizations); err != nil {
+		return err
 	}
+	return checkRequiredRepositories(stats, multiCtx.ProcessingCtx.Config.RequireRepos)
+}
+
+// Organization processing orders accepted by --org-order
+const (
+	OrgOrderAsListed = "as-listed"
+	OrgOrderAlpha    = "alpha"
+)
 
-	return finalizeMutliOrgProcessing(startTime, stats, multiCtx.ProcessingCtx.Config.Organizations)
+// orderOrganizations returns orgs in processing order. Besides as-listed and
+// alpha, order may be a comma-separated priority list: those orgs run first,
+// in that order, followed by the remaining orgs as listed.
+func orderOrganizations(orgs []string, order string) ([]string, error) {
+	switch order {
+	case "", OrgOrderAsListed:
+		return orgs, nil
+	case OrgOrderAlpha:
+		sorted := slices.Clone(orgs)
+		slices.Sort(sorted)
+		return sorted, nil
+	}
+
+	priority := parseOrganizations(order)
+	unknown := lo.Without(priority, orgs...)
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("--org-order names organizations not in --orgs: %s", strings.Join(unknown, ", "))
+	}
+	return lo.Uniq(append(priority, orgs...)), nil
 }
 
 type MultiOrgStats struct {
@@ -461,6 +832,7 @@ type MultiOrgStats struct {
 	FailedOrgs           int
 	TotalReposAcrossOrgs int
 	ProcessedRepos       int
+	EmptyOrgs            []string // Organizations that were processed but yielded no repositories
 }
 
 func initializeProcessingStats(orgs []string) MultiOrgStats {
@@ -473,7 +845,8 @@ func logProcessingStart(stats MultiOrgStats, config Config) {
 	slog.Info("Starting multi-organization analysis",
 		"total_organizations", stats.TotalOrgs,
 		"max_goroutines", config.MaxGoroutines,
-		"clone_concurrency", config.CloneConcurrency)
+		"clone_concurrency", config.CloneConcurrency,
+		"org_concurrency", max(config.OrgConcurrency, 1))
 }
 
 func createOrgProcessContext(multiCtx MultiOrgContext, org string, index, totalOrgs int) OrgProcessContext {
@@ -528,6 +901,19 @@ func finalizeMutliOrgProcessing(startTime time.Time, stats MultiOrgStats, orgs [
 	return nil
 }
 
+// checkRequiredRepositories fails the run for empty organizations when
+// --require-repos is set; otherwise they are only logged
+func checkRequiredRepositories(stats MultiOrgStats, requireRepos bool) error {
+	if len(stats.EmptyOrgs) == 0 {
+		return nil
+	}
+	if !requireRepos {
+		slog.Warn("Organizations yielded no repositories", "organizations", stats.EmptyOrgs)
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrNoRepositoriesFound, strings.Join(stats.EmptyOrgs, ", "))
+}
+
 func processOrganization(ctx context.Context, org string, processingCtx ProcessingContext, reporter *Reporter, logger *slog.Logger) (int, error) {
 	orgCtx := OrgProcessContext{
 		Ctx:           ctx,
@@ -541,14 +927,15 @@ func processOrganization(ctx context.Context, org string, processingCtx Processi
 }
 
 func processOrganizationWorkflow(orgCtx OrgProcessContext) (int, error) {
-	tempDir, cleanup, err := setupWorkspaceWithRetry(orgCtx.Logger, orgCtx.ProcessingCtx.Config.RetryDelay)
+	tempDir, cleanup, err := setupWorkspaceWithRetry(orgCtx.Logger, orgCtx.ProcessingCtx.Config)
 	if err != nil {
 		return 0, err
 	}
+	// Deferred so clones are removed after failed clones and panics too
 	defer cleanupWorkspace(cleanup, tempDir, orgCtx.Org, orgCtx.Logger)
 
 	operation := createCloneOperation(orgCtx.Org, tempDir, orgCtx.ProcessingCtx.Config)
-	if err := executeCloneWithoutRetry(orgCtx.Ctx, operation, orgCtx.Logger, orgCtx.ProcessingCtx.Config.RetryDelay); err != nil {
+	if err := executeCloneWithRetry(orgCtx.Ctx, operation, orgCtx.Logger, executeClonePhase); err != nil {
 		return 0, err
 	}
 
@@ -563,18 +950,20 @@ func processOrganizationWorkflow(orgCtx OrgProcessContext) (int, error) {
 	return len(repositories), nil
 }
 
-func setupWorkspaceWithRetry(logger *slog.Logger, retryDelay time.Duration) (string, func(), error) {
+// setupWorkspaceWithRetry creates an organization's clone directory under
+// --clones-dir; the cleanup it returns honours --keep-clones
+func setupWorkspaceWithRetry(logger *slog.Logger, config Config) (string, func(), error) {
 	var tempDir string
 	var cleanup func()
 	var setupErr error
 
 	for attempt := 1; attempt <= 3; attempt++ {
-		tempDir, cleanup, setupErr = setupWorkspaceWithRecovery(logger)
+		tempDir, cleanup, setupErr = setupWorkspaceWithRecovery(config.ClonesDir, config.KeepClones, logger)
 		if setupErr == nil {
 			break
 		}
 		logger.Warn("Workspace setup failed, retrying", "attempt", attempt, "error", setupErr)
-		time.Sleep(time.Duration(attempt) * retryDelay)
+		time.Sleep(time.Duration(attempt) * config.RetryDelay)
 	}
 
 	if setupErr != nil {
@@ -590,22 +979,34 @@ func cleanupWorkspace(cleanup func(), tempDir, org string, logger *slog.Logger)
 	logger.Info("Cleanup completed", "temp_dir", tempDir, "organization", org)
 }
 
-func executeCloneWithoutRetry(ctx context.Context, operation CloneOperation, logger *slog.Logger, retryDelay time.Duration) error {
-	var cloneErr error
-	for attempt := 1; attempt <= 3; attempt++ {
-		cloneErr = executeClonePhase(ctx, operation, logger)
+// cloneRunner performs one clone attempt; tests substitute a fake for ghorg
+type cloneRunner func(ctx context.Context, operation CloneOperation, logger *slog.Logger) error
+
+// executeCloneWithRetry retries transient clone failures up to Config.MaxRetries
+// times, doubling Config.RetryDelay after each attempt. Failures another attempt
+// cannot fix, such as rejected credentials, are returned immediately.
+func executeCloneWithRetry(ctx context.Context, operation CloneOperation, logger *slog.Logger, run cloneRunner) error {
+	delay := operation.Config.RetryDelay
+	for attempt := 1; ; attempt++ {
+		cloneErr := run(ctx, operation, logger)
 		if cloneErr == nil {
-			break
+			return nil
+		}
+		if ctx.Err() != nil || !isRetryableCloneError(cloneErr) {
+			return fmt.Errorf("clone failed and will not be retried: %w", cloneErr)
+		}
+		if attempt > operation.Config.MaxRetries {
+			return fmt.Errorf("failed to clone after %d attempts: %w", attempt, cloneErr)
 		}
-		logger.Warn("Clone failed, retrying", "attempt", attempt, "error", cloneErr)
-		time.Sleep(time.Duration(attempt*2) * retryDelay)
-	}
 
-	if cloneErr != nil {
-		return fmt.Errorf("failed to clone after 3 attempts: %w", cloneErr)
+		logger.Warn("Clone failed, retrying", "attempt", attempt, "max_retries", operation.Config.MaxRetries, "delay", delay, "error", cloneErr)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("clone retry cancelled: %w", ctx.Err())
+		case <-time.After(delay):
+		}
+		delay *= 2
 	}
-
-	return nil
 }
 
 func discoverRepositoriesWrapper(tempDir, org string) ([]Repository, error) {
@@ -661,13 +1062,16 @@ func createConfigFromEnv(envVars map[string]string) Config {
 	return Config{
 		MaxGoroutines:    DefaultMaxGoroutines,
 		CloneConcurrency: DefaultCloneConcurrency,
+		OrgConcurrency:   DefaultOrgConcurrency,
 		ProcessTimeout:   DefaultProcessTimeout,
 		RetryDelay:       retryDelay,
+		MaxRetries:       DefaultMaxRetries,
+		ProgressInterval: DefaultProgressInterval,
 		SkipArchived:     true,
 		SkipForks:        false,
 		GitHubToken:      getEnvOrDefault("GITHUB_TOKEN", ""),
 		Organizations:    parseOrganizations(getEnvOrDefault("GITHUB_ORGS", "")),
-		BaseURL:          getEnvOrDefault("GITHUB_BASE_URL", ""),
+		BaseURL:          normalizeBaseURLOrKeep(getEnvOrDefault("GITHUB_BASE_URL", "")),
 	}
 }
 
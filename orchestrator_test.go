@@ -2,14 +2,19 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -167,6 +172,30 @@ func TestValidateAnalysisConfiguration(t *testing.T) {
 			expectError: true,
 			errorMsg:    "CloneConcurrency must be positive, got 0",
 		},
+		{
+			name: "invalid file read concurrency - too high",
+			config: Config{
+				MaxGoroutines:       10,
+				CloneConcurrency:    5,
+				FileReadConcurrency: MaxSafeFileReadConcurrency + 1,
+				GitHubToken:         "test-token",
+				Organizations:       []string{"test-org"},
+			},
+			expectError: true,
+			errorMsg:    fmt.Sprintf("FileReadConcurrency must be between 0 and %d, got %d", MaxSafeFileReadConcurrency, MaxSafeFileReadConcurrency+1),
+		},
+		{
+			name: "invalid max retries - negative",
+			config: Config{
+				MaxGoroutines:    10,
+				CloneConcurrency: 5,
+				MaxRetries:       -1,
+				GitHubToken:      "test-token",
+				Organizations:    []string{"test-org"},
+			},
+			expectError: true,
+			errorMsg:    fmt.Sprintf("MaxRetries must be between 0 and %d, got -1", MaxSafeMaxRetries),
+		},
 		{
 			name: "missing github token",
 			config: Config{
@@ -1405,6 +1434,8 @@ func TestProcessRepositoriesConcurrently(t *testing.T) {
 		}
 		
 		config := Config{
+			Organizations:    []string{"test-org"},
+			GitHubToken:      "fake-token",
 			MaxGoroutines:    2,
 			CloneConcurrency: 1,
 			ProcessTimeout:   5 * time.Second,
@@ -1424,6 +1455,205 @@ func TestProcessRepositoriesConcurrently(t *testing.T) {
 		assert.NotNil(t, results)
 		assert.Len(t, results, len(repositories))
 	})
+
+	t.Run("streams one JSON line per completed repository", func(t *testing.T) {
+		// Given: three repositories, one of which does not exist, and a stream file
+		repositories := []Repository{
+			{Name: "network", Path: createTempTerraformRepo(t, map[string]string{"main.tf": `resource "aws_vpc" "main" {}`}), Organization: "test-org"},
+			{Name: "storage", Path: createTempTerraformRepo(t, map[string]string{"main.tf": `resource "aws_s3_bucket" "data" {}`}), Organization: "test-org"},
+			{Name: "missing", Path: filepath.Join(t.TempDir(), "missing"), Organization: "test-org"},
+		}
+		streamPath := filepath.Join(t.TempDir(), "results.jsonl")
+		require.NoError(t, os.WriteFile(streamPath, []byte("stale line from a previous run\n"), 0644))
+		config := Config{
+			Organizations:    []string{"test-org"},
+			GitHubToken:      "fake-token",
+			MaxGoroutines:    2,
+			CloneConcurrency: 1,
+			ProcessTimeout:   5 * time.Second,
+			StreamOutput:     streamPath,
+		}
+		processingCtx, err := createProcessingContext(config)
+		require.NoError(t, err)
+		logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+		// When: the repositories are processed and the context released
+		results := processRepositoriesConcurrently(repositories, context.Background(), processingCtx, logger)
+		releaseProcessingContext(processingCtx)
+
+		// Then: the file should hold exactly one valid JSON line per repository
+		require.Len(t, results, len(repositories))
+		content, err := os.ReadFile(streamPath)
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+		require.Len(t, lines, len(repositories))
+
+		records := map[string]StreamRecord{}
+		for _, line := range lines {
+			var record StreamRecord
+			require.NoError(t, json.Unmarshal([]byte(line), &record), "line %q", line)
+			records[record.Repo] = record
+		}
+		assert.Equal(t, 1, records["network"].Analysis.ResourceAnalysis.TotalResourceCount)
+		assert.Equal(t, 1, records["storage"].Analysis.ResourceAnalysis.TotalResourceCount)
+		assert.Empty(t, records["network"].Error)
+		assert.NotEmpty(t, records["missing"].Error)
+		assert.Nil(t, records["missing"].Analysis)
+	})
+
+	t.Run("creates the stream file's directory", func(t *testing.T) {
+		// Given: a stream path in a directory that does not exist yet
+		streamPath := filepath.Join(t.TempDir(), "out", "nested", "s.jsonl")
+
+		// When: the stream is opened and a result written
+		stream, err := openResultStream(streamPath)
+		require.NoError(t, err)
+		require.NoError(t, stream.Write(AnalysisResult{RepoName: "network", Organization: "test-org"}))
+		require.NoError(t, stream.Close())
+
+		// Then: the line lands in the new directory
+		content, err := os.ReadFile(streamPath)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), `"repo":"network"`)
+	})
+
+	t.Run("classifies timed-out repositories according to timeout-as-warning", func(t *testing.T) {
+		repoDir := createTempTerraformRepo(t, map[string]string{
+			"main.tf": `resource "aws_s3_bucket" "data" {}`,
+		})
+		repositories := []Repository{{Name: "huge-repo", Path: repoDir, Organization: "test-org"}}
+		logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+		tests := []struct {
+			name             string
+			timeoutAsWarning bool
+			expectedFailed   int
+			expectedWarnings int
+		}{
+			{"timeout is a failure by default", false, 1, 0},
+			{"timeout is a warning when enabled", true, 0, 1},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				// Given: a processing context whose deadline has already passed
+				config := Config{
+					Organizations:    []string{"test-org"},
+					GitHubToken:      "fake-token",
+					MaxGoroutines:    2,
+					CloneConcurrency: 1,
+					ProcessTimeout:   time.Millisecond,
+					TimeoutAsWarning: tt.timeoutAsWarning,
+				}
+				processingCtx, err := createProcessingContext(config)
+				require.NoError(t, err)
+				defer releaseProcessingContext(processingCtx)
+
+				ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+				defer cancel()
+
+				// When: the repository is processed
+				results := processRepositoriesConcurrently(repositories, ctx, processingCtx, logger)
+				stats := calculateStats(results, time.Second)
+
+				// Then: the timeout should be classified as configured
+				require.Len(t, results, 1)
+				assert.Equal(t, tt.expectedFailed, stats.FailedRepos)
+				assert.Equal(t, tt.expectedWarnings, stats.WarningRepos)
+				if tt.timeoutAsWarning {
+					assert.NoError(t, results[0].Error)
+					require.Len(t, results[0].Warnings, 1)
+					assert.Contains(t, results[0].Warnings[0], "timeout")
+				} else {
+					assert.ErrorIs(t, results[0].Error, context.DeadlineExceeded)
+				}
+			})
+		}
+	})
+
+	t.Run("interrupted run short-circuits remaining repositories", func(t *testing.T) {
+		// Given: a context cancelled as SIGINT would, even with timeout-as-warning set
+		repositories := []Repository{
+			{Name: "network", Path: createTempTerraformRepo(t, map[string]string{"main.tf": `resource "aws_vpc" "main" {}`}), Organization: "test-org"},
+			{Name: "storage", Path: createTempTerraformRepo(t, map[string]string{"main.tf": `resource "aws_s3_bucket" "data" {}`}), Organization: "test-org"},
+		}
+		config := Config{
+			Organizations:    []string{"test-org"},
+			GitHubToken:      "fake-token",
+			MaxGoroutines:    2,
+			CloneConcurrency: 1,
+			ProcessTimeout:   5 * time.Second,
+			TimeoutAsWarning: true,
+		}
+		processingCtx, err := createProcessingContext(config)
+		require.NoError(t, err)
+		defer releaseProcessingContext(processingCtx)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		// When: the repositories are processed
+		results := processRepositoriesConcurrently(repositories, ctx, processingCtx, logger)
+
+		// Then: every repository is returned for the reports, failed as cancelled rather than timed out
+		require.Len(t, results, len(repositories))
+		for _, result := range results {
+			assert.ErrorIs(t, result.Error, context.Canceled)
+			assert.NotContains(t, result.Error.Error(), "timeout")
+			assert.NotEmpty(t, result.Analysis.RepositoryPath)
+		}
+	})
+
+	t.Run("repository timing out mid-walk keeps partial results as a warning", func(t *testing.T) {
+		// Given: a repository whose walk is cut short by an expired context
+		repoDir := createTempTerraformRepo(t, map[string]string{
+			"main.tf": `resource "aws_s3_bucket" "data" {}`,
+		})
+		ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+		defer cancel()
+		logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+		// When: the repository is processed and classified
+		result := processRepositoryFilesWithContext(ctx, Repository{Name: "huge-repo", Path: repoDir, Organization: "test-org"}, defaultAnalysisOptions(), logger)
+		classified := classifyTimeoutResult(result, true)
+
+		// Then: the timeout error should become a warning and keep the analysis
+		assert.ErrorIs(t, result.Error, ErrRepositoryTimeout)
+		assert.NoError(t, classified.Error)
+		assert.Len(t, classified.Warnings, 1)
+		assert.Equal(t, repoDir, classified.Analysis.RepositoryPath)
+	})
+
+	t.Run("repository interrupted mid-walk stays a failure", func(t *testing.T) {
+		// Given: a repository whose walk is cut short by a cancelled context
+		repoDir := createTempTerraformRepo(t, map[string]string{
+			"main.tf": `resource "aws_s3_bucket" "data" {}`,
+		})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		// When: the repository is processed and classified
+		result := processRepositoryFilesWithContext(ctx, Repository{Name: "huge-repo", Path: repoDir, Organization: "test-org"}, defaultAnalysisOptions(), logger)
+		classified := classifyTimeoutResult(result, true)
+
+		// Then: the cancellation is not reported as a timeout or downgraded
+		assert.ErrorIs(t, classified.Error, context.Canceled)
+		assert.NotErrorIs(t, classified.Error, ErrRepositoryTimeout)
+		assert.Empty(t, classified.Warnings)
+	})
+
+	t.Run("errors joining a deadline with a cancellation stay failures", func(t *testing.T) {
+		// Given: a failure that wraps both a deadline and a cancellation
+		result := AnalysisResult{RepoName: "mixed", Error: errors.Join(context.DeadlineExceeded, context.Canceled)}
+
+		// When: the result is classified
+		classified := classifyTimeoutResult(result, true)
+
+		// Then: it is not downgraded to a warning
+		assert.Error(t, classified.Error)
+		assert.Empty(t, classified.Warnings)
+	})
 }
 
 // TestCloneAndAnalyzeMultipleOrgsCore tests multi-org analysis core functionality
@@ -1497,11 +1727,350 @@ func TestDiscoverRepositoriesWrapper(t *testing.T) {
 	})
 }
 
+// TestRequireRepos tests failing runs for organizations without repositories
+func TestRequireRepos(t *testing.T) {
+	// Given: an organization whose clone discovers no repositories next to one that has some
+	tempDir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(tempDir, "empty-org"), 0755))
+	processOrganization := func(orgCtx OrgProcessContext) (int, error) {
+		if orgCtx.Org == "acme" {
+			return 2, nil
+		}
+		repos, err := discoverRepositoriesWrapper(tempDir, orgCtx.Org)
+		return len(repos), err
+	}
+	run := func(orgs []string, requireRepos bool) error {
+		return processMultipleOrganizations(MultiOrgContext{
+			Ctx:                 context.Background(),
+			ProcessingCtx:       ProcessingContext{Config: Config{Organizations: orgs, RequireRepos: requireRepos}},
+			Reporter:            NewReporter(),
+			ProcessOrganization: processOrganization,
+		})
+	}
+
+	t.Run("fails naming the empty organization when required", func(t *testing.T) {
+		// When: repositories are required
+		err := run([]string{"acme", "empty-org"}, true)
+
+		// Then: the run should fail and name the empty organization
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrNoRepositoriesFound)
+		assert.Contains(t, err.Error(), "empty-org")
+		assert.NotContains(t, err.Error(), "acme")
+	})
+
+	t.Run("succeeds when not required", func(t *testing.T) {
+		// When: repositories are not required
+		err := run([]string{"acme", "empty-org"}, false)
+
+		// Then: the run should succeed
+		assert.NoError(t, err)
+	})
+
+	t.Run("succeeds when every organization has repositories", func(t *testing.T) {
+		// When: no organization was empty
+		err := run([]string{"acme"}, true)
+
+		// Then: the run should succeed
+		assert.NoError(t, err)
+	})
+}
+
+// TestOrgOrder tests the organization processing order option
+func TestOrgOrder(t *testing.T) {
+	orgs := []string{"zeta", "acme", "beta"}
+
+	tests := []struct {
+		name     string
+		order    string
+		expected []string
+	}{
+		{name: "as-listed keeps the --orgs order", order: OrgOrderAsListed, expected: []string{"zeta", "acme", "beta"}},
+		{name: "empty order keeps the --orgs order", order: "", expected: []string{"zeta", "acme", "beta"}},
+		{name: "alpha sorts organizations", order: OrgOrderAlpha, expected: []string{"acme", "beta", "zeta"}},
+		{name: "explicit priority list runs first", order: "beta,acme", expected: []string{"beta", "acme", "zeta"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given: a processor stub that records dispatched organizations
+			var dispatched []string
+			multiCtx := MultiOrgContext{
+				Ctx:           context.Background(),
+				ProcessingCtx: ProcessingContext{Config: Config{Organizations: orgs, OrgOrder: tt.order}},
+				Reporter:      NewReporter(),
+				ProcessOrganization: func(orgCtx OrgProcessContext) (int, error) {
+					dispatched = append(dispatched, orgCtx.Org)
+					return 1, nil
+				},
+			}
+
+			// When: the organizations are processed
+			err := processMultipleOrganizations(multiCtx)
+
+			// Then: they should be dispatched in the configured order
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, dispatched)
+		})
+	}
+
+	t.Run("priority list with unknown organization is rejected", func(t *testing.T) {
+		// When: the order names an organization that is not analyzed
+		_, err := orderOrganizations(orgs, "acme,typo-org")
+
+		// Then: an error should name the unknown organization
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "typo-org")
+	})
+}
+
+func TestCloneWorkspaceCleanup(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cloneInto := func(t *testing.T, config Config) (string, func()) {
+		tempDir, cleanup, err := setupWorkspaceWithRetry(logger, config)
+		require.NoError(t, err)
+		repoDir := filepath.Join(tempDir, "acme", "network")
+		require.NoError(t, os.MkdirAll(repoDir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, "main.tf"), []byte(`resource "aws_vpc" "main" {}`), 0644))
+		return tempDir, cleanup
+	}
+
+	t.Run("clones are removed after analysis", func(t *testing.T) {
+		// Given: an organization cloned under --clones-dir
+		clonesDir := t.TempDir()
+		tempDir, cleanup := cloneInto(t, Config{ClonesDir: clonesDir})
+		assert.Equal(t, clonesDir, filepath.Dir(tempDir))
+
+		// When: the workspace is cleaned up
+		cleanupWorkspace(cleanup, tempDir, "acme", logger)
+
+		// Then: the organization's directory is gone but the base directory remains
+		assert.NoDirExists(t, tempDir)
+		assert.DirExists(t, clonesDir)
+	})
+
+	t.Run("--keep-clones preserves the clones", func(t *testing.T) {
+		tempDir, cleanup := cloneInto(t, Config{ClonesDir: t.TempDir(), KeepClones: true})
+
+		cleanupWorkspace(cleanup, tempDir, "acme", logger)
+
+		assert.FileExists(t, filepath.Join(tempDir, "acme", "network", "main.tf"))
+	})
+
+	t.Run("organizations get separate directories", func(t *testing.T) {
+		clonesDir := t.TempDir()
+		first, cleanupFirst := cloneInto(t, Config{ClonesDir: clonesDir})
+		second, cleanupSecond := cloneInto(t, Config{ClonesDir: clonesDir})
+		defer cleanupSecond()
+
+		cleanupWorkspace(cleanupFirst, first, "acme", logger)
+
+		assert.NotEqual(t, first, second)
+		assert.DirExists(t, second)
+	})
+
+	t.Run("clone failures still remove the workspace", func(t *testing.T) {
+		// Given: a clone that fails because ghorg is not on PATH
+		t.Setenv("PATH", t.TempDir())
+		clonesDir := t.TempDir()
+		orgCtx := OrgProcessContext{
+			Ctx:           context.Background(),
+			Org:           "acme",
+			ProcessingCtx: ProcessingContext{Config: Config{ClonesDir: clonesDir, GitHubToken: "fake-token"}},
+			Reporter:      NewReporter(),
+			Logger:        logger,
+		}
+
+		// When: the organization is processed
+		_, err := processOrganizationWorkflow(orgCtx)
+
+		// Then: the error is returned and no clone directory is left behind
+		require.Error(t, err)
+		entries, readErr := os.ReadDir(clonesDir)
+		require.NoError(t, readErr)
+		assert.Empty(t, entries)
+	})
+}
+
+func TestOrgConcurrency(t *testing.T) {
+	orgs := []string{"org1", "org2", "org3", "org4", "org5"}
+
+	tests := []struct {
+		name        string
+		concurrency int
+		expected    int32
+	}{
+		{name: "zero processes one organization at a time", concurrency: 0, expected: 1},
+		{name: "default processes one organization at a time", concurrency: DefaultOrgConcurrency, expected: 1},
+		{name: "two organizations run at once", concurrency: 2, expected: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given: a processor stub that tracks how many organizations run at once
+			var running, peak, processed atomic.Int32
+			multiCtx := MultiOrgContext{
+				Ctx:           context.Background(),
+				ProcessingCtx: ProcessingContext{Config: Config{Organizations: orgs, OrgConcurrency: tt.concurrency}},
+				Reporter:      NewReporter(),
+				ProcessOrganization: func(orgCtx OrgProcessContext) (int, error) {
+					current := running.Add(1)
+					defer running.Add(-1)
+					for {
+						observed := peak.Load()
+						if current <= observed || peak.CompareAndSwap(observed, current) {
+							break
+						}
+					}
+					time.Sleep(20 * time.Millisecond)
+					processed.Add(1)
+					return 1, nil
+				},
+			}
+
+			// When: the organizations are processed
+			err := processMultipleOrganizations(multiCtx)
+
+			// Then: every organization runs, never more than the limit at once
+			require.NoError(t, err)
+			assert.Equal(t, int32(len(orgs)), processed.Load())
+			assert.Equal(t, tt.expected, peak.Load())
+		})
+	}
+}
+
+func TestAnalyzeLocalPath(t *testing.T) {
+	// Given: a checkout tree with two repositories and a hidden directory
+	localDir := createTempTerraformRepo(t, map[string]string{
+		"network/main.tf": `resource "aws_vpc" "main" {}`,
+		"storage/main.tf": `resource "aws_s3_bucket" "logs" {}` + "\n" + `resource "aws_s3_bucket" "data" {}`,
+		".git/config":     "[core]",
+		"README.md":       "# checkouts",
+	})
+	analyze := func(t *testing.T, config Config) *Reporter {
+		t.Helper()
+		config.MaxGoroutines, config.CloneConcurrency, config.ProcessTimeout = 2, 1, time.Minute
+		require.NoError(t, validateCLIAnalysisConfig(config))
+		processingCtx, err := createProcessingContext(config)
+		require.NoError(t, err)
+		defer releaseProcessingContext(processingCtx)
+
+		reporter := NewReporter()
+		require.NoError(t, analyzeLocalPath(context.Background(), processingCtx, reporter))
+		return reporter
+	}
+
+	t.Run("each subdirectory is analyzed without a token", func(t *testing.T) {
+		// When: the local path is analyzed
+		reporter := analyze(t, Config{LocalPath: localDir})
+
+		// Then: both repositories should be analyzed and the hidden directory ignored
+		resources := make(map[string]int)
+		for _, result := range reporter.results {
+			require.NoError(t, result.Error)
+			assert.Equal(t, LocalOrganization, result.Organization)
+			resources[result.RepoName] = result.Analysis.ResourceAnalysis.TotalResourceCount
+		}
+		assert.Equal(t, map[string]int{"network": 1, "storage": 2}, resources)
+	})
+
+	t.Run("single repo analyzes the path itself", func(t *testing.T) {
+		// When: the local path is analyzed as one repository
+		reporter := analyze(t, Config{LocalPath: localDir, SingleRepo: true})
+
+		// Then: every resource should belong to a single result
+		require.Len(t, reporter.results, 1)
+		assert.Equal(t, filepath.Base(localDir), reporter.results[0].RepoName)
+		assert.Equal(t, 3, reporter.results[0].Analysis.ResourceAnalysis.TotalResourceCount)
+	})
+
+	t.Run("single repo without a local path is rejected", func(t *testing.T) {
+		err := validateCLIAnalysisConfig(Config{SingleRepo: true, Organizations: []string{"acme"}, GitHubToken: "token"})
+		assert.ErrorContains(t, err, "--single-repo requires --local-path")
+	})
+
+	t.Run("missing local path returns an error", func(t *testing.T) {
+		_, err := discoverLocalRepositories(filepath.Join(localDir, "missing"), false)
+		assert.Error(t, err)
+	})
+}
+
 // ============================================================================
 // PANIC RECOVERY TESTS - Comprehensive tests for panic recovery mechanisms
 // ============================================================================
 
 // TestSubmitRepositoryJobsWithTimeoutPanicRecovery tests panic recovery in repository job submission
+func TestRepoTimeout(t *testing.T) {
+	t.Run("a slow repository times out while the others complete", func(t *testing.T) {
+		// Given: a repository whose analysis blocks until its context ends
+		analyze := func(ctx context.Context, repo Repository, options AnalysisOptions, logger *slog.Logger) AnalysisResult {
+			if repo.Name == "slow" {
+				<-ctx.Done()
+			}
+			return processRepositoryFilesWithContext(ctx, repo, options, logger)
+		}
+
+		repositories := []Repository{
+			{Name: "network", Path: createTempTerraformRepo(t, map[string]string{"main.tf": `resource "aws_vpc" "main" {}`}), Organization: "test-org"},
+			{Name: "slow", Path: createTempTerraformRepo(t, map[string]string{"main.tf": `resource "aws_s3_bucket" "data" {}`}), Organization: "test-org"},
+			{Name: "storage", Path: createTempTerraformRepo(t, map[string]string{"main.tf": `resource "aws_s3_bucket" "logs" {}`}), Organization: "test-org"},
+		}
+		config := Config{
+			Organizations:    []string{"test-org"},
+			GitHubToken:      "fake-token",
+			MaxGoroutines:    3,
+			CloneConcurrency: 1,
+			ProcessTimeout:   5 * time.Second,
+			RepoTimeout:      50 * time.Millisecond,
+		}
+		processingCtx, err := createProcessingContext(config)
+		require.NoError(t, err)
+		defer releaseProcessingContext(processingCtx)
+		ctx, cancel := context.WithTimeout(context.Background(), config.ProcessTimeout)
+		defer cancel()
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		// When: the repositories are processed
+		p := configureWaitGroup(config.MaxGoroutines)
+		jobResults := make(chan AnalysisResult, len(repositories))
+		submitRepositoryJobsWithTimeout(JobSubmissionContext{
+			Repositories: repositories,
+			Ctx:          ctx,
+			Pool:         p,
+			AntsPool:     processingCtx.Pool,
+			Results:      jobResults,
+			Options:      analysisOptionsFromConfig(config),
+			RepoTimeout:  config.RepoTimeout,
+			Logger:       logger,
+			Analyze:      analyze,
+		})
+		p.Wait()
+		close(jobResults)
+		var results []AnalysisResult
+		for result := range jobResults {
+			results = append(results, result)
+		}
+
+		// Then: only the slow repository fails, with a repository timeout error
+		require.Len(t, results, len(repositories))
+		byName := lo.KeyBy(results, func(result AnalysisResult) string { return result.RepoName })
+		assert.ErrorIs(t, byName["slow"].Error, ErrRepositoryTimeout)
+		assert.ErrorIs(t, byName["slow"].Error, context.DeadlineExceeded)
+		assert.Contains(t, byName["slow"].Error.Error(), "repository timeout of 50ms exceeded")
+		assert.Equal(t, ErrorKindTimeout, byName["slow"].ErrorKind)
+		for _, name := range []string{"network", "storage"} {
+			assert.NoError(t, byName[name].Error)
+			assert.Equal(t, 1, byName[name].Analysis.ResourceAnalysis.TotalResourceCount)
+		}
+		assert.NoError(t, ctx.Err(), "the run's timeout should not be consumed")
+	})
+
+	t.Run("negative repository timeouts are rejected", func(t *testing.T) {
+		err := validateAnalysisConfiguration(Config{MaxGoroutines: 1, CloneConcurrency: 1, ProcessTimeout: time.Minute, LocalPath: t.TempDir(), RepoTimeout: -time.Second})
+		assert.ErrorContains(t, err, "RepoTimeout")
+	})
+}
+
 func TestSubmitRepositoryJobsWithTimeoutPanicRecovery(t *testing.T) {
 	t.Run("recovers from panic in repository processing goroutine", func(t *testing.T) {
 		// Given: A repository that will cause panic and processing context
@@ -1782,12 +2351,12 @@ func TestParseWithRecoveryPanicRecovery(t *testing.T) {
 		
 		logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
 		
-		// When: parseOutputsSafely is called
-		result := parseOutputsSafely(invalidOutputContent, "outputs.tf", logger)
+		// When: parseOutputDetailsSafely is called
+		result := parseOutputDetailsSafely(invalidOutputContent, "outputs.tf", logger)
 		
 		// Then: Should return empty slice instead of panicking
 		assert.NotNil(t, result)
-		assert.Equal(t, []string{}, result)
+		assert.Equal(t, []OutputDetail{}, result)
 	})
 }
 
@@ -1909,7 +2478,7 @@ func TestPanicRecoveryWithNilPointers(t *testing.T) {
 		providers := parseProvidersSafely(emptyContent, "empty.tf", logger)
 		modules := parseModulesSafely(emptyContent, "empty.tf", logger)
 		variables := parseVariablesSafely(emptyContent, "empty.tf", logger)
-		outputs := parseOutputsSafely(emptyContent, "empty.tf", logger)
+		outputs := parseOutputDetailsSafely(emptyContent, "empty.tf", logger)
 		resourceTypes, untaggedResources := parseResourcesSafely(emptyContent, "empty.tf", logger)
 		
 		// Then: All should return appropriate empty results without panicking
@@ -1933,7 +2502,7 @@ func TestPanicRecoveryWithNilPointers(t *testing.T) {
 		providers := parseProvidersSafely(malformedContent, "malformed.tf", logger)
 		modules := parseModulesSafely(malformedContent, "malformed.tf", logger)
 		variables := parseVariablesSafely(malformedContent, "malformed.tf", logger)
-		outputs := parseOutputsSafely(malformedContent, "malformed.tf", logger)
+		outputs := parseOutputDetailsSafely(malformedContent, "malformed.tf", logger)
 		resourceTypes, untaggedResources := parseResourcesSafely(malformedContent, "malformed.tf", logger)
 		
 		// Then: All should handle gracefully without panicking
@@ -2034,4 +2603,186 @@ func TestPanicRecoveryLogging(t *testing.T) {
 		// but there should be some logging activity
 		_ = logOutput // Just verify no panic occurred
 	})
-}
\ No newline at end of file
+}
+
+func TestExecuteCloneWithRetry(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	operation := createCloneOperation("test-org", t.TempDir(), Config{MaxRetries: 3, RetryDelay: time.Millisecond})
+
+	// fakeRunner fails with each error in turn, then succeeds
+	fakeRunner := func(failures ...error) (cloneRunner, *int) {
+		calls := 0
+		return func(ctx context.Context, operation CloneOperation, logger *slog.Logger) error {
+			calls++
+			if calls <= len(failures) {
+				return failures[calls-1]
+			}
+			return nil
+		}, &calls
+	}
+	transient := &ghorgExitError{ExitCode: 1, Stderr: "GET https://api.github.com/orgs/test-org/repos: 502 Bad Gateway"}
+
+	t.Run("retries transient failures until the clone succeeds", func(t *testing.T) {
+		// Given: a runner that fails twice with a 5xx before succeeding
+		run, calls := fakeRunner(transient, transient)
+
+		// When: the clone is executed with retries
+		err := executeCloneWithRetry(context.Background(), operation, logger, run)
+
+		// Then: the third attempt should succeed
+		assert.NoError(t, err)
+		assert.Equal(t, 3, *calls)
+	})
+
+	t.Run("does not retry an authentication failure", func(t *testing.T) {
+		// Given: a runner whose token is rejected
+		run, calls := fakeRunner(&ghorgExitError{ExitCode: 1, Stderr: "401 Bad credentials"})
+
+		// When: the clone is executed with retries
+		err := executeCloneWithRetry(context.Background(), operation, logger, run)
+
+		// Then: it should give up after the first attempt
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "will not be retried")
+		assert.Equal(t, 1, *calls)
+	})
+
+	t.Run("gives up once the retries are exhausted", func(t *testing.T) {
+		// Given: a runner that keeps failing with a 5xx
+		run, calls := fakeRunner(transient, transient, transient, transient, transient)
+
+		// When: the clone is executed with retries
+		err := executeCloneWithRetry(context.Background(), operation, logger, run)
+
+		// Then: it should stop after MaxRetries retries and keep the last error
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to clone after 4 attempts")
+		assert.ErrorAs(t, err, new(*ghorgExitError))
+		assert.Equal(t, 4, *calls)
+	})
+
+	t.Run("stops waiting when the context is cancelled", func(t *testing.T) {
+		// Given: a cancelled context and a long backoff
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		slow := createCloneOperation("test-org", t.TempDir(), Config{MaxRetries: 3, RetryDelay: time.Hour})
+		run, calls := fakeRunner(transient)
+
+		// When: the clone is executed with retries
+		err := executeCloneWithRetry(ctx, slow, logger, run)
+
+		// Then: it should return without another attempt
+		require.Error(t, err)
+		assert.Equal(t, 1, *calls)
+	})
+}
+
+func TestCollectResultsProgress(t *testing.T) {
+	// Given: an organization logger and a manually driven progress clock
+	var logOutput strings.Builder
+	logger := slog.New(slog.NewJSONHandler(&logOutput, nil)).With("organization", "acme")
+	results := make(chan AnalysisResult)
+	ticks := make(chan time.Time)
+	go func() {
+		results <- AnalysisResult{RepoName: "network", Organization: "acme"}
+		results <- AnalysisResult{RepoName: "broken", Organization: "acme", Error: os.ErrNotExist}
+		ticks <- time.Now()
+		results <- AnalysisResult{RepoName: "storage", Organization: "acme"}
+		close(results)
+	}()
+
+	// When: results are collected while the clock ticks once
+	collected := collectResults(results, logger, 3, nil, ticks)
+
+	// Then: one progress event should report the repositories done at that point
+	assert.Len(t, collected, 3)
+	var progress []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(logOutput.String()), "\n") {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		if _, ok := entry["completed"]; ok {
+			progress = append(progress, entry)
+		}
+	}
+	require.Len(t, progress, 1)
+	assert.Equal(t, "Processed 2/3 repos", progress[0]["msg"])
+	assert.Equal(t, "acme", progress[0]["organization"])
+	assert.EqualValues(t, 2, progress[0]["completed"])
+	assert.EqualValues(t, 3, progress[0]["total"])
+	assert.EqualValues(t, 1, progress[0]["failed"])
+}
+
+func TestAnalysisErrorKinds(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("cancelled context", func(t *testing.T) {
+		// Given: a run interrupted before the repository was analyzed
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		results := make(chan AnalysisResult, 1)
+		p := configureWaitGroup(1)
+
+		// When: the job is submitted
+		submitRepositoryJobsWithTimeout(JobSubmissionContext{
+			Repositories: []Repository{{Name: "network", Path: t.TempDir(), Organization: "acme"}},
+			Ctx:          ctx,
+			Pool:         p,
+			Results:      results,
+			Logger:       logger,
+		})
+		p.Wait()
+
+		// Then: the result is classified as cancelled
+		assert.Equal(t, ErrorKindCancelled, (<-results).ErrorKind)
+	})
+
+	t.Run("missing path", func(t *testing.T) {
+		repo := Repository{Name: "gone", Path: filepath.Join(t.TempDir(), "missing"), Organization: "acme"}
+
+		result := processRepositoryFilesWithContext(context.Background(), repo, defaultAnalysisOptions(), logger)
+
+		require.Error(t, result.Error)
+		assert.Equal(t, ErrorKindPathNotFound, result.ErrorKind)
+	})
+
+	t.Run("recovered panic", func(t *testing.T) {
+		// Given: a job submission without a worker pool, which panics when the job runs
+		results := make(chan AnalysisResult, 1)
+		p := configureWaitGroup(1)
+
+		// When: the job is submitted
+		submitRepositoryJobsWithTimeout(JobSubmissionContext{
+			Repositories: []Repository{{Name: "network", Path: t.TempDir(), Organization: "acme"}},
+			Ctx:          context.Background(),
+			Pool:         p,
+			Results:      results,
+			Logger:       logger,
+		})
+		p.Wait()
+
+		// Then: the recovered panic is reported with its kind
+		result := <-results
+		assert.ErrorIs(t, result.Error, ErrAnalysisPanic)
+		assert.Equal(t, ErrorKindPanic, result.ErrorKind)
+	})
+
+	t.Run("kinds appear in the JSON and CSV reports", func(t *testing.T) {
+		reporter := NewReporter()
+		reporter.AddResults([]AnalysisResult{
+			{RepoName: "network", Organization: "acme", Analysis: RepositoryAnalysis{RepositoryPath: "/repos/acme/network"}},
+			withErrorKind(AnalysisResult{RepoName: "gone", Organization: "acme", Error: fmt.Errorf("stat: %w", os.ErrNotExist)}),
+		})
+
+		report := reporter.GenerateReport()
+		assert.Equal(t, []FailedRepository{{Repository: "gone", Organization: "acme", Error: "stat: file does not exist", ErrorKind: ErrorKindPathNotFound}}, report.FailedRepositories)
+
+		csvPath := filepath.Join(t.TempDir(), "report.csv")
+		require.NoError(t, reporter.ExportCSV(csvPath))
+		content, err := os.ReadFile(csvPath)
+		require.NoError(t, err)
+		lines := strings.Split(string(content), "\n")
+		require.Len(t, lines, 3)
+		assert.True(t, strings.HasSuffix(lines[0], ",ErrorKind"))
+		assert.Equal(t, "gone"+strings.Repeat(",", 14)+ErrorKindPathNotFound, lines[2])
+	})
+}
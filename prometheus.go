@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// ============================================================================
+// PROMETHEUS - Textfile collector metrics for scheduled analyses
+// ============================================================================
+
+type prometheusMetric struct {
+	Name  string
+	Help  string
+	Value func(counts SummaryCounts) int
+}
+
+// prometheusMetrics expose the per-organization SummaryCounts of the summary format
+var prometheusMetrics = []prometheusMetric{
+	{
+		Name:  "tfanalyzer_repos_total",
+		Help:  "Repositories processed in the last analysis run.",
+		Value: func(counts SummaryCounts) int { return counts.TotalRepos },
+	},
+	{
+		Name:  "tfanalyzer_repos_failed",
+		Help:  "Repositories whose analysis failed in the last analysis run.",
+		Value: func(counts SummaryCounts) int { return counts.FailedRepos },
+	},
+	{
+		Name:  "tfanalyzer_untagged_resources_total",
+		Help:  "Resources missing mandatory tags in successfully analyzed repositories.",
+		Value: func(counts SummaryCounts) int { return counts.UntaggedResources },
+	},
+	{
+		Name:  "tfanalyzer_resources_total",
+		Help:  "Resource blocks in successfully analyzed repositories.",
+		Value: func(counts SummaryCounts) int { return counts.TotalResources },
+	},
+}
+
+// Prometheus renders one gauge series per organization for each metric, in
+// the text exposition format read by node_exporter's textfile collector
+func (r *Reporter) Prometheus() string {
+	organizations := r.Summary().Organizations
+
+	var builder strings.Builder
+	for _, metric := range prometheusMetrics {
+		fmt.Fprintf(&builder, "# HELP %s %s\n", metric.Name, metric.Help)
+		fmt.Fprintf(&builder, "# TYPE %s gauge\n", metric.Name)
+		for _, organization := range organizations {
+			fmt.Fprintf(&builder, "%s{organization=\"%s\"} %d\n",
+				metric.Name, escapePrometheusLabel(organization.Organization), metric.Value(organization.SummaryCounts))
+		}
+	}
+	return builder.String()
+}
+
+var prometheusLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapePrometheusLabel(value string) string {
+	return prometheusLabelEscaper.Replace(value)
+}
+
+// ExportPrometheus writes through a temporary file and renames it so the
+// textfile collector never scrapes a partially written file
+func (r *Reporter) ExportPrometheus(filename string) error {
+	tempFile := filename + ".tmp"
+	if err := os.WriteFile(tempFile, []byte(r.Prometheus()), 0644); err != nil {
+		return fmt.Errorf("failed to write Prometheus metrics file: %w", err)
+	}
+	if err := os.Rename(tempFile, filename); err != nil {
+		return fmt.Errorf("failed to write Prometheus metrics file: %w", err)
+	}
+
+	slog.Info("Prometheus metrics exported", "file", filename)
+	return nil
+}
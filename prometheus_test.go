@@ -0,0 +1,95 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+var (
+	prometheusCommentPattern = regexp.MustCompile(`^# (HELP|TYPE) ([a-zA-Z_:][a-zA-Z0-9_:]*) (.+)$`)
+	prometheusSamplePattern  = regexp.MustCompile(`^([a-zA-Z_:][a-zA-Z0-9_:]*)\{organization="((?:[^"\\\n]|\\.)*)"\} (-?[0-9]+)$`)
+)
+
+func TestPrometheusMetrics(t *testing.T) {
+	// Given: two organizations, one with a failed repository and one whose name needs escaping
+	reporter := NewReporter()
+	reporter.AddResults([]AnalysisResult{
+		{RepoName: "network", Organization: "acme", Analysis: RepositoryAnalysis{ResourceAnalysis: ResourceAnalysis{
+			TotalResourceCount: 4,
+			UntaggedResources:  []UntaggedResource{{ResourceType: "aws_vpc", Name: "main"}},
+		}}},
+		{RepoName: "storage", Organization: "acme", Analysis: RepositoryAnalysis{ResourceAnalysis: ResourceAnalysis{TotalResourceCount: 2}}},
+		{RepoName: "broken", Organization: "acme", Error: os.ErrNotExist},
+		{RepoName: "edge", Organization: `we"ird`, Analysis: RepositoryAnalysis{ResourceAnalysis: ResourceAnalysis{TotalResourceCount: 1}}},
+	})
+	viper.Reset()
+	tempDir := t.TempDir()
+	viper.Set("output.format", "prometheus")
+	viper.Set("output.directory", tempDir)
+
+	// When: reports are generated with --format prometheus
+	if err := generateReports(reporter, Config{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Then: the file should be valid exposition format with HELP and TYPE before each metric's samples
+	content, err := os.ReadFile(filepath.Join(tempDir, PrometheusFileName))
+	if err != nil {
+		t.Fatalf("Expected metrics file: %v", err)
+	}
+	if !strings.HasSuffix(string(content), "\n") {
+		t.Error("Expected the file to end with a newline")
+	}
+
+	described := make(map[string]map[string]bool)
+	samples := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSuffix(string(content), "\n"), "\n") {
+		if match := prometheusCommentPattern.FindStringSubmatch(line); match != nil {
+			if described[match[2]] == nil {
+				described[match[2]] = make(map[string]bool)
+			}
+			described[match[2]][match[1]] = true
+			if match[1] == "TYPE" && match[3] != "gauge" {
+				t.Errorf("Expected gauge type, got %q", line)
+			}
+			continue
+		}
+		match := prometheusSamplePattern.FindStringSubmatch(line)
+		if match == nil {
+			t.Errorf("Line is not valid exposition format: %q", line)
+			continue
+		}
+		if !described[match[1]]["HELP"] || !described[match[1]]["TYPE"] {
+			t.Errorf("Expected HELP and TYPE before sample %q", line)
+		}
+		samples[match[1]+"/"+match[2]] = match[3]
+	}
+
+	expected := map[string]string{
+		"tfanalyzer_repos_total/acme":                 "3",
+		"tfanalyzer_repos_failed/acme":                "1",
+		"tfanalyzer_untagged_resources_total/acme":    "1",
+		"tfanalyzer_resources_total/acme":             "6",
+		`tfanalyzer_repos_total/we\"ird`:              "1",
+		`tfanalyzer_repos_failed/we\"ird`:             "0",
+		`tfanalyzer_untagged_resources_total/we\"ird`: "0",
+		`tfanalyzer_resources_total/we\"ird`:          "1",
+	}
+	if len(samples) != len(expected) {
+		t.Errorf("Expected %d samples, got %v", len(expected), samples)
+	}
+	for series, value := range expected {
+		if samples[series] != value {
+			t.Errorf("Expected %s = %s, got %q", series, value, samples[series])
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(tempDir, PrometheusFileName+".tmp")); !os.IsNotExist(err) {
+		t.Errorf("Expected no temporary file left behind, got stat error %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// ============================================================================
+// RECENCY - Skipping repositories without recent commits (--since)
+// ============================================================================
+
+// parseSinceDuration accepts Go durations such as "72h" plus whole days
+// such as "30d", since --since windows are usually counted in days
+func parseSinceDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+
+	var since time.Duration
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		count, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid --since value %q: expected a duration such as 30d or 72h", value)
+		}
+		since = time.Duration(count) * 24 * time.Hour
+	} else {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid --since value %q: expected a duration such as 30d or 72h", value)
+		}
+		since = parsed
+	}
+
+	if since < 0 {
+		return 0, fmt.Errorf("invalid --since value %q: must not be negative", value)
+	}
+	return since, nil
+}
+
+// commitTimeReader reads the time of a repository's latest commit
+type commitTimeReader func(ctx context.Context, repoPath string) (time.Time, error)
+
+func gitLatestCommitTime(ctx context.Context, repoPath string) (time.Time, error) {
+	output, err := exec.CommandContext(ctx, "git", "-C", repoPath, "log", "-1", "--format=%ct", "HEAD").Output()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read latest commit time of %s: %w", repoPath, err)
+	}
+	seconds, err := strconv.ParseInt(strings.TrimSpace(string(output)), 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unexpected commit time %q for %s: %w", output, repoPath, err)
+	}
+	return time.Unix(seconds, 0), nil
+}
+
+// filterRecentRepositories drops repositories whose latest commit is older
+// than since, as read by commitTime. Repositories whose commit time cannot be
+// read, such as plain directories under --local-path, are kept rather than
+// silently dropped.
+func filterRecentRepositories(ctx context.Context, repositories []Repository, since time.Duration, commitTime commitTimeReader, logger *slog.Logger) []Repository {
+	if since <= 0 {
+		return repositories
+	}
+
+	cutoff := time.Now().Add(-since)
+	recent := lo.Filter(repositories, func(repo Repository, _ int) bool {
+		committed, err := commitTime(ctx, repo.Path)
+		if err != nil {
+			logger.Debug("Keeping repository with unknown commit time", "repository", repo.Name, "error", err)
+			return true
+		}
+		if committed.Before(cutoff) {
+			logger.Debug("Skipping repository without recent commits", "repository", repo.Name, "last_commit", committed)
+			return false
+		}
+		return true
+	})
+
+	logger.Info("Filtered repositories by latest commit",
+		"since", since,
+		"kept", len(recent),
+		"skipped", len(repositories)-len(recent))
+	return recent
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseSinceDuration(t *testing.T) {
+	tests := []struct {
+		value       string
+		expected    time.Duration
+		expectError bool
+	}{
+		{"", 0, false},
+		{"30d", 30 * 24 * time.Hour, false},
+		{"72h", 72 * time.Hour, false},
+		{"1h30m", 90 * time.Minute, false},
+		{"-5d", 0, true},
+		{"two weeks", 0, true},
+		{"1.5d", 0, true},
+	}
+	for _, tt := range tests {
+		since, err := parseSinceDuration(tt.value)
+		if tt.expectError {
+			if err == nil {
+				t.Errorf("Expected an error for %q, got %v", tt.value, since)
+			}
+			continue
+		}
+		if err != nil || since != tt.expected {
+			t.Errorf("parseSinceDuration(%q) = %v, %v; expected %v", tt.value, since, err, tt.expected)
+		}
+	}
+}
+
+func TestFilterRecentRepositories(t *testing.T) {
+	// Given: repositories last committed 2 days ago, 90 days ago, and never
+	commitTimes := map[string]time.Time{
+		"/repos/active": time.Now().Add(-48 * time.Hour),
+		"/repos/stale":  time.Now().Add(-90 * 24 * time.Hour),
+	}
+	commitTime := func(_ context.Context, repoPath string) (time.Time, error) {
+		if committed, ok := commitTimes[repoPath]; ok {
+			return committed, nil
+		}
+		return time.Time{}, os.ErrNotExist
+	}
+	repositories := []Repository{
+		{Name: "active", Path: "/repos/active"},
+		{Name: "stale", Path: "/repos/stale"},
+		{Name: "plain-dir", Path: "/repos/plain-dir"},
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("skips repositories older than the cutoff", func(t *testing.T) {
+		// When: repositories are filtered to the last 30 days
+		recent := filterRecentRepositories(context.Background(), repositories, 30*24*time.Hour, commitTime, logger)
+
+		// Then: the stale repository is dropped and the unknown one kept
+		if len(recent) != 2 || recent[0].Name != "active" || recent[1].Name != "plain-dir" {
+			t.Errorf("Expected active and plain-dir, got %+v", recent)
+		}
+	})
+
+	t.Run("keeps everything when --since is not set", func(t *testing.T) {
+		if recent := filterRecentRepositories(context.Background(), repositories, 0, commitTime, logger); len(recent) != len(repositories) {
+			t.Errorf("Expected all %d repositories, got %+v", len(repositories), recent)
+		}
+	})
+}
+
+func TestGitLatestCommitTime(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	// Given: a git repository whose only commit is backdated to 2020
+	repoDir := t.TempDir()
+	backdated := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "--allow-empty", "-m", "initial"},
+	} {
+		cmd := exec.Command("git", append([]string{"-C", repoDir}, args...)...)
+		cmd.Env = append(os.Environ(), "GIT_COMMITTER_DATE="+backdated.Format(time.RFC3339), "GIT_AUTHOR_DATE="+backdated.Format(time.RFC3339))
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v: %s", args, err, output)
+		}
+	}
+
+	// When: the latest commit time is read
+	committed, err := gitLatestCommitTime(context.Background(), repoDir)
+
+	// Then: it should be the backdated commit time, and a --since window excludes it
+	if err != nil || !committed.Equal(backdated) {
+		t.Fatalf("Expected %v, got %v (%v)", backdated, committed, err)
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	repositories := []Repository{{Name: "old", Path: repoDir}}
+	if recent := filterRecentRepositories(context.Background(), repositories, 30*24*time.Hour, gitLatestCommitTime, logger); len(recent) != 0 {
+		t.Errorf("Expected the backdated repository to be skipped, got %+v", recent)
+	}
+
+	if _, err := gitLatestCommitTime(context.Background(), filepath.Join(repoDir, "missing")); err == nil {
+		t.Error("Expected an error outside a git repository")
+	}
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ============================================================================
+// REGISTRY - Latest provider versions from the Terraform Registry (--check-latest)
+// ============================================================================
+
+const (
+	// DefaultRegistryURL is the public registry queried for provider releases
+	DefaultRegistryURL = "https://registry.terraform.io"
+	// DefaultRegistryTimeout bounds each registry request, including reading the response
+	DefaultRegistryTimeout = 10 * time.Second
+	// publicRegistryHost is the host implied by namespace/type provider sources
+	publicRegistryHost = "registry.terraform.io"
+)
+
+// registryClient looks up provider releases through the registry's provider
+// versions API. Each source is fetched at most once per run; failed lookups
+// are cached too so a registry outage costs one request per provider.
+type registryClient struct {
+	baseURL string
+	http    *http.Client
+	mu      sync.Mutex
+	cache   map[string]registryLookup
+}
+
+type registryLookup struct {
+	releases []string // Released versions, newest first
+	err      error
+}
+
+// registryVersionsResponse is the part of /v1/providers/{namespace}/{type}/versions that is read
+type registryVersionsResponse struct {
+	Versions []struct {
+		Version string `json:"version"`
+	} `json:"versions"`
+}
+
+func newRegistryClient(baseURL string, timeout time.Duration) *registryClient {
+	return &registryClient{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		http:    &http.Client{Timeout: timeout},
+		cache:   make(map[string]registryLookup),
+	}
+}
+
+// registryProviderAddress splits a canonical provider source into the
+// namespace and type the public registry serves; other hosts are not queried
+func registryProviderAddress(source string) (namespace, providerType string, ok bool) {
+	parts := strings.Split(strings.ToLower(source), "/")
+	if len(parts) == 3 && parts[0] == publicRegistryHost {
+		parts = parts[1:]
+	}
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// releases returns the provider's released versions, newest first.
+// Pre-releases are skipped because Terraform never selects them implicitly.
+func (c *registryClient) releases(ctx context.Context, source string) ([]string, error) {
+	namespace, providerType, ok := registryProviderAddress(source)
+	if !ok {
+		return nil, fmt.Errorf("provider %s is not served by %s", source, publicRegistryHost)
+	}
+	key := namespace + "/" + providerType
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if lookup, cached := c.cache[key]; cached {
+		return lookup.releases, lookup.err
+	}
+	releases, err := c.fetchReleases(ctx, namespace, providerType)
+	c.cache[key] = registryLookup{releases: releases, err: err}
+	return releases, err
+}
+
+func (c *registryClient) fetchReleases(ctx context.Context, namespace, providerType string) ([]string, error) {
+	url := fmt.Sprintf("%s/v1/providers/%s/%s/versions", c.baseURL, namespace, providerType)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build registry request: %w", err)
+	}
+	response, err := c.http.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("registry request for %s/%s failed: %w", namespace, providerType, err)
+	}
+	defer func() { _ = response.Body.Close() }()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("registry returned %s for %s/%s", response.Status, namespace, providerType)
+	}
+
+	var body registryVersionsResponse
+	if err := json.NewDecoder(response.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode registry response for %s/%s: %w", namespace, providerType, err)
+	}
+
+	type release struct {
+		version string
+		numbers []int
+	}
+	var releases []release
+	for _, entry := range body.Versions {
+		if strings.ContainsAny(entry.Version, "-+") {
+			continue
+		}
+		if numbers, ok := parseVersionNumbers(entry.Version); ok {
+			releases = append(releases, release{entry.Version, numbers})
+		}
+	}
+	slices.SortFunc(releases, func(a, b release) int {
+		return compareVersionNumbers(b.numbers, a.numbers)
+	})
+	versions := make([]string, len(releases))
+	for i, r := range releases {
+		versions[i] = r.version
+	}
+	return versions, nil
+}
+
+// providerVersionsBehind finds the newest release a normalized constraint
+// allows, which is what terraform init would install, and counts the
+// releases newer than it. Unconstrained providers always track the latest,
+// and constraints no release satisfies are not counted as behind.
+func providerVersionsBehind(constraint string, releases []string) int {
+	for i, version := range releases {
+		if constraintAllows(constraint, version) {
+			return i
+		}
+	}
+	return 0
+}
+
+// constraintAllows evaluates a normalized constraint such as "~> 5.0" or
+// ">= 4.2, < 6.0" against a release; unparseable parts allow every release
+func constraintAllows(constraint, version string) bool {
+	release, ok := parseVersionNumbers(version)
+	if !ok {
+		return false
+	}
+	for _, part := range strings.Split(constraint, ", ") {
+		if part == "" {
+			continue
+		}
+		operator, bound := "=", part
+		for _, op := range versionConstraintOperators {
+			if rest, found := strings.CutPrefix(part, op); found {
+				operator, bound = op, strings.TrimSpace(rest)
+				break
+			}
+		}
+		limit, ok := parseVersionNumbers(bound)
+		if !ok {
+			continue
+		}
+		if !versionSatisfies(release, operator, limit) {
+			return false
+		}
+	}
+	return true
+}
+
+func versionSatisfies(release []int, operator string, limit []int) bool {
+	comparison := compareVersionNumbers(release, limit)
+	switch operator {
+	case "~>":
+		// ~> 5.1 allows >= 5.1, < 6.0 and ~> 5.1.2 allows >= 5.1.2, < 5.2.0
+		upper := slices.Clone(limit[:max(len(limit)-1, 1)])
+		upper[len(upper)-1]++
+		return comparison >= 0 && compareVersionNumbers(release, upper) < 0
+	case ">=":
+		return comparison >= 0
+	case "<=":
+		return comparison <= 0
+	case ">":
+		return comparison > 0
+	case "<":
+		return comparison < 0
+	case "!=":
+		return comparison != 0
+	default:
+		return comparison == 0
+	}
+}
+
+// checkLatestProviderVersions sets LatestVersion and VersionsBehind on every
+// analyzed provider the registry serves. Lookup failures are warnings and
+// leave the provider without latest-version details.
+func checkLatestProviderVersions(ctx context.Context, reporter *Reporter, client *registryClient, logger *slog.Logger) {
+	reporter.mu.Lock()
+	defer reporter.mu.Unlock()
+
+	warned := make(map[string]bool)
+	for i := range reporter.results {
+		providers := reporter.results[i].Analysis.Providers.ProviderDetails
+		for j := range providers {
+			provider := &providers[j]
+			if _, _, ok := registryProviderAddress(provider.Source); !ok {
+				continue
+			}
+			releases, err := client.releases(ctx, provider.Source)
+			if err != nil {
+				if !warned[provider.Source] {
+					logger.Warn("Failed to look up latest provider version", "provider", provider.Source, "error", err)
+					warned[provider.Source] = true
+				}
+				continue
+			}
+			if len(releases) == 0 {
+				continue
+			}
+			provider.LatestVersion = releases[0]
+			provider.VersionsBehind = providerVersionsBehind(provider.Version, releases)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCheckLatestProviderVersions(t *testing.T) {
+	// Given: a registry serving aws and random releases and failing for everything else
+	var requests atomic.Int32
+	registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch r.URL.Path {
+		case "/v1/providers/hashicorp/aws/versions":
+			fmt.Fprint(w, `{"versions":[{"version":"4.67.0"},{"version":"5.0.0"},{"version":"5.1.0"},{"version":"6.0.0-beta1"},{"version":"5.2.0"},{"version":"4.9.0"}]}`)
+		case "/v1/providers/hashicorp/random/versions":
+			fmt.Fprint(w, `{"versions":[{"version":"3.6.0"},{"version":"3.5.1"}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer registry.Close()
+
+	providers := func(details ...ProviderDetail) AnalysisResult {
+		return AnalysisResult{RepoName: "repo", Organization: "acme", Analysis: RepositoryAnalysis{
+			Providers: ProvidersAnalysis{ProviderDetails: details},
+		}}
+	}
+	reporter := NewReporter()
+	reporter.AddResults([]AnalysisResult{
+		providers(
+			ProviderDetail{Source: "hashicorp/aws", Version: "~> 4.0"},
+			ProviderDetail{Source: "hashicorp/random"},
+			ProviderDetail{Source: "registry.example.com/platform/internal", Version: "1.0.0"},
+		),
+		providers(
+			ProviderDetail{Source: "registry.terraform.io/hashicorp/aws", Version: ">= 5.0, < 5.2"},
+			ProviderDetail{Source: "acme/missing", Version: "~> 1.0"},
+		),
+	})
+	var logs strings.Builder
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+
+	// When: latest versions are checked
+	checkLatestProviderVersions(context.Background(), reporter, newRegistryClient(registry.URL+"/", time.Second), logger)
+
+	// Then: each provider reports the latest release and how far its constraint is behind
+	expected := [][]ProviderDetail{
+		{
+			{Source: "hashicorp/aws", Version: "~> 4.0", LatestVersion: "5.2.0", VersionsBehind: 3},
+			{Source: "hashicorp/random", LatestVersion: "3.6.0"},
+			{Source: "registry.example.com/platform/internal", Version: "1.0.0"},
+		},
+		{
+			{Source: "registry.terraform.io/hashicorp/aws", Version: ">= 5.0, < 5.2", LatestVersion: "5.2.0", VersionsBehind: 1},
+			{Source: "acme/missing", Version: "~> 1.0"},
+		},
+	}
+	for i, result := range reporter.results {
+		for j, provider := range result.Analysis.Providers.ProviderDetails {
+			if provider.LatestVersion != expected[i][j].LatestVersion || provider.VersionsBehind != expected[i][j].VersionsBehind {
+				t.Errorf("Expected %s %q to be %+v, got %+v", provider.Source, provider.Version, expected[i][j], provider)
+			}
+		}
+	}
+
+	// Then: each source is fetched once and the failed lookup is a warning
+	if got := requests.Load(); got != 3 {
+		t.Errorf("Expected 3 registry requests (aws, random, missing), got %d", got)
+	}
+	if !strings.Contains(logs.String(), "level=WARN") || !strings.Contains(logs.String(), "acme/missing") {
+		t.Errorf("Expected a warning for acme/missing, got %q", logs.String())
+	}
+
+	// Then: the markdown report lists the outdated constraints
+	markdown := reporter.generateMarkdownContent()
+	for _, row := range []string{"## Outdated Providers", "| hashicorp/aws | ~> 4.0 | 5.2.0 | 3 | 1 |", "| registry.terraform.io/hashicorp/aws | >= 5.0, < 5.2 | 5.2.0 | 1 | 1 |"} {
+		if !strings.Contains(markdown, row) {
+			t.Errorf("Expected markdown to contain %q", row)
+		}
+	}
+}
+
+func TestRegistryClientTimeout(t *testing.T) {
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(200 * time.Millisecond):
+		}
+	}))
+	defer slow.Close()
+
+	client := newRegistryClient(slow.URL, 20*time.Millisecond)
+	if _, err := client.releases(context.Background(), "hashicorp/aws"); err == nil {
+		t.Error("Expected the registry timeout to be applied")
+	}
+
+	reporter := NewReporter()
+	reporter.AddResults([]AnalysisResult{{RepoName: "repo", Analysis: RepositoryAnalysis{
+		Providers: ProvidersAnalysis{ProviderDetails: []ProviderDetail{{Source: "hashicorp/aws", Version: "~> 5.0"}}},
+	}}})
+	checkLatestProviderVersions(context.Background(), reporter, client, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if provider := reporter.results[0].Analysis.Providers.ProviderDetails[0]; provider.LatestVersion != "" {
+		t.Errorf("Expected no latest version after a failed lookup, got %+v", provider)
+	}
+}
+
+func TestConstraintAllows(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		expected   bool
+	}{
+		{"", "9.9.9", true},
+		{"~> 5.1", "5.9.0", true},
+		{"~> 5.1", "6.0.0", false},
+		{"~> 5.1.2", "5.1.9", true},
+		{"~> 5.1.2", "5.2.0", false},
+		{"~> 5", "5.9.0", true},
+		{"~> 5", "6.0.0", false},
+		{">= 4.2, < 6.0", "5.5.0", true},
+		{">= 4.2, < 6.0", "4.1.0", false},
+		{"!= 5.0.0", "5.0.0", false},
+		{"5.0.0", "5.0.0", true},
+		{"= 5.0.0", "5.0.1", false},
+	}
+	for _, tt := range tests {
+		if got := constraintAllows(tt.constraint, tt.version); got != tt.expected {
+			t.Errorf("Expected constraintAllows(%q, %q) to be %v, got %v", tt.constraint, tt.version, tt.expected, got)
+		}
+	}
+}
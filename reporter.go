@@ -1,10 +1,20 @@
 package main
 
 import (
+	"cmp"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"maps"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/bitfield/script"
@@ -28,21 +38,47 @@ type GlobalBackendSummary struct {
 
 type GlobalSummary struct {
 	TotalReposScanned    int                  `json:"total_repos_scanned"`
+	EmptyRepos           int                  `json:"empty_repos"` // Repositories without any Terraform files
 	GlobalBackendSummary GlobalBackendSummary `json:"global_backend_summary"`
+	Findings             FindingsSummary      `json:"findings"`
+	ModuleSourceTypes    map[string]int       `json:"module_source_types"`
+	ResourceTypes        []ResourceTypeUsage  `json:"resource_types"`
+	TagCoveragePct       float64              `json:"tag_coverage_pct"`              // Across every taggable resource; see rollUpTagCoverage
+	TagCoverageByOrg     map[string]float64   `json:"tag_coverage_by_org,omitempty"` // Keyed by organization; absent for local analysis
+}
+
+// ResourceTypeUsage is one resource type's use across every analyzed repository
+type ResourceTypeUsage struct {
+	Type       string `json:"type"`
+	TotalCount int    `json:"total_count"` // Resource blocks of this type in all repositories
+	RepoCount  int    `json:"repo_count"`  // Repositories declaring at least one
 }
 
 type RepositoryForJSON struct {
 	RepositoryAnalysis
+	Organization string   `json:"organization,omitempty"`
+	Warnings     []string `json:"warnings,omitempty"`
+}
+
+// FailedRepository is a repository that could not be analyzed
+type FailedRepository struct {
+	Repository   string `json:"repository"`
 	Organization string `json:"organization,omitempty"`
+	Error        string `json:"error"`
+	ErrorKind    string `json:"error_kind"` // timeout, cancelled, path_not_found, panic or analysis_error
 }
 
 type ComprehensiveReport struct {
-	Repositories  []RepositoryForJSON `json:"repositories"`
-	GlobalSummary GlobalSummary       `json:"global_summary"`
+	Repositories       []RepositoryForJSON `json:"repositories"`
+	FailedRepositories []FailedRepository  `json:"failed_repositories,omitempty"`
+	GlobalSummary      GlobalSummary       `json:"global_summary"`
 }
 
 type Reporter struct {
-	results []AnalysisResult
+	mu               sync.Mutex // Guards results while organizations are analyzed concurrently
+	results          []AnalysisResult
+	mandatoryTags    []string // Tag set the untagged resources were checked against
+	maxTotalFindings int      // Cap on findings detail across all repositories; 0 is unlimited
 }
 
 func NewReporter() *Reporter {
@@ -51,7 +87,21 @@ func NewReporter() *Reporter {
 	}
 }
 
+// SetMandatoryTags records the tag set used for analysis so reports describe it
+func (r *Reporter) SetMandatoryTags(tags []string) {
+	r.mandatoryTags = tags
+}
+
+func (r *Reporter) requiredTags() []string {
+	if len(r.mandatoryTags) > 0 {
+		return r.mandatoryTags
+	}
+	return defaultMandatoryTags
+}
+
 func (r *Reporter) AddResults(results []AnalysisResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	r.results = append(r.results, results...)
 }
 
@@ -59,14 +109,189 @@ func (r *Reporter) GetResults() []AnalysisResult {
 	return r.results
 }
 
+// Organizations lists the organizations with results, in result order
+func (r *Reporter) Organizations() []string {
+	return lo.Uniq(lo.Map(r.results, func(result AnalysisResult, _ int) string {
+		return result.Organization
+	}))
+}
+
+// ForOrganization returns a reporter holding only org's results, with the same report settings
+func (r *Reporter) ForOrganization(org string) *Reporter {
+	return &Reporter{
+		results: lo.Filter(r.results, func(result AnalysisResult, _ int) bool {
+			return result.Organization == org
+		}),
+		mandatoryTags:    r.mandatoryTags,
+		maxTotalFindings: r.maxTotalFindings,
+	}
+}
+
+// Report sort keys accepted by --sort-reports-by
+const (
+	SortByOrg        = "org"
+	SortByName       = "name"
+	SortByResources  = "resources"
+	SortByUntagged   = "untagged"
+	SortByScore      = "score"
+	SortByComplexity = "complexity"
+)
+
+var validSortKeys = []string{SortByOrg, SortByName, SortByResources, SortByUntagged, SortByScore, SortByComplexity}
+
+// SortResults orders the results used by every report format
+func (r *Reporter) SortResults(key string) error {
+	sorted, err := sortResults(r.results, key)
+	if err != nil {
+		return err
+	}
+	r.results = sorted
+	return nil
+}
+
+// sortResults returns a sorted copy of results. Name and org sort
+// ascending, resource and untagged counts descending, and compliance
+// score ascending so the least compliant repositories come first, and
+// complexity score descending.
+// Ties always fall back to organization then repository name.
+func sortResults(results []AnalysisResult, key string) ([]AnalysisResult, error) {
+	compare, err := resultComparator(key)
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := slices.Clone(results)
+	slices.SortStableFunc(sorted, func(a, b AnalysisResult) int {
+		if order := compare(a, b); order != 0 {
+			return order
+		}
+		return compareByOrgThenName(a, b)
+	})
+	return sorted, nil
+}
+
+func resultComparator(key string) (func(a, b AnalysisResult) int, error) {
+	switch key {
+	case "", SortByOrg:
+		return compareByOrgThenName, nil
+	case SortByName:
+		return func(a, b AnalysisResult) int {
+			return cmp.Compare(a.RepoName, b.RepoName)
+		}, nil
+	case SortByResources:
+		return func(a, b AnalysisResult) int {
+			return cmp.Compare(b.Analysis.ResourceAnalysis.TotalResourceCount, a.Analysis.ResourceAnalysis.TotalResourceCount)
+		}, nil
+	case SortByUntagged:
+		return func(a, b AnalysisResult) int {
+			return cmp.Compare(len(b.Analysis.ResourceAnalysis.UntaggedResources), len(a.Analysis.ResourceAnalysis.UntaggedResources))
+		}, nil
+	case SortByScore:
+		return func(a, b AnalysisResult) int {
+			return cmp.Compare(complianceScore(a.Analysis), complianceScore(b.Analysis))
+		}, nil
+	case SortByComplexity:
+		return func(a, b AnalysisResult) int {
+			return cmp.Compare(b.Analysis.ComplexityScore, a.Analysis.ComplexityScore)
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid sort key %q (valid: %s)", key, strings.Join(validSortKeys, ", "))
+	}
+}
+
+func compareByOrgThenName(a, b AnalysisResult) int {
+	if order := cmp.Compare(a.Organization, b.Organization); order != 0 {
+		return order
+	}
+	return cmp.Compare(a.RepoName, b.RepoName)
+}
+
+// complianceScore is the percentage of resources carrying every required tag;
+// repositories without resources score 100.
+func complianceScore(analysis RepositoryAnalysis) float64 {
+	total := analysis.ResourceAnalysis.TotalResourceCount
+	if total == 0 {
+		return 100
+	}
+	untagged := min(len(analysis.ResourceAnalysis.UntaggedResources), total)
+	return float64(total-untagged) / float64(total) * 100
+}
+
 func (r *Reporter) generateGlobalSummary() GlobalSummary {
 	successfulResults := r.getSuccessfulResults()
 	backendSummary := r.aggregateBackends(successfulResults)
+	coverageByOrg := lo.MapValues(lo.GroupBy(lo.Filter(successfulResults, func(result AnalysisResult, _ int) bool {
+		return result.Organization != ""
+	}), func(result AnalysisResult) string {
+		return result.Organization
+	}), func(results []AnalysisResult, _ string) float64 {
+		return rollUpTagCoverage(results)
+	})
 
 	return GlobalSummary{
 		TotalReposScanned:    len(successfulResults),
+		EmptyRepos:           lo.CountBy(successfulResults, func(result AnalysisResult) bool { return result.Analysis.IsEmpty }),
 		GlobalBackendSummary: backendSummary,
+		Findings:             r.FindingsSummary(),
+		ModuleSourceTypes:    aggregateModuleSourceTypes(successfulResults),
+		ResourceTypes:        aggregateResourceTypeUsage(successfulResults),
+		TagCoveragePct:       rollUpTagCoverage(successfulResults),
+		TagCoverageByOrg:     coverageByOrg,
+	}
+}
+
+// rollUpTagCoverage weights each repository by its taggable resources, so
+// coverage is tagged resources over taggable resources across all results
+func rollUpTagCoverage(results []AnalysisResult) float64 {
+	taggable := lo.SumBy(results, func(result AnalysisResult) int {
+		return result.Analysis.ResourceAnalysis.TaggableResourceCount
+	})
+	untagged := lo.SumBy(results, func(result AnalysisResult) int {
+		resources := result.Analysis.ResourceAnalysis
+		return min(len(resources.UntaggedResources), resources.TaggableResourceCount)
+	})
+	return tagCoveragePct(taggable, untagged)
+}
+
+// aggregateResourceTypeUsage rolls up each repository's ResourceTypes into an
+// inventory sorted by total count, most used first, then by type
+func aggregateResourceTypeUsage(results []AnalysisResult) []ResourceTypeUsage {
+	usageByType := make(map[string]*ResourceTypeUsage)
+	for _, result := range results {
+		seen := make(map[string]bool)
+		for _, resourceType := range result.Analysis.ResourceAnalysis.ResourceTypes {
+			usage, exists := usageByType[resourceType.Type]
+			if !exists {
+				usage = &ResourceTypeUsage{Type: resourceType.Type}
+				usageByType[resourceType.Type] = usage
+			}
+			usage.TotalCount += resourceType.Count
+			if !seen[resourceType.Type] {
+				usage.RepoCount++
+				seen[resourceType.Type] = true
+			}
+		}
+	}
+
+	inventory := lo.Map(lo.Values(usageByType), func(usage *ResourceTypeUsage, _ int) ResourceTypeUsage { return *usage })
+	sort.Slice(inventory, func(i, j int) bool {
+		if inventory[i].TotalCount != inventory[j].TotalCount {
+			return inventory[i].TotalCount > inventory[j].TotalCount
+		}
+		return inventory[i].Type < inventory[j].Type
+	})
+	return inventory
+}
+
+// aggregateModuleSourceTypes rolls up each repository's SourceTypeBreakdown
+func aggregateModuleSourceTypes(results []AnalysisResult) map[string]int {
+	rollup := make(map[string]int)
+	for _, result := range results {
+		for sourceType, count := range result.Analysis.Modules.SourceTypeBreakdown {
+			rollup[sourceType] += count
+		}
 	}
+	return rollup
 }
 
 func (r *Reporter) getSuccessfulResults() []AnalysisResult {
@@ -75,6 +300,25 @@ func (r *Reporter) getSuccessfulResults() []AnalysisResult {
 	})
 }
 
+func (r *Reporter) getFailedRepositories() []FailedRepository {
+	return lo.FilterMap(r.results, func(result AnalysisResult, _ int) (FailedRepository, bool) {
+		if result.Error == nil {
+			return FailedRepository{}, false
+		}
+		// Results built outside the processing pipeline may not be classified yet
+		kind := result.ErrorKind
+		if kind == "" {
+			kind = classifyAnalysisError(result.Error)
+		}
+		return FailedRepository{
+			Repository:   result.RepoName,
+			Organization: result.Organization,
+			Error:        result.Error.Error(),
+			ErrorKind:    kind,
+		}, true
+	})
+}
+
 func (r *Reporter) aggregateBackends(results []AnalysisResult) GlobalBackendSummary {
 	backendMap := make(map[string]int)
 
@@ -121,14 +365,16 @@ func (r *Reporter) GenerateReport() ComprehensiveReport {
 		return RepositoryForJSON{
 			RepositoryAnalysis: result.Analysis,
 			Organization:       result.Organization,
+			Warnings:           result.Warnings,
 		}
 	})
 
 	globalSummary := r.generateGlobalSummary()
 
 	return ComprehensiveReport{
-		Repositories:  repositories,
-		GlobalSummary: globalSummary,
+		Repositories:       repositories,
+		FailedRepositories: r.getFailedRepositories(),
+		GlobalSummary:      globalSummary,
 	}
 }
 
@@ -201,11 +447,13 @@ func printRepositorySummaries(repositories []RepositoryAnalysis) {
 		repoName := extractRepoName(repo.RepositoryPath)
 		slog.Info("Repository summary",
 			"name", repoName,
+			"classification", repo.Classification,
 			"providers", repo.Providers.UniqueProviderCount,
 			"modules", repo.Modules.UniqueModuleCount,
 			"resources", repo.ResourceAnalysis.TotalResourceCount,
 			"variables", len(repo.VariableAnalysis.DefinedVariables),
-			"outputs", repo.OutputAnalysis.OutputCount)
+			"outputs", repo.OutputAnalysis.OutputCount,
+			"data_sources", repo.DataSources.TotalCount)
 	}
 }
 
@@ -280,6 +528,42 @@ func calculateTotalVariables(repositories []RepositoryAnalysis) int {
 	})
 }
 
+func countRepositoriesByClassification(repositories []RepositoryAnalysis, classification string) int {
+	return lo.CountBy(repositories, func(repo RepositoryAnalysis) bool {
+		return repo.Classification == classification
+	})
+}
+
+func calculateTotalDataSources(repositories []RepositoryAnalysis) int {
+	return sumRepoProperty(repositories, func(repo RepositoryAnalysis) int {
+		return repo.DataSources.TotalCount
+	})
+}
+
+func calculateTotalFileErrors(repositories []RepositoryAnalysis) int {
+	return sumRepoProperty(repositories, func(repo RepositoryAnalysis) int {
+		return len(repo.FileErrors)
+	})
+}
+
+func calculateTotalProvisioners(repositories []RepositoryAnalysis) int {
+	return sumRepoProperty(repositories, func(repo RepositoryAnalysis) int {
+		return len(repo.ResourceAnalysis.Provisioners)
+	})
+}
+
+func calculateTotalDeprecatedResources(repositories []RepositoryAnalysis) int {
+	return sumRepoProperty(repositories, func(repo RepositoryAnalysis) int {
+		return len(repo.ResourceAnalysis.DeprecatedResources)
+	})
+}
+
+func calculateTotalHardcodedRegions(repositories []RepositoryAnalysis) int {
+	return sumRepoProperty(repositories, func(repo RepositoryAnalysis) int {
+		return len(repo.ResourceAnalysis.HardcodedRegionFindings)
+	})
+}
+
 func calculateTotalOutputs(repositories []RepositoryAnalysis) int {
 	return sumRepoProperty(repositories, func(repo RepositoryAnalysis) int {
 		return repo.OutputAnalysis.OutputCount
@@ -307,14 +591,14 @@ func (r *Reporter) ExportCSV(filename string) error {
 	successfulResults := r.getSuccessfulResults()
 	
 	csvLines := []string{
-		"Repository,Path,BackendType,BackendRegion,Providers,Modules,Resources,Variables,Outputs,UntaggedResources",
+		"Repository,Path,BackendType,BackendRegion,Providers,Modules,Resources,Variables,Outputs,UntaggedResources,DataSources,Classification,Provisioners,FileErrors,ErrorKind",
 	}
 
 	for _, result := range successfulResults {
 		analysis := result.Analysis
 		repoName := extractRepoName(analysis.RepositoryPath)
 		
-		csvLines = append(csvLines, fmt.Sprintf("%s,%s,%s,%s,%d,%d,%d,%d,%d,%d",
+		csvLines = append(csvLines, fmt.Sprintf("%s,%s,%s,%s,%d,%d,%d,%d,%d,%d,%d,%s,%d,%d,",
 			repoName,
 			analysis.RepositoryPath,
 			getBackendType(analysis.BackendConfig),
@@ -325,9 +609,18 @@ func (r *Reporter) ExportCSV(filename string) error {
 			len(analysis.VariableAnalysis.DefinedVariables),
 			analysis.OutputAnalysis.OutputCount,
 			len(analysis.ResourceAnalysis.UntaggedResources),
+			analysis.DataSources.TotalCount,
+			analysis.Classification,
+			len(analysis.ResourceAnalysis.Provisioners),
+			len(analysis.FileErrors),
 		))
 	}
 
+	// Failed repositories have no analysis, so only the name and ErrorKind are filled in
+	for _, failed := range r.getFailedRepositories() {
+		csvLines = append(csvLines, failed.Repository+strings.Repeat(",", 14)+failed.ErrorKind)
+	}
+
 	csvContent := strings.Join(csvLines, "\n")
 	_, err := script.Echo(csvContent).WriteFile(filename)
 	if err != nil {
@@ -338,6 +631,134 @@ func (r *Reporter) ExportCSV(filename string) error {
 	return nil
 }
 
+// resourceCSVFlushInterval is the number of rows written between flushes
+const resourceCSVFlushInterval = 1000
+
+var resourceCSVHeader = []string{"Repository", "Organization", "ResourceType", "Count", "Untagged"}
+
+// ExportResourceCSV writes one row per resource type in each repository.
+// Rows are streamed to the file instead of being built up in memory, so
+// large organizations can be exported with bounded memory.
+func (r *Reporter) ExportResourceCSV(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create resource CSV file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	rows, err := writeResourceCSV(file, r.getSuccessfulResults())
+	if err != nil {
+		return fmt.Errorf("failed to write resource CSV file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close resource CSV file: %w", err)
+	}
+
+	slog.Info("Resource CSV report exported", "file", filename, "rows", rows)
+	return nil
+}
+
+// writeResourceCSV streams resource rows to w and returns the number written
+func writeResourceCSV(w io.Writer, results []AnalysisResult) (int, error) {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(resourceCSVHeader); err != nil {
+		return 0, err
+	}
+
+	rows := 0
+	for _, result := range results {
+		resources := result.Analysis.ResourceAnalysis
+		untaggedByType := lo.CountValuesBy(resources.UntaggedResources, func(resource UntaggedResource) string {
+			return resource.ResourceType
+		})
+		repoName := extractRepoName(result.Analysis.RepositoryPath)
+
+		for _, resourceType := range resources.ResourceTypes {
+			record := []string{
+				repoName,
+				result.Organization,
+				resourceType.Type,
+				strconv.Itoa(resourceType.Count),
+				strconv.Itoa(untaggedByType[resourceType.Type]),
+			}
+			if err := writer.Write(record); err != nil {
+				return rows, err
+			}
+
+			rows++
+			if rows%resourceCSVFlushInterval == 0 {
+				writer.Flush()
+				if err := writer.Error(); err != nil {
+					return rows, err
+				}
+			}
+		}
+	}
+
+	writer.Flush()
+	return rows, writer.Error()
+}
+
+var untaggedCSVHeader = []string{"Organization", "Repository", "ResourceType", "ResourceName", "MissingTags"}
+
+// ExportUntaggedCSV writes one row per untagged resource across all
+// repositories, streamed like ExportResourceCSV
+func (r *Reporter) ExportUntaggedCSV(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create untagged CSV file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	rows, err := writeUntaggedCSV(file, r.getSuccessfulResults())
+	if err != nil {
+		return fmt.Errorf("failed to write untagged CSV file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close untagged CSV file: %w", err)
+	}
+
+	slog.Info("Untagged resources CSV exported", "file", filename, "rows", rows)
+	return nil
+}
+
+// writeUntaggedCSV streams untagged resource rows to w, joining missing tags
+// with ";" so the column stays a single CSV field
+func writeUntaggedCSV(w io.Writer, results []AnalysisResult) (int, error) {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(untaggedCSVHeader); err != nil {
+		return 0, err
+	}
+
+	rows := 0
+	for _, result := range results {
+		repoName := extractRepoName(result.Analysis.RepositoryPath)
+		for _, resource := range result.Analysis.ResourceAnalysis.UntaggedResources {
+			record := []string{
+				result.Organization,
+				repoName,
+				resource.ResourceType,
+				resource.Name,
+				strings.Join(resource.MissingTags, ";"),
+			}
+			if err := writer.Write(record); err != nil {
+				return rows, err
+			}
+
+			rows++
+			if rows%resourceCSVFlushInterval == 0 {
+				writer.Flush()
+				if err := writer.Error(); err != nil {
+					return rows, err
+				}
+			}
+		}
+	}
+
+	writer.Flush()
+	return rows, writer.Error()
+}
+
 func (r *Reporter) ExportMarkdown(filename string) error {
 	markdownContent := r.generateMarkdownContent()
 	
@@ -364,10 +785,27 @@ func (r *Reporter) generateMarkdownContent() string {
 	r.appendReportHeader(&markdownBuilder)
 	r.appendExecutiveSummary(&markdownBuilder, &report, skippedRepos)
 	r.appendBackendSummary(&markdownBuilder, &report)
+	r.appendBackendSecurityWarnings(&markdownBuilder, &report)
+	r.appendBackendWorkspaces(&markdownBuilder, &report)
 	r.appendRepositoryDetails(&markdownBuilder, &report)
+	r.appendMostComplexRepositories(&markdownBuilder, &report)
 	r.appendSkippedRepositories(&markdownBuilder, skippedRepos)
+	r.appendFileErrors(&markdownBuilder, &report)
+	r.appendResourceTypeInventory(&markdownBuilder, &report)
 	r.appendProviderDetails(&markdownBuilder, &report)
+	r.appendProviderVersionDrift(&markdownBuilder)
+	r.appendOutdatedProviders(&markdownBuilder)
+	r.appendProviderVersionIssues(&markdownBuilder, &report)
+	r.appendUnpinnedModules(&markdownBuilder, &report)
+	r.appendDynamicModules(&markdownBuilder, &report)
+	r.appendDataSourceDetails(&markdownBuilder, &report)
+	r.appendMovedBlocks(&markdownBuilder, &report)
+	r.appendProvisionerUsage(&markdownBuilder, &report)
+	r.appendDeprecatedResources(&markdownBuilder, &report)
+	r.appendHardcodedRegions(&markdownBuilder, &report)
 	r.appendUntaggedResourcesSummary(&markdownBuilder, &report)
+	r.appendComplianceViolations(&markdownBuilder, &report)
+	r.appendSecretFindings(&markdownBuilder, &report)
 	r.appendReportFooter(&markdownBuilder)
 	
 	return markdownBuilder.String()
@@ -401,6 +839,30 @@ func (r *Reporter) appendExecutiveSummary(builder *strings.Builder, report *Comp
 		calculateTotalVariables(repositories))
 	fmt.Fprintf(builder, "- **Total outputs found**: %d\n", 
 		calculateTotalOutputs(repositories))
+	fmt.Fprintf(builder, "- **Total data sources found**: %d\n",
+		calculateTotalDataSources(repositories))
+	fmt.Fprintf(builder, "- **Provisioner blocks found**: %d\n",
+		calculateTotalProvisioners(repositories))
+	fmt.Fprintf(builder, "- **Deprecated resources found**: %d\n",
+		calculateTotalDeprecatedResources(repositories))
+	fmt.Fprintf(builder, "- **Hardcoded regions and availability zones**: %d\n",
+		calculateTotalHardcodedRegions(repositories))
+	fmt.Fprintf(builder, "- **Files that could not be analyzed**: %d\n",
+		calculateTotalFileErrors(repositories))
+	fmt.Fprintf(builder, "- **Read-only repositories (data sources/outputs only)**: %d\n",
+		countRepositoriesByClassification(repositories, ClassificationReadOnly))
+	fmt.Fprintf(builder, "- **Empty repositories**: %d\n",
+		countRepositoriesByClassification(repositories, ClassificationEmpty))
+	fmt.Fprintf(builder, "- **Repositories without Terraform files**: %d\n",
+		report.GlobalSummary.EmptyRepos)
+	fmt.Fprintf(builder, "- **Tag coverage**: %.1f%%\n",
+		report.GlobalSummary.TagCoveragePct)
+	fmt.Fprintf(builder, "- **Total findings**: %d\n",
+		report.GlobalSummary.Findings.TotalFindings)
+	if report.GlobalSummary.Findings.FindingsTruncatedGlobally {
+		fmt.Fprintf(builder, "- **Findings omitted from detail (--max-total-findings)**: %d\n",
+			report.GlobalSummary.Findings.OmittedFindings)
+	}
 	builder.WriteString("\n")
 }
 
@@ -423,14 +885,77 @@ func (r *Reporter) appendBackendSummary(builder *strings.Builder, report *Compre
 	builder.WriteString("\n")
 }
 
+func (r *Reporter) appendBackendSecurityWarnings(builder *strings.Builder, report *ComprehensiveReport) {
+	type backendWarning struct {
+		repoName string
+		message  string
+	}
+	var warnings []backendWarning
+	for _, repo := range report.Repositories {
+		for _, message := range backendSecurityWarnings(repo.BackendConfig) {
+			warnings = append(warnings, backendWarning{repoName: extractRepoName(repo.RepositoryPath), message: message})
+		}
+	}
+	if len(warnings) == 0 {
+		return
+	}
+
+	builder.WriteString("## Backend Security Warnings\n\n")
+	fmt.Fprintf(builder, "Found **%d** insecure S3 backend settings.\n\n", len(warnings))
+	builder.WriteString("| Repository | Warning |\n")
+	builder.WriteString("|------------|---------|\n")
+	for _, warning := range warnings {
+		fmt.Fprintf(builder, "| %s | %s |\n", warning.repoName, warning.message)
+	}
+	builder.WriteString("\n")
+}
+
+func (r *Reporter) appendBackendWorkspaces(builder *strings.Builder, report *ComprehensiveReport) {
+	repositories := lo.Filter(report.Repositories, func(repo RepositoryForJSON, _ int) bool {
+		return repo.BackendConfig != nil && repo.BackendConfig.Workspace != nil
+	})
+	if len(repositories) == 0 {
+		return
+	}
+
+	builder.WriteString("## HCP Terraform Workspaces\n\n")
+	builder.WriteString("| Repository | Backend | Organization | Workspaces |\n")
+	builder.WriteString("|------------|---------|--------------|------------|\n")
+	for _, repo := range repositories {
+		fmt.Fprintf(builder, "| %s | %s | %s | %s |\n",
+			extractRepoName(repo.RepositoryPath), getBackendType(repo.BackendConfig),
+			repo.BackendConfig.Workspace.Organization, describeBackendWorkspaces(*repo.BackendConfig.Workspace))
+	}
+	builder.WriteString("\n")
+}
+
+// describeBackendWorkspaces summarizes how workspaces are selected, e.g.
+// "network", "prefix app-" or "tags env=prod, team"
+func describeBackendWorkspaces(workspace BackendWorkspace) string {
+	var parts []string
+	if workspace.Name != "" {
+		parts = append(parts, workspace.Name)
+	}
+	if workspace.Prefix != "" {
+		parts = append(parts, "prefix "+workspace.Prefix)
+	}
+	if len(workspace.Tags) > 0 {
+		parts = append(parts, "tags "+strings.Join(workspace.Tags, ", "))
+	}
+	if workspace.Project != "" {
+		parts = append(parts, "project "+workspace.Project)
+	}
+	return strings.Join(parts, "; ")
+}
+
 func (r *Reporter) appendRepositoryDetails(builder *strings.Builder, report *ComprehensiveReport) {
 	if len(report.Repositories) == 0 {
 		return
 	}
 	
 	builder.WriteString("## Repository Analysis Details\n\n")
-	builder.WriteString("| Repository | Providers | Modules | Resources | Variables | Outputs | Backend | Region |\n")
-	builder.WriteString("|------------|-----------|---------|-----------|-----------|---------|---------|--------|\n")
+	builder.WriteString("| Repository | Classification | Providers | Modules | Resources | Data Sources | Variables | Outputs | Backend | Region |\n")
+	builder.WriteString("|------------|----------------|-----------|---------|-----------|--------------|-----------|---------|---------|--------|\n")
 	
 	for _, repo := range report.Repositories {
 		r.appendRepositoryRow(builder, repo.RepositoryAnalysis)
@@ -443,11 +968,13 @@ func (r *Reporter) appendRepositoryRow(builder *strings.Builder, repo Repository
 	backendType := getBackendType(repo.BackendConfig)
 	backendRegion := getBackendRegion(repo.BackendConfig)
 	
-	fmt.Fprintf(builder, "| %s | %d | %d | %d | %d | %d | %s | %s |\n",
+	fmt.Fprintf(builder, "| %s | %s | %d | %d | %d | %d | %d | %d | %s | %s |\n",
 		repoName,
+		repo.Classification,
 		repo.Providers.UniqueProviderCount,
 		repo.Modules.TotalModuleCalls,
 		repo.ResourceAnalysis.TotalResourceCount,
+		repo.DataSources.TotalCount,
 		len(repo.VariableAnalysis.DefinedVariables),
 		repo.OutputAnalysis.OutputCount,
 		backendType,
@@ -468,6 +995,21 @@ func (r *Reporter) appendSkippedRepositories(builder *strings.Builder, skippedRe
 	builder.WriteString("\n")
 }
 
+func (r *Reporter) appendResourceTypeInventory(builder *strings.Builder, report *ComprehensiveReport) {
+	inventory := report.GlobalSummary.ResourceTypes
+	if len(inventory) == 0 {
+		return
+	}
+
+	builder.WriteString("## Resource Type Inventory\n\n")
+	builder.WriteString("| Resource Type | Total Count | Repository Count |\n")
+	builder.WriteString("|---------------|-------------|------------------|\n")
+	for _, usage := range inventory {
+		fmt.Fprintf(builder, "| %s | %d | %d |\n", usage.Type, usage.TotalCount, usage.RepoCount)
+	}
+	builder.WriteString("\n")
+}
+
 func (r *Reporter) appendProviderDetails(builder *strings.Builder, report *ComprehensiveReport) {
 	if len(report.Repositories) == 0 {
 		return
@@ -492,6 +1034,303 @@ func (r *Reporter) appendProviderDetails(builder *strings.Builder, report *Compr
 	}
 }
 
+func (r *Reporter) appendOutdatedProviders(builder *strings.Builder) {
+	outdated := r.OutdatedProviders()
+	if len(outdated) == 0 {
+		return
+	}
+
+	builder.WriteString("## Outdated Providers\n\n")
+	builder.WriteString("| Provider | Version Constraint | Latest Version | Versions Behind | Repository Count |\n")
+	builder.WriteString("|----------|--------------------|----------------|-----------------|------------------|\n")
+	for _, provider := range outdated {
+		fmt.Fprintf(builder, "| %s | %s | %s | %d | %d |\n",
+			provider.Source, provider.Version, provider.LatestVersion, provider.VersionsBehind, provider.RepositoryCount)
+	}
+	builder.WriteString("\n")
+}
+
+func (r *Reporter) appendProviderVersionDrift(builder *strings.Builder) {
+	drift := r.ProviderVersionDrift()
+	if len(drift) == 0 {
+		return
+	}
+
+	builder.WriteString("## Provider Version Drift\n\n")
+	builder.WriteString("| Provider | Version Constraint | Repository Count |\n")
+	builder.WriteString("|----------|--------------------|------------------|\n")
+	for _, provider := range drift {
+		for _, constraint := range provider.Constraints {
+			fmt.Fprintf(builder, "| %s | %s | %d |\n", provider.Source, constraint.Version, constraint.RepositoryCount)
+		}
+	}
+	builder.WriteString("\n")
+}
+
+func (r *Reporter) appendProviderVersionIssues(builder *strings.Builder, report *ComprehensiveReport) {
+	issueCount := sumRepoProperty(lo.Map(report.Repositories, func(repo RepositoryForJSON, _ int) RepositoryAnalysis {
+		return repo.RepositoryAnalysis
+	}), func(repo RepositoryAnalysis) int {
+		return len(repo.Providers.VersionIssues)
+	})
+	if issueCount == 0 {
+		return
+	}
+
+	builder.WriteString("## Provider Version Constraints\n\n")
+	fmt.Fprintf(builder, "Found **%d** providers without a safe version constraint; prefer `~>` pinning.\n\n", issueCount)
+	builder.WriteString("| Repository | Provider | Version Constraint | Classification | Issue |\n")
+	builder.WriteString("|------------|----------|--------------------|----------------|-------|\n")
+	for _, repo := range report.Repositories {
+		for _, issue := range repo.Providers.VersionIssues {
+			fmt.Fprintf(builder, "| %s | %s | %s | %s | %s |\n",
+				extractRepoName(repo.RepositoryPath), issue.Source, issue.Version, issue.Classification, issue.Reason)
+		}
+	}
+	builder.WriteString("\n")
+}
+
+func (r *Reporter) appendUnpinnedModules(builder *strings.Builder, report *ComprehensiveReport) {
+	unpinnedCount := sumRepoProperty(lo.Map(report.Repositories, func(repo RepositoryForJSON, _ int) RepositoryAnalysis {
+		return repo.RepositoryAnalysis
+	}), func(repo RepositoryAnalysis) int {
+		return len(repo.Modules.UnpinnedModules)
+	})
+	if unpinnedCount == 0 {
+		return
+	}
+
+	builder.WriteString("## Unpinned Modules\n\n")
+	fmt.Fprintf(builder, "Found **%d** registry modules without a `version` or git modules without a `ref`.\n\n", unpinnedCount)
+	builder.WriteString("| Repository | Module Source | Kind | Calls |\n")
+	builder.WriteString("|------------|---------------|------|-------|\n")
+	for _, repo := range report.Repositories {
+		for _, module := range repo.Modules.UnpinnedModules {
+			fmt.Fprintf(builder, "| %s | %s | %s | %s |\n",
+				extractRepoName(repo.RepositoryPath), module.Source, module.SourceKind, moduleCountLabel(module))
+		}
+	}
+	builder.WriteString("\n")
+}
+
+// moduleCountLabel marks counts that are a lower bound, e.g. "1+" for a
+// module called with for_each over a variable
+func moduleCountLabel(module ModuleDetail) string {
+	if module.Dynamic {
+		return strconv.Itoa(module.Count) + "+"
+	}
+	return strconv.Itoa(module.Count)
+}
+
+func (r *Reporter) appendDynamicModules(builder *strings.Builder, report *ComprehensiveReport) {
+	dynamicCount := sumRepoProperty(lo.Map(report.Repositories, func(repo RepositoryForJSON, _ int) RepositoryAnalysis {
+		return repo.RepositoryAnalysis
+	}), func(repo RepositoryAnalysis) int {
+		return lo.CountBy(repo.Modules.UniqueModules, func(module ModuleDetail) bool { return module.Dynamic })
+	})
+	if dynamicCount == 0 {
+		return
+	}
+
+	builder.WriteString("## Dynamic Module Calls\n\n")
+	fmt.Fprintf(builder, "Found **%d** modules called with a `count` or `for_each` that cannot be resolved statically; their instance counts are lower bounds.\n\n", dynamicCount)
+	builder.WriteString("| Repository | Module Source | Kind | Instances |\n")
+	builder.WriteString("|------------|---------------|------|-----------|\n")
+	for _, repo := range report.Repositories {
+		for _, module := range repo.Modules.UniqueModules {
+			if module.Dynamic {
+				fmt.Fprintf(builder, "| %s | %s | %s | %s |\n",
+					extractRepoName(repo.RepositoryPath), module.Source, module.SourceKind, moduleCountLabel(module))
+			}
+		}
+	}
+	builder.WriteString("\n")
+}
+
+func (r *Reporter) appendDataSourceDetails(builder *strings.Builder, report *ComprehensiveReport) {
+	usage := make(map[string]int)
+	for _, repo := range report.Repositories {
+		for _, dataSourceType := range repo.DataSources.DataSourceTypes {
+			usage[dataSourceType.Type] += dataSourceType.Count
+		}
+	}
+	if len(usage) == 0 {
+		return
+	}
+
+	builder.WriteString("## Data Source Usage Details\n\n")
+	builder.WriteString("| Data Source Type | Count |\n")
+	builder.WriteString("|------------------|-------|\n")
+	types := lo.Keys(usage)
+	sort.Strings(types)
+	for _, dataSourceType := range types {
+		fmt.Fprintf(builder, "| %s | %d |\n", dataSourceType, usage[dataSourceType])
+	}
+	builder.WriteString("\n")
+}
+
+func (r *Reporter) appendMovedBlocks(builder *strings.Builder, report *ComprehensiveReport) {
+	movedCount := sumRepoProperty(lo.Map(report.Repositories, func(repo RepositoryForJSON, _ int) RepositoryAnalysis {
+		return repo.RepositoryAnalysis
+	}), func(repo RepositoryAnalysis) int {
+		return repo.MovedAnalysis.TotalCount
+	})
+	if movedCount == 0 {
+		return
+	}
+
+	builder.WriteString("## Moved Blocks\n\n")
+	fmt.Fprintf(builder, "Found **%d** moved blocks recording in-progress refactors.\n\n", movedCount)
+
+	builder.WriteString("| Repository | From | To | Location |\n")
+	builder.WriteString("|------------|------|----|----------|\n")
+
+	for _, repo := range report.Repositories {
+		repoName := extractRepoName(repo.RepositoryPath)
+		for _, moved := range repo.MovedAnalysis.MovedBlocks {
+			fmt.Fprintf(builder, "| %s | `%s` | `%s` | %s |\n",
+				repoName, moved.From, moved.To, sourceLocation(moved.File, moved.Line))
+		}
+	}
+	builder.WriteString("\n")
+}
+
+// sourceLocation renders file:line, or only the file when the line is unknown
+func sourceLocation(file string, line int) string {
+	if line == 0 {
+		return file
+	}
+	return fmt.Sprintf("%s:%d", file, line)
+}
+
+func (r *Reporter) appendFileErrors(builder *strings.Builder, report *ComprehensiveReport) {
+	fileErrorCount := calculateTotalFileErrors(lo.Map(report.Repositories, func(repo RepositoryForJSON, _ int) RepositoryAnalysis {
+		return repo.RepositoryAnalysis
+	}))
+	if fileErrorCount == 0 {
+		return
+	}
+
+	builder.WriteString("## Files That Could Not Be Analyzed\n\n")
+	fmt.Fprintf(builder, "**%d** files were skipped, so these repositories may have findings that are not reported.\n\n", fileErrorCount)
+
+	builder.WriteString("| Repository | File | Reason |\n")
+	builder.WriteString("|------------|------|--------|\n")
+
+	for _, repo := range report.Repositories {
+		repoName := extractRepoName(repo.RepositoryPath)
+		for _, fileErr := range repo.FileErrors {
+			fmt.Fprintf(builder, "| %s | %s | %s |\n", repoName, fileErr.Path, strings.ReplaceAll(fileErr.Reason, "|", "\\|"))
+		}
+	}
+	builder.WriteString("\n")
+}
+
+func (r *Reporter) appendProvisionerUsage(builder *strings.Builder, report *ComprehensiveReport) {
+	provisionerCount := calculateTotalProvisioners(lo.Map(report.Repositories, func(repo RepositoryForJSON, _ int) RepositoryAnalysis {
+		return repo.RepositoryAnalysis
+	}))
+	if provisionerCount == 0 {
+		return
+	}
+
+	builder.WriteString("## Provisioner Usage\n\n")
+	fmt.Fprintf(builder, "Found **%d** provisioner blocks. Provisioners are a last resort; prefer provider resources or configuration management.\n\n", provisionerCount)
+
+	builder.WriteString("| Repository | Resource | Provisioner |\n")
+	builder.WriteString("|------------|----------|-------------|\n")
+
+	for _, repo := range report.Repositories {
+		repoName := extractRepoName(repo.RepositoryPath)
+		for _, usage := range repo.ResourceAnalysis.Provisioners {
+			fmt.Fprintf(builder, "| %s | %s.%s | %s |\n", repoName, usage.ResourceType, usage.Name, usage.Provisioner)
+		}
+	}
+	builder.WriteString("\n")
+}
+
+// MostComplexRepositoriesLimit caps the "Most Complex Repositories" table
+const MostComplexRepositoriesLimit = 5
+
+// mostComplexRepositories returns up to limit repositories with a non-zero
+// complexity score, highest first; ties keep the report order
+func mostComplexRepositories(repositories []RepositoryForJSON, limit int) []RepositoryForJSON {
+	scored := lo.Filter(repositories, func(repo RepositoryForJSON, _ int) bool {
+		return repo.ComplexityScore > 0
+	})
+	slices.SortStableFunc(scored, func(a, b RepositoryForJSON) int {
+		return cmp.Compare(b.ComplexityScore, a.ComplexityScore)
+	})
+	return scored[:min(limit, len(scored))]
+}
+
+func (r *Reporter) appendMostComplexRepositories(builder *strings.Builder, report *ComprehensiveReport) {
+	top := mostComplexRepositories(report.Repositories, MostComplexRepositoriesLimit)
+	if len(top) == 0 {
+		return
+	}
+
+	builder.WriteString("## Most Complex Repositories\n\n")
+	builder.WriteString("| Repository | Complexity | Resources | Modules | Providers |\n")
+	builder.WriteString("|------------|------------|-----------|---------|-----------|\n")
+
+	for _, repo := range top {
+		fmt.Fprintf(builder, "| %s | %d | %d | %d | %d |\n", extractRepoName(repo.RepositoryPath), repo.ComplexityScore,
+			repo.ResourceAnalysis.TotalResourceCount, repo.Modules.TotalModuleCalls, repo.Providers.UniqueProviderCount)
+	}
+	builder.WriteString("\n")
+}
+
+func (r *Reporter) appendDeprecatedResources(builder *strings.Builder, report *ComprehensiveReport) {
+	deprecatedCount := calculateTotalDeprecatedResources(lo.Map(report.Repositories, func(repo RepositoryForJSON, _ int) RepositoryAnalysis {
+		return repo.RepositoryAnalysis
+	}))
+	if deprecatedCount == 0 {
+		return
+	}
+
+	builder.WriteString("## Deprecated Resources\n\n")
+	fmt.Fprintf(builder, "Found **%d** resources using deprecated types.\n\n", deprecatedCount)
+
+	builder.WriteString("| Repository | Resource | Recommended |\n")
+	builder.WriteString("|------------|----------|-------------|\n")
+
+	for _, repo := range report.Repositories {
+		repoName := extractRepoName(repo.RepositoryPath)
+		for _, resource := range repo.ResourceAnalysis.DeprecatedResources {
+			recommended := resource.Recommended
+			if recommended == "" {
+				recommended = "-"
+			}
+			fmt.Fprintf(builder, "| %s | %s.%s | %s |\n", repoName, resource.Type, resource.Name, recommended)
+		}
+	}
+	builder.WriteString("\n")
+}
+
+func (r *Reporter) appendHardcodedRegions(builder *strings.Builder, report *ComprehensiveReport) {
+	regionCount := calculateTotalHardcodedRegions(lo.Map(report.Repositories, func(repo RepositoryForJSON, _ int) RepositoryAnalysis {
+		return repo.RepositoryAnalysis
+	}))
+	if regionCount == 0 {
+		return
+	}
+
+	builder.WriteString("## Hardcoded Regions\n\n")
+	fmt.Fprintf(builder, "Found **%d** resource attributes with a literal region or availability zone. Derive them from variables or data sources to keep configurations portable.\n\n", regionCount)
+
+	builder.WriteString("| Repository | Resource | Attribute | Value |\n")
+	builder.WriteString("|------------|----------|-----------|-------|\n")
+
+	for _, repo := range report.Repositories {
+		repoName := extractRepoName(repo.RepositoryPath)
+		for _, finding := range repo.ResourceAnalysis.HardcodedRegionFindings {
+			fmt.Fprintf(builder, "| %s | %s.%s | %s | %s |\n", repoName, finding.ResourceType, finding.Name, finding.Attribute, finding.Value)
+		}
+	}
+	builder.WriteString("\n")
+}
+
 func (r *Reporter) appendUntaggedResourcesSummary(builder *strings.Builder, report *ComprehensiveReport) {
 	repositories := lo.Map(report.Repositories, func(repo RepositoryForJSON, _ int) RepositoryAnalysis {
 		return repo.RepositoryAnalysis
@@ -503,17 +1342,82 @@ func (r *Reporter) appendUntaggedResourcesSummary(builder *strings.Builder, repo
 	
 	builder.WriteString("## Resource Tagging Compliance\n\n")
 	fmt.Fprintf(builder, "Found **%d** resources missing mandatory tags (%s).\n\n",
-		untaggedResourcesCount, strings.Join(mandatoryTags, ", "))
+		untaggedResourcesCount, strings.Join(r.requiredTags(), ", "))
+	fmt.Fprintf(builder, "Tag coverage is **%.1f%%** of taggable resources.\n\n",
+		report.GlobalSummary.TagCoveragePct)
+	
+	if len(report.GlobalSummary.TagCoverageByOrg) > 0 {
+		builder.WriteString("### Tag Coverage by Organization\n\n")
+		builder.WriteString("| Organization | Tag Coverage |\n")
+		builder.WriteString("|--------------|--------------|\n")
+		for _, org := range slices.Sorted(maps.Keys(report.GlobalSummary.TagCoverageByOrg)) {
+			fmt.Fprintf(builder, "| %s | %.1f%% |\n", org, report.GlobalSummary.TagCoverageByOrg[org])
+		}
+		builder.WriteString("\n")
+	}
 	
 	builder.WriteString("### Repositories with Untagged Resources\n\n")
-	builder.WriteString("| Repository | Untagged Resources |\n")
-	builder.WriteString("|------------|--------------------|\n")
+	builder.WriteString("| Repository | Untagged Resources | Tag Coverage |\n")
+	builder.WriteString("|------------|--------------------|--------------|\n")
 	
 	for _, repo := range repositories {
 		if len(repo.ResourceAnalysis.UntaggedResources) > 0 {
 			repoName := extractRepoName(repo.RepositoryPath)
-			fmt.Fprintf(builder, "| %s | %d |\n",
-				repoName, len(repo.ResourceAnalysis.UntaggedResources))
+			fmt.Fprintf(builder, "| %s | %d | %.1f%% |\n",
+				repoName, len(repo.ResourceAnalysis.UntaggedResources), repo.ResourceAnalysis.TagCoveragePct)
+		}
+	}
+	builder.WriteString("\n")
+}
+
+func (r *Reporter) appendComplianceViolations(builder *strings.Builder, report *ComprehensiveReport) {
+	violationCount := sumRepoProperty(lo.Map(report.Repositories, func(repo RepositoryForJSON, _ int) RepositoryAnalysis {
+		return repo.RepositoryAnalysis
+	}), func(repo RepositoryAnalysis) int {
+		return len(repo.ComplianceViolations)
+	})
+	if violationCount == 0 {
+		return
+	}
+
+	builder.WriteString("## Compliance Policy Violations\n\n")
+	fmt.Fprintf(builder, "Found **%d** compliance policy violations.\n\n", violationCount)
+
+	builder.WriteString("| Repository | Policy | Subject | Details |\n")
+	builder.WriteString("|------------|--------|---------|---------|\n")
+
+	for _, repo := range report.Repositories {
+		repoName := extractRepoName(repo.RepositoryPath)
+		for _, violation := range repo.ComplianceViolations {
+			fmt.Fprintf(builder, "| %s | %s | %s | %s |\n",
+				repoName, violation.Policy, violation.Subject, violation.Message)
+		}
+	}
+	builder.WriteString("\n")
+}
+
+func (r *Reporter) appendSecretFindings(builder *strings.Builder, report *ComprehensiveReport) {
+	findingCount := sumRepoProperty(lo.Map(report.Repositories, func(repo RepositoryForJSON, _ int) RepositoryAnalysis {
+		return repo.RepositoryAnalysis
+	}), func(repo RepositoryAnalysis) int {
+		return len(repo.SecretFindings)
+	})
+	if findingCount == 0 {
+		return
+	}
+
+	builder.WriteString("## Potential Embedded Secrets\n\n")
+	fmt.Fprintf(builder, "Found **%d** potential secrets in resource attributes.\n\n", findingCount)
+
+	builder.WriteString("| Repository | Resource | Attribute | Location | Rule | Match |\n")
+	builder.WriteString("|------------|----------|-----------|----------|------|-------|\n")
+
+	for _, repo := range report.Repositories {
+		repoName := extractRepoName(repo.RepositoryPath)
+		for _, finding := range repo.SecretFindings {
+			fmt.Fprintf(builder, "| %s | %s.%s | %s | %s | %s | %s |\n",
+				repoName, finding.ResourceType, finding.ResourceName, finding.Attribute,
+				sourceLocation(finding.File, finding.Line), finding.Rule, finding.Match)
 		}
 	}
 	builder.WriteString("\n")
@@ -546,7 +1450,7 @@ func (r *Reporter) aggregateProviderUsage(repositories []RepositoryAnalysis) []P
 	
 	for _, repo := range repositories {
 		for _, provider := range repo.Providers.ProviderDetails {
-			key := fmt.Sprintf("%s@%s", provider.Source, provider.Version)
+			key := fmt.Sprintf("%s@%s", provider.Source, normalizeVersionConstraint(provider.Version))
 			providerMap[key]++
 		}
 	}
@@ -566,6 +1470,208 @@ func (r *Reporter) aggregateProviderUsage(repositories []RepositoryAnalysis) []P
 	})
 }
 
+// ProviderInventoryEntry is one unique provider@version across all analyzed repositories
+type ProviderInventoryEntry struct {
+	Source          string `json:"source"`
+	Version         string `json:"version"`
+	RepositoryCount int    `json:"repository_count"`
+}
+
+func (r *Reporter) ProviderInventory() []ProviderInventoryEntry {
+	repositories := lo.Map(r.getSuccessfulResults(), func(result AnalysisResult, _ int) RepositoryAnalysis {
+		return result.Analysis
+	})
+
+	inventory := lo.Map(r.aggregateProviderUsage(repositories), func(usage ProviderUsage, _ int) ProviderInventoryEntry {
+		return ProviderInventoryEntry{
+			Source:          usage.Source,
+			Version:         usage.Version,
+			RepositoryCount: usage.Count,
+		}
+	})
+
+	sort.Slice(inventory, func(i, j int) bool {
+		if inventory[i].Source != inventory[j].Source {
+			return inventory[i].Source < inventory[j].Source
+		}
+		return inventory[i].Version < inventory[j].Version
+	})
+	return inventory
+}
+
+// OutdatedProvider is a provider constraint that excludes newer registry releases, set by --check-latest
+type OutdatedProvider struct {
+	Source          string `json:"source"`
+	Version         string `json:"version"`
+	LatestVersion   string `json:"latest_version"`
+	VersionsBehind  int    `json:"versions_behind"`
+	RepositoryCount int    `json:"repository_count"`
+}
+
+// OutdatedProviders lists provider constraints behind the latest registry
+// release, most releases behind first
+func (r *Reporter) OutdatedProviders() []OutdatedProvider {
+	byKey := make(map[string]*OutdatedProvider)
+	for _, result := range r.getSuccessfulResults() {
+		for _, provider := range result.Analysis.Providers.ProviderDetails {
+			if provider.VersionsBehind == 0 {
+				continue
+			}
+			key := provider.Source + "@" + provider.Version
+			if entry, exists := byKey[key]; exists {
+				entry.RepositoryCount++
+				continue
+			}
+			byKey[key] = &OutdatedProvider{
+				Source:          provider.Source,
+				Version:         provider.Version,
+				LatestVersion:   provider.LatestVersion,
+				VersionsBehind:  provider.VersionsBehind,
+				RepositoryCount: 1,
+			}
+		}
+	}
+
+	outdated := lo.Map(lo.Values(byKey), func(entry *OutdatedProvider, _ int) OutdatedProvider { return *entry })
+	sort.Slice(outdated, func(i, j int) bool {
+		if outdated[i].VersionsBehind != outdated[j].VersionsBehind {
+			return outdated[i].VersionsBehind > outdated[j].VersionsBehind
+		}
+		if outdated[i].Source != outdated[j].Source {
+			return outdated[i].Source < outdated[j].Source
+		}
+		return outdated[i].Version < outdated[j].Version
+	})
+	return outdated
+}
+
+// ProviderVersionDrift is a provider pinned to different version constraints across repositories
+type ProviderVersionDrift struct {
+	Source      string                   `json:"source"`
+	Constraints []ProviderInventoryEntry `json:"constraints"`
+}
+
+// ProviderVersionDrift lists providers whose repositories use more than one
+// version constraint. Constraints are compared in normalized form, so
+// "~>5.0" and "~> 5.0" are not drift.
+func (r *Reporter) ProviderVersionDrift() []ProviderVersionDrift {
+	pinned := lo.Filter(r.ProviderInventory(), func(entry ProviderInventoryEntry, _ int) bool {
+		return entry.Version != ""
+	})
+	bySource := lo.GroupBy(pinned, func(entry ProviderInventoryEntry) string {
+		return entry.Source
+	})
+
+	var drift []ProviderVersionDrift
+	for _, source := range slices.Sorted(maps.Keys(bySource)) {
+		if len(bySource[source]) > 1 {
+			drift = append(drift, ProviderVersionDrift{Source: source, Constraints: bySource[source]})
+		}
+	}
+	return drift
+}
+
+// formatProviderInventory renders the inventory as JSON or as a markdown table
+func formatProviderInventory(inventory []ProviderInventoryEntry, format string) (string, error) {
+	if format == "json" {
+		jsonData, err := json.MarshalIndent(inventory, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal provider inventory: %w", err)
+		}
+		return string(jsonData) + "\n", nil
+	}
+
+	var builder strings.Builder
+	builder.WriteString("| Provider | Version | Repository Count |\n")
+	builder.WriteString("|----------|---------|------------------|\n")
+	for _, entry := range inventory {
+		version := entry.Version
+		if version == "" {
+			version = "(unpinned)"
+		}
+		fmt.Fprintf(&builder, "| %s | %s | %d |\n", entry.Source, version, entry.RepositoryCount)
+	}
+	return builder.String(), nil
+}
+
+func (r *Reporter) PrintProviderInventory(format string) error {
+	output, err := formatProviderInventory(r.ProviderInventory(), format)
+	if err != nil {
+		return err
+	}
+	fmt.Print(output)
+	return nil
+}
+
+// ErrComplianceThresholdExceeded is returned after reports are written when a
+// --fail-on-* threshold is breached
+var ErrComplianceThresholdExceeded = errors.New("compliance thresholds exceeded")
+
+// CheckComplianceThresholds evaluates the --fail-on-untagged,
+// --fail-on-missing-provider-version, --require-pinned-providers and
+// --fail-on-deprecated gates against every successful result
+func (r *Reporter) CheckComplianceThresholds(config Config) error {
+	results := r.getSuccessfulResults()
+	repositories := lo.Map(results, func(result AnalysisResult, _ int) RepositoryAnalysis {
+		return result.Analysis
+	})
+
+	var breaches []string
+	if untagged := r.calculateTotalUntaggedResources(repositories); config.FailOnUntagged >= 0 && untagged > config.FailOnUntagged {
+		breaches = append(breaches, fmt.Sprintf("%d untagged resources exceed --fail-on-untagged %d", untagged, config.FailOnUntagged))
+	}
+	if config.FailOnMissingProviderVersion {
+		unpinned := lo.FlatMap(results, func(result AnalysisResult, _ int) []string {
+			return lo.Map(unpinnedProviders(result.Analysis.Providers.ProviderDetails), func(provider string, _ int) string {
+				return result.Organization + "/" + result.RepoName + ":" + provider
+			})
+		})
+		if len(unpinned) > 0 {
+			breaches = append(breaches, fmt.Sprintf("%d providers have no version constraint (%s)", len(unpinned), strings.Join(unpinned, ", ")))
+		}
+	}
+	if config.RequirePinnedProviders {
+		// Repositories without providers have nothing to pin and never fail the gate
+		if pinned, total := providerPinningCoverage(repositories); total > 0 && pinned*100 < config.MinPinnedPct*total {
+			breaches = append(breaches, fmt.Sprintf("%.1f%% of providers are pinned (%d of %d), below --min-pinned-pct %d",
+				float64(pinned)*100/float64(total), pinned, total, config.MinPinnedPct))
+		}
+	}
+	if deprecated := calculateTotalDeprecatedResources(repositories); config.FailOnDeprecated && deprecated > 0 {
+		breaches = append(breaches, fmt.Sprintf("%d resources use deprecated types", deprecated))
+	}
+
+	if len(breaches) > 0 {
+		return fmt.Errorf("%w: %s", ErrComplianceThresholdExceeded, strings.Join(breaches, "; "))
+	}
+	return nil
+}
+
+// ErrValidationFailed is returned by --validate-only runs when any file fails to parse
+var ErrValidationFailed = errors.New("terraform files failed to parse")
+
+// PrintValidationResults lists every file that failed to read or parse and returns
+// ErrValidationFailed when there is at least one
+func (r *Reporter) PrintValidationResults(w io.Writer) error {
+	failures := 0
+	for _, result := range r.getSuccessfulResults() {
+		for _, fileErr := range result.Analysis.FileErrors {
+			fmt.Fprintf(w, "%s/%s: %s: %s\n", result.Organization, result.RepoName, fileErr.Path, fileErr.Reason)
+			failures++
+		}
+		for _, legacy := range result.Analysis.LegacyHCLFiles {
+			fmt.Fprintf(w, "%s/%s: %s: legacy HCL1 syntax (%s); migrate with terraform 0.12upgrade\n", result.Organization, result.RepoName, legacy.File, legacy.Reason)
+			failures++
+		}
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("%w: %d file(s)", ErrValidationFailed, failures)
+	}
+	fmt.Fprintf(w, "All Terraform files parsed successfully (%d repositories)\n", len(r.getSuccessfulResults()))
+	return nil
+}
+
 func (r *Reporter) calculateTotalUntaggedResources(repositories []RepositoryAnalysis) int {
 	return sumRepoProperty(repositories, func(repo RepositoryAnalysis) int {
 		return len(repo.ResourceAnalysis.UntaggedResources)
@@ -1,10 +1,16 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+
+	"github.com/samber/lo"
 )
 
 // TestNewReporter tests reporter creation
@@ -593,6 +599,46 @@ func TestGenerateReport(t *testing.T) {
 	})
 }
 
+func TestResourceTypeInventory(t *testing.T) {
+	// Given: two repositories sharing aws_s3_bucket, one also using aws_vpc, and a failed repository
+	resources := func(types ...ResourceType) RepositoryAnalysis {
+		return RepositoryAnalysis{ResourceAnalysis: ResourceAnalysis{ResourceTypes: types}}
+	}
+	reporter := NewReporter()
+	reporter.AddResults([]AnalysisResult{
+		{RepoName: "storage", Analysis: resources(ResourceType{Type: "aws_s3_bucket", Count: 3})},
+		{RepoName: "network", Analysis: resources(ResourceType{Type: "aws_s3_bucket", Count: 1}, ResourceType{Type: "aws_vpc", Count: 4})},
+		{RepoName: "broken", Analysis: resources(ResourceType{Type: "aws_iam_role", Count: 9}), Error: errors.New("clone failed")},
+	})
+
+	// When: the report is generated
+	report := reporter.GenerateReport()
+
+	// Then: types are totalled across repositories, most used first
+	expected := []ResourceTypeUsage{
+		{Type: "aws_s3_bucket", TotalCount: 4, RepoCount: 2},
+		{Type: "aws_vpc", TotalCount: 4, RepoCount: 1},
+	}
+	if !slices.Equal(report.GlobalSummary.ResourceTypes, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, report.GlobalSummary.ResourceTypes)
+	}
+
+	// Then: the JSON and markdown reports carry the inventory
+	jsonData, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(string(jsonData), `"resource_types":[{"type":"aws_s3_bucket","total_count":4,"repo_count":2}`) {
+		t.Errorf("Expected the JSON global summary to list resource types, got %s", jsonData)
+	}
+	markdown := reporter.generateMarkdownContent()
+	for _, row := range []string{"## Resource Type Inventory", "| aws_s3_bucket | 4 | 2 |", "| aws_vpc | 4 | 1 |"} {
+		if !strings.Contains(markdown, row) {
+			t.Errorf("Expected markdown to contain %q", row)
+		}
+	}
+}
+
 // TestPrintMarkdownToScreen tests markdown printing
 func TestPrintMarkdownToScreen(t *testing.T) {
 	t.Run("prints markdown without panic", func(t *testing.T) {
@@ -706,3 +752,469 @@ func TestConvertBoldText(t *testing.T) {
 	})
 }
 
+
+// TestProviderInventory tests the providers-only inventory across repositories
+func TestProviderInventory(t *testing.T) {
+	// Given: two repositories sharing the AWS provider and declaring resources
+	repoA := createTempTerraformRepo(t, map[string]string{
+		"main.tf": `
+terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 5.0"
+    }
+  }
+}
+
+resource "aws_s3_bucket" "logs" {}
+`,
+	})
+	repoB := createTempTerraformRepo(t, map[string]string{
+		"main.tf": `
+terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 5.0"
+    }
+    random = {
+      source = "hashicorp/random"
+    }
+  }
+}
+
+resource "aws_instance" "web" {}
+`,
+	})
+	options := analysisOptionsFromConfig(Config{ListProviders: true})
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	reporter := NewReporter()
+	for _, repo := range []Repository{
+		{Name: "repo-a", Organization: "test-org", Path: repoA},
+		{Name: "repo-b", Organization: "test-org", Path: repoB},
+	} {
+		reporter.AddResults([]AnalysisResult{processRepositoryFilesWithOptions(repo, options, logger)})
+	}
+
+	t.Run("skips non-provider sections", func(t *testing.T) {
+		for _, result := range reporter.GetResults() {
+			if result.Error != nil {
+				t.Fatalf("Expected no error for %s, got %v", result.RepoName, result.Error)
+			}
+			if result.Analysis.ResourceAnalysis.TotalResourceCount != 0 {
+				t.Errorf("Expected no resources collected for %s, got %d",
+					result.RepoName, result.Analysis.ResourceAnalysis.TotalResourceCount)
+			}
+			if result.Analysis.Providers.UniqueProviderCount == 0 {
+				t.Errorf("Expected providers collected for %s", result.RepoName)
+			}
+		}
+	})
+
+	t.Run("aggregates unique provider versions", func(t *testing.T) {
+		expected := []ProviderInventoryEntry{
+			{Source: "hashicorp/aws", Version: "~> 5.0", RepositoryCount: 2},
+			{Source: "hashicorp/random", Version: "", RepositoryCount: 1},
+		}
+
+		inventory := reporter.ProviderInventory()
+
+		if len(inventory) != len(expected) {
+			t.Fatalf("Expected %d inventory entries, got %d: %+v", len(expected), len(inventory), inventory)
+		}
+		for i, entry := range expected {
+			if inventory[i] != entry {
+				t.Errorf("Entry %d: expected %+v, got %+v", i, entry, inventory[i])
+			}
+		}
+	})
+
+	t.Run("formats inventory as JSON", func(t *testing.T) {
+		output, err := formatProviderInventory(reporter.ProviderInventory(), "json")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		var decoded []ProviderInventoryEntry
+		if err := json.Unmarshal([]byte(output), &decoded); err != nil {
+			t.Fatalf("Expected valid JSON, got %v: %s", err, output)
+		}
+		if len(decoded) != 2 {
+			t.Errorf("Expected 2 entries, got %d", len(decoded))
+		}
+		if strings.Contains(output, "resource") {
+			t.Errorf("Expected no resource data in inventory output, got: %s", output)
+		}
+	})
+
+	t.Run("formats inventory as table", func(t *testing.T) {
+		output, err := formatProviderInventory(reporter.ProviderInventory(), "all")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if !strings.Contains(output, "| hashicorp/aws | ~> 5.0 | 2 |") {
+			t.Errorf("Expected aws row in table, got: %s", output)
+		}
+		if !strings.Contains(output, "| hashicorp/random | (unpinned) | 1 |") {
+			t.Errorf("Expected unpinned random row in table, got: %s", output)
+		}
+	})
+}
+
+func TestSortResults(t *testing.T) {
+	untagged := func(n int) []UntaggedResource {
+		return make([]UntaggedResource, n)
+	}
+	fixture := []AnalysisResult{
+		{RepoName: "delta", Organization: "org-b", Analysis: RepositoryAnalysis{
+			ResourceAnalysis: ResourceAnalysis{TotalResourceCount: 10, UntaggedResources: untagged(1)},
+			ComplexityScore:  7,
+		}},
+		{RepoName: "alpha", Organization: "org-b", Analysis: RepositoryAnalysis{
+			ResourceAnalysis: ResourceAnalysis{TotalResourceCount: 4, UntaggedResources: untagged(4)},
+			ComplexityScore:  30,
+		}},
+		{RepoName: "charlie", Organization: "org-a", Analysis: RepositoryAnalysis{
+			ResourceAnalysis: ResourceAnalysis{TotalResourceCount: 20, UntaggedResources: untagged(2)},
+			ComplexityScore:  15,
+		}},
+		{RepoName: "bravo", Organization: "org-a", Analysis: RepositoryAnalysis{}},
+	}
+
+	tests := []struct {
+		key      string
+		expected []string
+	}{
+		{"", []string{"bravo", "charlie", "alpha", "delta"}},
+		{SortByOrg, []string{"bravo", "charlie", "alpha", "delta"}},
+		{SortByName, []string{"alpha", "bravo", "charlie", "delta"}},
+		{SortByResources, []string{"charlie", "delta", "alpha", "bravo"}},
+		{SortByUntagged, []string{"alpha", "charlie", "delta", "bravo"}},
+		// Scores: alpha 0%, charlie 90%, delta 90%, bravo 100% (no resources)
+		{SortByScore, []string{"alpha", "charlie", "delta", "bravo"}},
+		{SortByComplexity, []string{"alpha", "charlie", "delta", "bravo"}},
+	}
+
+	for _, tt := range tests {
+		t.Run("sort by "+tt.key, func(t *testing.T) {
+			// When: results are sorted by the key
+			sorted, err := sortResults(fixture, tt.key)
+
+			// Then: repositories should follow the expected order
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			names := lo.Map(sorted, func(result AnalysisResult, _ int) string { return result.RepoName })
+			if strings.Join(names, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("Expected order %v, got %v", tt.expected, names)
+			}
+		})
+	}
+
+	t.Run("markdown lists the most complex repositories first", func(t *testing.T) {
+		reporter := NewReporter()
+		reporter.AddResults(lo.Map(fixture, func(result AnalysisResult, _ int) AnalysisResult {
+			result.Analysis.RepositoryPath = "/clones/" + result.RepoName
+			return result
+		}))
+
+		markdown := reporter.generateMarkdownContent()
+
+		alpha, charlie := strings.Index(markdown, "| alpha | 30 |"), strings.Index(markdown, "| charlie | 15 |")
+		if !strings.Contains(markdown, "## Most Complex Repositories") || alpha < 0 || charlie < alpha {
+			t.Errorf("Expected alpha above charlie in the complexity table, got %s", markdown)
+		}
+		top := lo.Map(mostComplexRepositories(reporter.GenerateReport().Repositories, 2), func(repo RepositoryForJSON, _ int) int {
+			return repo.ComplexityScore
+		})
+		if len(top) != 2 || top[0] != 30 || top[1] != 15 {
+			t.Errorf("Expected the two highest scores, got %v", top)
+		}
+		if len(mostComplexRepositories(reporter.GenerateReport().Repositories, 10)) != 3 {
+			t.Error("Expected repositories scoring zero to be left out")
+		}
+	})
+
+	t.Run("input slice is not modified", func(t *testing.T) {
+		_, _ = sortResults(fixture, SortByName)
+		if fixture[0].RepoName != "delta" {
+			t.Errorf("Expected fixture to keep its order, got %s first", fixture[0].RepoName)
+		}
+	})
+
+	t.Run("invalid key is rejected", func(t *testing.T) {
+		reporter := NewReporter()
+		reporter.AddResults(fixture)
+		if err := reporter.SortResults("stars"); err == nil || !strings.Contains(err.Error(), "invalid sort key") {
+			t.Errorf("Expected invalid sort key error, got %v", err)
+		}
+	})
+
+	t.Run("reporter exports in sorted order", func(t *testing.T) {
+		// Given: a reporter sorted by resource count
+		reporter := NewReporter()
+		reporter.AddResults(fixture)
+		if err := reporter.SortResults(SortByResources); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// When: the report is generated
+		report := reporter.GenerateReport()
+
+		// Then: repositories should appear in the sorted order
+		if len(report.Repositories) != 4 || report.Repositories[0].ResourceAnalysis.TotalResourceCount != 20 {
+			t.Errorf("Expected the largest repository first, got %+v", report.Repositories)
+		}
+	})
+}
+
+// recordingWriter counts bytes and tracks the largest single write
+type recordingWriter struct {
+	writes       int
+	bytes        int
+	largestWrite int
+	lines        int
+}
+
+func (w *recordingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	w.bytes += len(p)
+	w.largestWrite = max(w.largestWrite, len(p))
+	w.lines += strings.Count(string(p), "\n")
+	return len(p), nil
+}
+
+func TestWriteResourceCSV(t *testing.T) {
+	t.Run("streams a high resource count export in bounded chunks", func(t *testing.T) {
+		// Given: many repositories with many resource types each
+		const repoCount, typesPerRepo = 50, 400
+		results := make([]AnalysisResult, repoCount)
+		for i := range results {
+			resourceTypes := make([]ResourceType, typesPerRepo)
+			for j := range resourceTypes {
+				resourceTypes[j] = ResourceType{Type: "synthetic_resource_type_" + strings.Repeat("x", j%20), Count: j + 1}
+			}
+			results[i] = AnalysisResult{
+				RepoName:     "repo",
+				Organization: "org",
+				Analysis: RepositoryAnalysis{
+					RepositoryPath:   "/repos/repo",
+					ResourceAnalysis: ResourceAnalysis{ResourceTypes: resourceTypes},
+				},
+			}
+		}
+		writer := &recordingWriter{}
+
+		// When: the resource CSV is written
+		rows, err := writeResourceCSV(writer, results)
+
+		// Then: every row should be written without buffering the whole export
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if rows != repoCount*typesPerRepo || writer.lines != rows+1 {
+			t.Errorf("Expected %d rows plus header, got %d rows and %d lines", repoCount*typesPerRepo, rows, writer.lines)
+		}
+		if writer.writes < 2 || writer.largestWrite > 64*1024 {
+			t.Errorf("Expected output in small chunks, got %d writes with largest %d of %d bytes",
+				writer.writes, writer.largestWrite, writer.bytes)
+		}
+	})
+
+	t.Run("exports untagged counts per resource type", func(t *testing.T) {
+		// Given: a reporter with an analyzed repository
+		reporter := NewReporter()
+		reporter.AddResults([]AnalysisResult{{
+			RepoName:     "network",
+			Organization: "acme",
+			Analysis: RepositoryAnalysis{
+				RepositoryPath: "/repos/network",
+				ResourceAnalysis: ResourceAnalysis{
+					ResourceTypes:     []ResourceType{{Type: "aws_vpc", Count: 2}},
+					UntaggedResources: []UntaggedResource{{ResourceType: "aws_vpc", Name: "main"}},
+				},
+			},
+		}})
+		csvPath := filepath.Join(t.TempDir(), "resources.csv")
+
+		// When: the resource CSV is exported
+		if err := reporter.ExportResourceCSV(csvPath); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then: the file should contain the header and the resource row
+		content, err := os.ReadFile(csvPath)
+		if err != nil {
+			t.Fatalf("Failed to read CSV: %v", err)
+		}
+		expected := "Repository,Organization,ResourceType,Count,Untagged\nnetwork,acme,aws_vpc,2,1\n"
+		if string(content) != expected {
+			t.Errorf("Expected %q, got %q", expected, content)
+		}
+	})
+}
+
+func TestExportUntaggedCSV(t *testing.T) {
+	// Given: untagged resources in two repositories of different organizations, plus a failed repository
+	reporter := NewReporter()
+	reporter.AddResults([]AnalysisResult{
+		{RepoName: "network", Organization: "acme", Analysis: RepositoryAnalysis{
+			RepositoryPath: "/repos/network",
+			ResourceAnalysis: ResourceAnalysis{UntaggedResources: []UntaggedResource{
+				{ResourceType: "aws_vpc", Name: "main", MissingTags: []string{"Owner", "Project"}},
+				{ResourceType: "aws_subnet", Name: "private", MissingTags: []string{"CostCenter"}},
+			}},
+		}},
+		{RepoName: "storage", Organization: "globex", Analysis: RepositoryAnalysis{
+			RepositoryPath: "/repos/storage",
+			ResourceAnalysis: ResourceAnalysis{UntaggedResources: []UntaggedResource{
+				{ResourceType: "aws_s3_bucket", Name: "logs", MissingTags: []string{"Environment"}},
+			}},
+		}},
+		{RepoName: "broken", Organization: "acme", Error: os.ErrNotExist},
+	})
+	csvPath := filepath.Join(t.TempDir(), UntaggedCSVFileName)
+
+	// When: the untagged CSV is exported
+	if err := reporter.ExportUntaggedCSV(csvPath); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Then: the file should hold the header and one row per untagged resource
+	content, err := os.ReadFile(csvPath)
+	if err != nil {
+		t.Fatalf("Failed to read CSV: %v", err)
+	}
+	expected := "Organization,Repository,ResourceType,ResourceName,MissingTags\n" +
+		"acme,network,aws_vpc,main,Owner;Project\n" +
+		"acme,network,aws_subnet,private,CostCenter\n" +
+		"globex,storage,aws_s3_bucket,logs,Environment\n"
+	if string(content) != expected {
+		t.Errorf("Expected %q, got %q", expected, content)
+	}
+}
+
+func TestProviderVersionDrift(t *testing.T) {
+	withAWS := func(name, version string) AnalysisResult {
+		return AnalysisResult{
+			RepoName: name,
+			Analysis: RepositoryAnalysis{
+				RepositoryPath: "/repos/" + name,
+				Providers: ProvidersAnalysis{
+					ProviderDetails: []ProviderDetail{{Source: "hashicorp/aws", Version: version}},
+				},
+			},
+		}
+	}
+
+	t.Run("cosmetically different constraints are not drift", func(t *testing.T) {
+		// Given: repositories pinning ~>5.0 and ~> 5.0
+		reporter := NewReporter()
+		reporter.AddResults([]AnalysisResult{withAWS("a", "~>5.0"), withAWS("b", "~> 5.0")})
+
+		// When: the drift report is built
+		drift := reporter.ProviderVersionDrift()
+
+		// Then: both should group as one constraint
+		if len(drift) != 0 {
+			t.Errorf("Expected no drift, got %+v", drift)
+		}
+		inventory := reporter.ProviderInventory()
+		if len(inventory) != 1 || inventory[0].Version != "~> 5.0" || inventory[0].RepositoryCount != 2 {
+			t.Errorf("Expected one ~> 5.0 entry used by 2 repositories, got %+v", inventory)
+		}
+	})
+
+	t.Run("different constraints are reported as drift", func(t *testing.T) {
+		// Given: a third repository pinned to an older major version
+		reporter := NewReporter()
+		reporter.AddResults([]AnalysisResult{withAWS("a", "~>5.0"), withAWS("b", "~> 5.0"), withAWS("c", "~> 4.0")})
+
+		// When: the drift report is built
+		drift := reporter.ProviderVersionDrift()
+
+		// Then: the provider should list both normalized constraints
+		if len(drift) != 1 || drift[0].Source != "hashicorp/aws" {
+			t.Fatalf("Expected drift for hashicorp/aws, got %+v", drift)
+		}
+		expected := []ProviderInventoryEntry{
+			{Source: "hashicorp/aws", Version: "~> 4.0", RepositoryCount: 1},
+			{Source: "hashicorp/aws", Version: "~> 5.0", RepositoryCount: 2},
+		}
+		if !slices.Equal(drift[0].Constraints, expected) {
+			t.Errorf("Expected constraints %+v, got %+v", expected, drift[0].Constraints)
+		}
+		if !strings.Contains(reporter.generateMarkdownContent(), "## Provider Version Drift") {
+			t.Error("Expected drift section in markdown report")
+		}
+	})
+}
+
+func TestCheckComplianceThresholds(t *testing.T) {
+	reporter := NewReporter()
+	reporter.AddResults([]AnalysisResult{
+		{RepoName: "network", Organization: "acme", Analysis: RepositoryAnalysis{
+			Providers: ProvidersAnalysis{ProviderDetails: []ProviderDetail{{Source: "hashicorp/aws", Version: "~> 5.0"}}},
+			ResourceAnalysis: ResourceAnalysis{UntaggedResources: []UntaggedResource{
+				{ResourceType: "aws_vpc", Name: "main"},
+				{ResourceType: "aws_subnet", Name: "private"},
+			}},
+		}},
+		{RepoName: "storage", Organization: "acme", Analysis: RepositoryAnalysis{
+			Providers: ProvidersAnalysis{ProviderDetails: []ProviderDetail{{Source: "hashicorp/random"}}},
+		}},
+	})
+
+	tests := []struct {
+		name        string
+		config      Config
+		expectError string
+	}{
+		{"disabled gates pass", Config{FailOnUntagged: FailOnUntaggedDisabled}, ""},
+		{"untagged count at the threshold passes", Config{FailOnUntagged: 2}, ""},
+		{"untagged count above the threshold fails", Config{FailOnUntagged: 1}, "2 untagged resources exceed --fail-on-untagged 1"},
+		{"zero threshold fails on any untagged resource", Config{FailOnUntagged: 0}, "--fail-on-untagged 0"},
+		{"unpinned provider fails", Config{FailOnUntagged: FailOnUntaggedDisabled, FailOnMissingProviderVersion: true}, "acme/storage:hashicorp/random"},
+		{"pinned share at the minimum passes", Config{FailOnUntagged: FailOnUntaggedDisabled, RequirePinnedProviders: true, MinPinnedPct: 50}, ""},
+		{"pinned share below the minimum fails", Config{FailOnUntagged: FailOnUntaggedDisabled, RequirePinnedProviders: true, MinPinnedPct: DefaultMinPinnedPct}, "50.0% of providers are pinned (1 of 2), below --min-pinned-pct 100"},
+		{"minimum is ignored without --require-pinned-providers", Config{FailOnUntagged: FailOnUntaggedDisabled, MinPinnedPct: DefaultMinPinnedPct}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When: thresholds are evaluated
+			err := reporter.CheckComplianceThresholds(tt.config)
+
+			// Then: only a breach should return the sentinel error
+			if tt.expectError == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrComplianceThresholdExceeded) || !strings.Contains(err.Error(), tt.expectError) {
+				t.Errorf("Expected ErrComplianceThresholdExceeded containing %q, got %v", tt.expectError, err)
+			}
+			if exitCodeFor(err) != ExitCodeThresholdExceeded {
+				t.Errorf("Expected exit code %d, got %d", ExitCodeThresholdExceeded, exitCodeFor(err))
+			}
+		})
+	}
+
+	t.Run("pinned providers pass", func(t *testing.T) {
+		pinned := NewReporter()
+		pinned.AddResults(reporter.GetResults()[:1])
+		if err := pinned.CheckComplianceThresholds(Config{FailOnUntagged: FailOnUntaggedDisabled, FailOnMissingProviderVersion: true}); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+
+	t.Run("other errors exit with code 1", func(t *testing.T) {
+		if code := exitCodeFor(os.ErrNotExist); code != ExitCodeError {
+			t.Errorf("Expected exit code %d, got %d", ExitCodeError, code)
+		}
+	})
+}
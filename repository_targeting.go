@@ -28,6 +28,18 @@ func parseTargetRepos(input string) []string {
 	})
 }
 
+// repoNamePattern matches the characters GitHub allows in owner and repository names
+var repoNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// parseRepoReference splits a --repo value of the form org/name
+func parseRepoReference(reference string) (string, string, error) {
+	org, name, found := strings.Cut(strings.TrimSpace(reference), "/")
+	if !found || !repoNamePattern.MatchString(org) || !repoNamePattern.MatchString(name) {
+		return "", "", fmt.Errorf("invalid --repo %q: expected <org>/<name>", reference)
+	}
+	return org, name, nil
+}
+
 // readTargetReposFromFile reads repository names from a file
 func readTargetReposFromFile(filePath string) ([]string, error) {
 	file, err := os.Open(filePath)
@@ -84,12 +96,23 @@ func parsePrefixes(input string) []string {
 	})
 }
 
-// validateTargetingConfiguration validates repository targeting configuration
+// validateTargetingConfiguration validates repository targeting configuration.
+// An explicit repository list (--target-repos, --target-repos-file or --repo)
+// is exclusive: ghorg clones exactly the listed repositories and cannot also
+// filter them by name. Without a list, the match filter selects repositories
+// first and the exclude filter then removes repositories from that selection.
 func validateTargetingConfiguration(config Config) error {
 	// Validate conflicting target options
 	if len(config.TargetRepos) > 0 && config.TargetReposFile != "" {
 		return fmt.Errorf("cannot specify both --target-repos and --target-repos-file")
 	}
+
+	// An explicit repository list cannot be narrowed by name filters
+	if listFlag := targetListFlag(config); listFlag != "" {
+		if filterFlag := nameFilterFlag(config); filterFlag != "" {
+			return fmt.Errorf("cannot combine %s with %s: ghorg clones exactly the listed repositories, so name filters would be ignored; filter the list itself instead", listFlag, filterFlag)
+		}
+	}
 	
 	// Validate conflicting match options
 	if config.MatchRegex != "" && len(config.MatchPrefix) > 0 {
@@ -103,12 +126,38 @@ func validateTargetingConfiguration(config Config) error {
 	
 	// Validate regex patterns
 	if err := validateRegexPattern(config.MatchRegex); err != nil {
-		return fmt.Errorf("invalid match regex: %w", err)
+		return fmt.Errorf("invalid match regex for --match-regex: %w", err)
 	}
 	
 	if err := validateRegexPattern(config.ExcludeRegex); err != nil {
-		return fmt.Errorf("invalid exclude regex: %w", err)
+		return fmt.Errorf("invalid exclude regex for --exclude-regex: %w", err)
 	}
 	
 	return nil
+}
+
+// targetListFlag names the flag that supplied an explicit repository list
+func targetListFlag(config Config) string {
+	switch {
+	case config.TargetReposFile != "":
+		return "--target-repos-file"
+	case len(config.TargetRepos) > 0:
+		return "--target-repos"
+	}
+	return ""
+}
+
+// nameFilterFlag names the first match or exclude filter that is set
+func nameFilterFlag(config Config) string {
+	switch {
+	case config.MatchRegex != "":
+		return "--match-regex"
+	case len(config.MatchPrefix) > 0:
+		return "--match-prefix"
+	case config.ExcludeRegex != "":
+		return "--exclude-regex"
+	case len(config.ExcludePrefix) > 0:
+		return "--exclude-prefix"
+	}
+	return ""
 }
\ No newline at end of file
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -465,6 +466,62 @@ func TestTargetingConfigValidation(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "invalid match regex")
 	})
+
+	t.Run("it names the flag and pattern of an invalid exclude regex", func(t *testing.T) {
+		// Given: config with an exclude regex that does not compile
+		config := Config{
+			Organizations: []string{"test-org"},
+			ExcludeRegex:  "^terraform-(.*",
+		}
+
+		// When: validateTargetingConfiguration is called
+		err := validateTargetingConfiguration(config)
+
+		// Then: the error should identify the flag and the offending pattern
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "--exclude-regex")
+		assert.Contains(t, err.Error(), "^terraform-(.*")
+	})
+
+	t.Run("it rejects an explicit repository list combined with name filters", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			config   Config
+			expected string
+		}{
+			{"target repos with match regex", Config{TargetRepos: []string{"repo1"}, MatchRegex: "^terraform-"}, "cannot combine --target-repos with --match-regex"},
+			{"target repos with exclude prefix", Config{TargetRepos: []string{"repo1"}, ExcludePrefix: []string{"old-"}}, "cannot combine --target-repos with --exclude-prefix"},
+			{"target repos file with match prefix", Config{TargetReposFile: "repos.txt", MatchPrefix: []string{"tf-"}}, "cannot combine --target-repos-file with --match-prefix"},
+			{"target repos file with exclude regex", Config{TargetReposFile: "repos.txt", ExcludeRegex: "-deprecated$"}, "cannot combine --target-repos-file with --exclude-regex"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				// When: validateTargetingConfiguration is called
+				err := validateTargetingConfiguration(tt.config)
+
+				// Then: the error should name both conflicting flags
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expected)
+			})
+		}
+	})
+
+	t.Run("it accepts a single targeting mode and match with exclude filters", func(t *testing.T) {
+		configs := []Config{
+			{TargetRepos: []string{"repo1", "repo2"}},
+			{TargetReposFile: "repos.txt"},
+			{MatchRegex: "^terraform-"},
+			{MatchPrefix: []string{"tf-"}, ExcludeRegex: "-deprecated$"},
+			{MatchRegex: "^terraform-", ExcludePrefix: []string{"terraform-legacy-"}},
+		}
+		for _, config := range configs {
+			// When: validateTargetingConfiguration is called
+			err := validateTargetingConfiguration(config)
+
+			// Then: the configuration should be valid
+			assert.NoError(t, err, "config %+v", config)
+		}
+	})
 }
 
 // TestViperBindingForTargeting tests viper configuration binding
@@ -529,4 +586,100 @@ func createTempRepoFile(t *testing.T, content string) string {
 // - validateTargetingConfiguration(Config) error
 // - Extended Config struct with targeting fields
 // - Modified buildGhorgCommand to include targeting options
-// - Modified createConfigFromViper to load targeting options
\ No newline at end of file
+// - Modified createConfigFromViper to load targeting options
+// TestSingleRepositoryTarget tests --repo parsing, the clone it drives and the resulting report
+func TestSingleRepositoryTarget(t *testing.T) {
+	t.Run("it validates the org/name format", func(t *testing.T) {
+		tests := []struct {
+			input       string
+			expectError bool
+		}{
+			{"acme/network", false},
+			{"acme/terraform-aws.vpc_v2", false},
+			{"acme", true},
+			{"/network", true},
+			{"acme/", true},
+			{"acme/network/extra", true},
+			{"acme/net work", true},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.input, func(t *testing.T) {
+				org, name, err := parseRepoReference(tt.input)
+				if tt.expectError {
+					assert.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+				assert.Equal(t, tt.input, org+"/"+name)
+			})
+		}
+	})
+
+	t.Run("it rejects combining --repo with --target-repos", func(t *testing.T) {
+		viper.Reset()
+		viper.Set("github.repo", "acme/network")
+		viper.Set("github.target_repos", []string{"storage"})
+
+		_, err := createConfigFromViper()
+
+		assert.ErrorContains(t, err, "--repo cannot be combined")
+	})
+
+	t.Run("it clones only the named repository and reports only it", func(t *testing.T) {
+		// Given: a fake ghorg that clones every repository of the org unless targeted
+		binDir := t.TempDir()
+		argsFile := filepath.Join(binDir, "args")
+		fakeGhorg := `#!/bin/sh
+echo "$@" > "` + argsFile + `"
+org=$2
+shift 2
+while [ $# -gt 0 ]; do
+  case $1 in
+    --path) dest=$2; shift ;;
+    --target-repos-path) targets=$2; shift ;;
+  esac
+  shift
+done
+for repo in network storage; do
+  if [ -z "$targets" ] || grep -qx "$repo" "$targets"; then
+    mkdir -p "$dest/$org/$repo"
+    printf 'resource "aws_vpc" "main" {}\n' > "$dest/$org/$repo/main.tf"
+  fi
+done
+`
+		require.NoError(t, os.WriteFile(filepath.Join(binDir, "ghorg"), []byte(fakeGhorg), 0o755))
+		t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+		viper.Reset()
+		viper.Set("github.repo", "acme/network")
+		viper.Set("github.token", "token123")
+		viper.Set("processing.max_goroutines", 2)
+		viper.Set("processing.clone_concurrency", 1)
+		config, err := createConfigFromViper()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"acme"}, config.Organizations)
+		assert.Equal(t, []string{"network"}, config.TargetRepos)
+		require.NoError(t, validateCLIAnalysisConfig(config))
+
+		processingCtx, err := createProcessingContext(config)
+		require.NoError(t, err)
+		defer releaseProcessingContext(processingCtx)
+
+		// When: the analysis workflow runs
+		reporter, err := executeAnalysisWorkflow(context.Background(), processingCtx)
+		require.NoError(t, err)
+
+		// Then: ghorg was asked for the acme org with a target file naming only network
+		invocation, err := os.ReadFile(argsFile)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(invocation), "clone acme "), "unexpected ghorg args: %s", invocation)
+		assert.Contains(t, string(invocation), "--target-repos-path")
+
+		// Then: the report contains only that repository
+		results := reporter.GetResults()
+		require.Len(t, results, 1)
+		assert.Equal(t, "network", results[0].RepoName)
+		assert.Equal(t, "acme", results[0].Organization)
+	})
+}
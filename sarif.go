@@ -0,0 +1,136 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/bitfield/script"
+)
+
+// ============================================================================
+// SARIF - Static Analysis Results Interchange Format export for CI scanners
+// ============================================================================
+
+const (
+	SARIFVersion = "2.1.0"
+	SARIFSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// SARIF rule ids
+const (
+	SARIFRuleMissingTags     = "tf-analyzer/missing-tags"
+	SARIFRuleInvalidTagValue = "tf-analyzer/invalid-tag-value"
+)
+
+type SARIFLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []SARIFRun `json:"runs"`
+}
+
+type SARIFRun struct {
+	Tool    SARIFTool     `json:"tool"`
+	Results []SARIFResult `json:"results"`
+}
+
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+type SARIFDriver struct {
+	Name    string      `json:"name"`
+	Version string      `json:"version"`
+	Rules   []SARIFRule `json:"rules"`
+}
+
+type SARIFRule struct {
+	ID               string       `json:"id"`
+	ShortDescription SARIFMessage `json:"shortDescription"`
+}
+
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+type SARIFResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   SARIFMessage    `json:"message"`
+	Locations []SARIFLocation `json:"locations"`
+}
+
+type SARIFLocation struct {
+	LogicalLocations []SARIFLogicalLocation `json:"logicalLocations"`
+}
+
+// SARIFLogicalLocation identifies a resource by address since tag findings
+// do not track a file position
+type SARIFLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+var sarifRules = []SARIFRule{
+	{ID: SARIFRuleMissingTags, ShortDescription: SARIFMessage{Text: "Resource is missing mandatory tags"}},
+	{ID: SARIFRuleInvalidTagValue, ShortDescription: SARIFMessage{Text: "Tag value does not match its tag_value_rules pattern"}},
+}
+
+// SARIF builds a single-run log with a result per untagged resource and per
+// invalid tag value across every successfully analyzed repository
+func (r *Reporter) SARIF() SARIFLog {
+	results := []SARIFResult{}
+	for _, result := range r.getSuccessfulResults() {
+		resources := result.Analysis.ResourceAnalysis
+		for _, resource := range resources.UntaggedResources {
+			results = append(results, sarifResult(result, SARIFRuleMissingTags, resource.ResourceType, resource.Name,
+				fmt.Sprintf("%s.%s is missing tags: %s", resource.ResourceType, resource.Name, strings.Join(resource.MissingTags, ", "))))
+		}
+		for _, resource := range resources.InvalidTagResources {
+			for _, tag := range resource.InvalidTags {
+				results = append(results, sarifResult(result, SARIFRuleInvalidTagValue, resource.ResourceType, resource.Name,
+					fmt.Sprintf("%s.%s tag %s=%q does not match %s", resource.ResourceType, resource.Name, tag.Tag, tag.Value, tag.Pattern)))
+			}
+		}
+	}
+
+	return SARIFLog{
+		Version: SARIFVersion,
+		Schema:  SARIFSchema,
+		Runs: []SARIFRun{{
+			Tool:    SARIFTool{Driver: SARIFDriver{Name: "tf-analyzer", Version: ToolVersion, Rules: sarifRules}},
+			Results: results,
+		}},
+	}
+}
+
+func sarifResult(result AnalysisResult, ruleID, resourceType, name, message string) SARIFResult {
+	address := resourceType + "." + name
+	return SARIFResult{
+		RuleID:  ruleID,
+		Level:   "warning",
+		Message: SARIFMessage{Text: message},
+		Locations: []SARIFLocation{{LogicalLocations: []SARIFLogicalLocation{{
+			Name:               address,
+			FullyQualifiedName: result.Organization + "/" + result.RepoName + "/" + address,
+			Kind:               "resource",
+		}}}},
+	}
+}
+
+func (r *Reporter) ExportSARIF(filename string) error {
+	jsonData, err := json.MarshalIndent(r.SARIF(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal SARIF: %w", err)
+	}
+
+	_, err = script.Echo(string(jsonData)).WriteFile(filename)
+	if err != nil {
+		return fmt.Errorf("failed to write SARIF file: %w", err)
+	}
+
+	slog.Info("SARIF report exported", "file", filename)
+	return nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestSARIFReport(t *testing.T) {
+	// Given: a repository with untagged and invalid-tag resources, and a failed repository
+	reporter := NewReporter()
+	reporter.AddResults([]AnalysisResult{
+		{
+			RepoName:     "network",
+			Organization: "acme",
+			Analysis: RepositoryAnalysis{ResourceAnalysis: ResourceAnalysis{
+				UntaggedResources: []UntaggedResource{
+					{ResourceType: "aws_vpc", Name: "main", MissingTags: []string{"Owner", "Project"}},
+				},
+				InvalidTagResources: []InvalidTagResource{
+					{ResourceType: "aws_subnet", Name: "private", InvalidTags: []InvalidTagValue{{Tag: "Environment", Value: "qa", Pattern: "^(prod|dev)$"}}},
+				},
+			}},
+		},
+		{RepoName: "broken", Organization: "acme", Error: os.ErrNotExist},
+	})
+	viper.Reset()
+	tempDir := t.TempDir()
+	viper.Set("output.format", "sarif")
+	viper.Set("output.directory", tempDir)
+
+	// When: reports are generated with --format sarif
+	if err := generateReports(reporter, Config{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Then: the file should hold a SARIF 2.1.0 log with one run and a result per finding
+	content, err := os.ReadFile(filepath.Join(tempDir, SARIFReportFileName))
+	if err != nil {
+		t.Fatalf("Expected SARIF file: %v", err)
+	}
+	var log map[string]any
+	if err := json.Unmarshal(content, &log); err != nil {
+		t.Fatalf("Expected a JSON object: %v", err)
+	}
+	if log["version"] != SARIFVersion || log["$schema"] == nil {
+		t.Errorf("Expected version %s and $schema, got %v and %v", SARIFVersion, log["version"], log["$schema"])
+	}
+	runs, ok := log["runs"].([]any)
+	if !ok || len(runs) != 1 {
+		t.Fatalf("Expected exactly one run, got %v", log["runs"])
+	}
+	run := runs[0].(map[string]any)
+	driver := run["tool"].(map[string]any)["driver"].(map[string]any)
+	if driver["name"] != "tf-analyzer" {
+		t.Errorf("Expected tool driver tf-analyzer, got %v", driver["name"])
+	}
+
+	results := run["results"].([]any)
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	expected := []struct{ ruleID, location, message string }{
+		{SARIFRuleMissingTags, "aws_vpc.main", "aws_vpc.main is missing tags: Owner, Project"},
+		{SARIFRuleInvalidTagValue, "aws_subnet.private", `aws_subnet.private tag Environment="qa" does not match ^(prod|dev)$`},
+	}
+	for i, want := range expected {
+		result := results[i].(map[string]any)
+		if result["ruleId"] != want.ruleID {
+			t.Errorf("Expected ruleId %s, got %v", want.ruleID, result["ruleId"])
+		}
+		if text := result["message"].(map[string]any)["text"]; text != want.message {
+			t.Errorf("Expected message %q, got %v", want.message, text)
+		}
+		location := result["locations"].([]any)[0].(map[string]any)["logicalLocations"].([]any)[0].(map[string]any)
+		if location["name"] != want.location || location["fullyQualifiedName"] != "acme/network/"+want.location {
+			t.Errorf("Expected location %s, got %v", want.location, location)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(tempDir, JSONReportFileName)); !os.IsNotExist(err) {
+		t.Errorf("Expected no full JSON report, got stat error %v", err)
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ============================================================================
+// SCHEMA - JSON Schema for the report written by ExportJSON
+// ============================================================================
+
+const (
+	JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+	ReportSchemaID  = "https://github.com/lprior-repo/tf-analyzer/schemas/terraform-analysis-report.json"
+)
+
+// JSONSchema is the subset of JSON Schema the report generator emits
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	ID                   string                 `json:"$id,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Type                 any                    `json:"type,omitempty"` // A type name, or a list when null is allowed
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties any                    `json:"additionalProperties,omitempty"` // false or a value schema
+	Items                *JSONSchema            `json:"items,omitempty"`
+	AnyOf                []*JSONSchema          `json:"anyOf,omitempty"`
+	Defs                 map[string]*JSONSchema `json:"$defs,omitempty"`
+}
+
+// reportSchema derives the schema from ComprehensiveReport and its json tags,
+// so it cannot drift from what ExportJSON writes. Each struct type becomes a
+// $defs entry; nil slices, maps and pointers are allowed to be null because
+// encoding/json writes them that way.
+func reportSchema() *JSONSchema {
+	generator := schemaGenerator{defs: make(map[string]*JSONSchema)}
+	root := generator.structSchema(reflect.TypeOf(ComprehensiveReport{}))
+	root.Schema = JSONSchemaDraft
+	root.ID = ReportSchemaID
+	root.Title = "tf-analyzer report"
+	root.Defs = generator.defs
+	return root
+}
+
+func reportSchemaJSON() ([]byte, error) {
+	data, err := json.MarshalIndent(reportSchema(), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal report schema: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+type schemaGenerator struct {
+	defs map[string]*JSONSchema
+}
+
+func (g schemaGenerator) typeSchema(t reflect.Type) *JSONSchema {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return nullable(g.typeSchema(t.Elem()))
+	case reflect.Struct:
+		if _, exists := g.defs[t.Name()]; !exists {
+			g.defs[t.Name()] = nil // Reserve the name so recursive types terminate
+			g.defs[t.Name()] = g.structSchema(t)
+		}
+		return &JSONSchema{Ref: "#/$defs/" + t.Name()}
+	case reflect.Slice, reflect.Array:
+		return &JSONSchema{Type: []string{"array", "null"}, Items: g.typeSchema(t.Elem())}
+	case reflect.Map:
+		return &JSONSchema{Type: []string{"object", "null"}, AdditionalProperties: g.typeSchema(t.Elem())}
+	case reflect.String:
+		return &JSONSchema{Type: "string"}
+	case reflect.Bool:
+		return &JSONSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &JSONSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &JSONSchema{Type: "number"}
+	}
+	return &JSONSchema{} // Interfaces accept any value
+}
+
+func nullable(schema *JSONSchema) *JSONSchema {
+	if types, ok := schema.Type.([]string); ok && len(types) == 2 && types[1] == "null" {
+		return schema
+	}
+	if name, ok := schema.Type.(string); ok {
+		schema.Type = []string{name, "null"}
+		return schema
+	}
+	return &JSONSchema{AnyOf: []*JSONSchema{schema, {Type: "null"}}}
+}
+
+// structSchema lists exported fields under their json names, inlining
+// embedded structs the way encoding/json does. Fields without omitempty are
+// always written and therefore required.
+func (g schemaGenerator) structSchema(t reflect.Type) *JSONSchema {
+	schema := &JSONSchema{Type: "object", Properties: make(map[string]*JSONSchema), AdditionalProperties: false}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, omitEmpty, ok := jsonFieldName(field)
+		if !ok {
+			continue
+		}
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && field.Tag.Get("json") == "" {
+			embedded := g.structSchema(field.Type)
+			for property, propertySchema := range embedded.Properties {
+				schema.Properties[property] = propertySchema
+			}
+			schema.Required = append(schema.Required, embedded.Required...)
+			continue
+		}
+		schema.Properties[name] = g.typeSchema(field.Type)
+		if !omitEmpty {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	return schema
+}
+
+func jsonFieldName(field reflect.StructField) (string, bool, bool) {
+	if !field.IsExported() && !field.Anonymous {
+		return "", false, false
+	}
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, false
+	}
+	name, options, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+	return name, strings.Contains(options, "omitempty"), true
+}
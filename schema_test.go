@@ -0,0 +1,247 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// validateJSONSchema checks value against the subset of JSON Schema that
+// reportSchema emits and returns every violation found
+func validateJSONSchema(schema *JSONSchema, defs map[string]*JSONSchema, value any, path string) []string {
+	if schema.Ref != "" {
+		return validateJSONSchema(defs[strings.TrimPrefix(schema.Ref, "#/$defs/")], defs, value, path)
+	}
+	if len(schema.AnyOf) > 0 {
+		for _, option := range schema.AnyOf {
+			if len(validateJSONSchema(option, defs, value, path)) == 0 {
+				return nil
+			}
+		}
+		return []string{fmt.Sprintf("%s: matches no anyOf option", path)}
+	}
+
+	var types []string
+	switch t := schema.Type.(type) {
+	case string:
+		types = []string{t}
+	case []string:
+		types = t
+	}
+	if len(types) > 0 && !slices.Contains(types, jsonTypeOf(value)) &&
+		!(jsonTypeOf(value) == "integer" && slices.Contains(types, "number")) {
+		return []string{fmt.Sprintf("%s: expected %v, got %s", path, types, jsonTypeOf(value))}
+	}
+
+	var violations []string
+	switch v := value.(type) {
+	case map[string]any:
+		for _, name := range schema.Required {
+			if _, exists := v[name]; !exists {
+				violations = append(violations, fmt.Sprintf("%s: missing required %s", path, name))
+			}
+		}
+		for name, item := range v {
+			if property, exists := schema.Properties[name]; exists {
+				violations = append(violations, validateJSONSchema(property, defs, item, path+"."+name)...)
+			} else if additional, ok := schema.AdditionalProperties.(*JSONSchema); ok {
+				violations = append(violations, validateJSONSchema(additional, defs, item, path+"."+name)...)
+			} else if schema.AdditionalProperties == false {
+				violations = append(violations, fmt.Sprintf("%s: unexpected property %s", path, name))
+			}
+		}
+	case []any:
+		for i, item := range v {
+			violations = append(violations, validateJSONSchema(schema.Items, defs, item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	}
+	return violations
+}
+
+func jsonTypeOf(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if v == float64(int64(v)) {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	}
+	return "object"
+}
+
+func TestReportSchema(t *testing.T) {
+	// The analyzer's report must validate against the schema it publishes
+	repoDir := createTempTerraformRepo(t, map[string]string{
+		"main.tf": `terraform {
+  required_version = ">= 1.5"
+  backend "s3" {
+    region = "us-east-1"
+  }
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 5.0"
+    }
+  }
+}
+
+provider "aws" {
+  region = "us-east-1"
+}
+
+module "vpc" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "5.1.0"
+}
+
+module "legacy" {
+  source = "git::https://example.com/legacy.git"
+}
+
+resource "aws_instance" "web" {
+  password = "hunter2hunter2"
+  tags = {
+    Environment = "prod"
+  }
+}
+
+data "aws_ami" "ubuntu" {}
+
+variable "region" {
+  default = "us-east-1"
+}
+
+output "id" {
+  value = aws_instance.web.id
+}
+`,
+	})
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	analysis, err := analyzeRepositoryWithOptions(repoDir, analysisOptionsFromConfig(Config{ScanSecrets: true}), logger)
+	if err != nil {
+		t.Fatalf("Expected analysis to succeed, got %v", err)
+	}
+
+	schemaJSON, err := reportSchemaJSON()
+	if err != nil {
+		t.Fatalf("Expected schema to marshal, got %v", err)
+	}
+	var schema JSONSchema
+	if err := json.Unmarshal(schemaJSON, &schema); err != nil {
+		t.Fatalf("Expected schema to round-trip, got %v", err)
+	}
+	normalizeDecodedSchema(&schema)
+
+	validate := func(t *testing.T, report any) []string {
+		data, err := json.Marshal(report)
+		if err != nil {
+			t.Fatalf("Expected report to marshal, got %v", err)
+		}
+		var decoded any
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Expected report to decode, got %v", err)
+		}
+		return validateJSONSchema(&schema, schema.Defs, decoded, "$")
+	}
+
+	t.Run("analyzer report validates", func(t *testing.T) {
+		// Given: a report with a successful and a failed repository
+		reporter := NewReporter()
+		reporter.AddResults([]AnalysisResult{
+			{RepoName: "network", Organization: "acme", Analysis: analysis, Warnings: []string{"slow"}},
+			{RepoName: "broken", Organization: "acme", Error: os.ErrNotExist},
+		})
+
+		// When/Then: the report should satisfy the schema
+		if violations := validate(t, reporter.GenerateReport()); len(violations) > 0 {
+			t.Errorf("Expected report to validate, got:\n%s", strings.Join(violations, "\n"))
+		}
+	})
+
+	t.Run("empty report validates", func(t *testing.T) {
+		if violations := validate(t, NewReporter().GenerateReport()); len(violations) > 0 {
+			t.Errorf("Expected empty report to validate, got:\n%s", strings.Join(violations, "\n"))
+		}
+	})
+
+	t.Run("mismatched documents are rejected", func(t *testing.T) {
+		report := map[string]any{
+			"repositories":   []any{map[string]any{"providers": map[string]any{"unique_provider_count": "one"}}},
+			"global_summary": map[string]any{},
+			"extra":          true,
+		}
+		violations := strings.Join(validate(t, report), "\n")
+		for _, expected := range []string{"unexpected property extra", "missing required total_repos_scanned", "$.repositories[0].providers.unique_provider_count: expected"} {
+			if !strings.Contains(violations, expected) {
+				t.Errorf("Expected violation %q, got:\n%s", expected, violations)
+			}
+		}
+	})
+
+	t.Run("schema command writes the schema file", func(t *testing.T) {
+		// Given: an output path
+		schemaOutput = filepath.Join(t.TempDir(), "report.schema.json")
+		defer func() { schemaOutput = "" }()
+
+		// When: the schema command runs
+		if err := printSchema(schemaCmd, nil); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then: the file should hold the generated schema
+		written, err := os.ReadFile(schemaOutput)
+		if err != nil {
+			t.Fatalf("Expected schema file, got %v", err)
+		}
+		if string(written) != string(schemaJSON) {
+			t.Error("Expected the written schema to match reportSchemaJSON")
+		}
+	})
+}
+
+// normalizeDecodedSchema restores the Go types json.Unmarshal loses for the
+// interface-typed Type and AdditionalProperties fields
+func normalizeDecodedSchema(schema *JSONSchema) {
+	if schema == nil {
+		return
+	}
+	if types, ok := schema.Type.([]any); ok {
+		names := make([]string, len(types))
+		for i, name := range types {
+			names[i] = name.(string)
+		}
+		schema.Type = names
+	}
+	if additional, ok := schema.AdditionalProperties.(map[string]any); ok {
+		data, _ := json.Marshal(additional)
+		var decoded JSONSchema
+		_ = json.Unmarshal(data, &decoded)
+		schema.AdditionalProperties = &decoded
+	}
+	if additional, ok := schema.AdditionalProperties.(*JSONSchema); ok {
+		normalizeDecodedSchema(additional)
+	}
+	normalizeDecodedSchema(schema.Items)
+	for _, option := range schema.AnyOf {
+		normalizeDecodedSchema(option)
+	}
+	for _, property := range schema.Properties {
+		normalizeDecodedSchema(property)
+	}
+	for _, def := range schema.Defs {
+		normalizeDecodedSchema(def)
+	}
+}
@@ -0,0 +1,303 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ============================================================================
+// SECRETS - Best-effort detection of credentials embedded in Terraform
+// ============================================================================
+
+// SecretFinding records a likely credential found in a resource attribute
+type SecretFinding struct {
+	ResourceType string `json:"resource_type"`
+	ResourceName string `json:"resource_name"`
+	Attribute    string `json:"attribute"`
+	File         string `json:"file"`
+	Line         int    `json:"line"`
+	Rule         string `json:"rule"`
+	Match        string `json:"match"` // Redacted excerpt of the detected value
+}
+
+type secretRule struct {
+	ID      string
+	Pattern *regexp.Regexp
+	Group   int // Capture group holding the secret value; 0 means the whole match
+}
+
+var secretRules = []secretRule{
+	{ID: "aws-access-key", Pattern: regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{ID: "private-key", Pattern: regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`)},
+	{ID: "secret-assignment", Pattern: regexp.MustCompile(`(?i)\b[a-z0-9_]*(?:password|passwd|secret|token|api_?key)[a-z0-9_]*["']?\s*[:=]\s*["']?([^\s"'$]{6,})`), Group: 1},
+}
+
+var sensitiveKeyPattern = regexp.MustCompile(`(?i)(password|passwd|secret|token|api_?key|private_?key)`)
+
+// sensitiveAttributePattern is stricter than sensitiveKeyPattern because it
+// applies to every resource attribute: secret_name or token_type hold no
+// credential, while password, client_secret and auth_token do.
+var sensitiveAttributePattern = regexp.MustCompile(`(?i)(?:^|_)(?:password|passwd|secret|token|api_?key|access_key|secret_key|private_key)$`)
+
+// Functions whose literal arguments commonly carry rendered user data
+var secretCarrierFunctions = map[string]bool{
+	"jsonencode":   true,
+	"yamlencode":   true,
+	"base64encode": true,
+	"base64gzip":   true,
+}
+
+// secretScanContext carries the location of the attribute being scanned
+type secretScanContext struct {
+	Source       []byte
+	File         string
+	ResourceType string
+	ResourceName string
+	Attribute    string
+}
+
+// scanSecrets inspects resource, data, provider and module blocks, backend
+// and cloud settings, variable defaults, local values and the top-level
+// assignments of .tfvars files. Sensitive attributes holding a literal string
+// are reported, as are access keys and private keys in any literal, heredoc
+// strings such as EC2 user_data, and literal arguments of encoding functions.
+// References such as var.password are never reported.
+func scanSecrets(content, filename string) []SecretFinding {
+	body, source, _ := parseHCLSource(content, filename)
+	if body == nil {
+		return nil
+	}
+
+	var findings []SecretFinding
+	for _, block := range body.Blocks {
+		if resourceType, values, ok := namedValueAttributes(block); ok {
+			for name, attr := range values {
+				ctx := secretScanContext{Source: source, File: filename, ResourceType: resourceType, ResourceName: name, Attribute: name}
+				findings = append(findings, scanAttributeForSecrets(attr, ctx)...)
+			}
+			continue
+		}
+		// Backend and cloud settings sit inside the terraform block
+		scanned := []*hclsyntax.Block{block}
+		if block.Type == "terraform" {
+			scanned = block.Body.Blocks
+		}
+		for _, scannedBlock := range scanned {
+			ctx, ok := secretScanContextFor(scannedBlock)
+			if !ok {
+				continue
+			}
+			ctx.Source, ctx.File = source, filename
+			findings = append(findings, scanBlockForSecrets(scannedBlock.Body, "", ctx)...)
+		}
+	}
+	for name, attr := range body.Attributes {
+		ctx := secretScanContext{Source: source, File: filename, ResourceType: "var", ResourceName: name, Attribute: name}
+		findings = append(findings, scanAttributeForSecrets(attr, ctx)...)
+	}
+	return findings
+}
+
+// secretScanContextFor names the block findings are attributed to
+func secretScanContextFor(block *hclsyntax.Block) (secretScanContext, bool) {
+	switch {
+	case block.Type == "resource" && len(block.Labels) >= 2:
+		return secretScanContext{ResourceType: block.Labels[0], ResourceName: block.Labels[1]}, true
+	case block.Type == "data" && len(block.Labels) >= 2:
+		return secretScanContext{ResourceType: "data." + block.Labels[0], ResourceName: block.Labels[1]}, true
+	case (block.Type == "provider" || block.Type == "module" || block.Type == "backend") && len(block.Labels) >= 1:
+		return secretScanContext{ResourceType: block.Type, ResourceName: block.Labels[0]}, true
+	case block.Type == "cloud":
+		return secretScanContext{ResourceType: "terraform", ResourceName: "cloud"}, true
+	}
+	return secretScanContext{}, false
+}
+
+// namedValueAttributes returns a variable's default or a locals block's
+// values keyed by the name they are referenced by, since that name rather
+// than the attribute says what they hold, e.g. var.db_password or
+// local.api_token. Variables without a default yield no attributes.
+func namedValueAttributes(block *hclsyntax.Block) (string, map[string]*hclsyntax.Attribute, bool) {
+	switch {
+	case block.Type == "variable" && len(block.Labels) >= 1:
+		attributes := make(map[string]*hclsyntax.Attribute)
+		if attr, ok := block.Body.Attributes["default"]; ok {
+			attributes[block.Labels[0]] = attr
+		}
+		return "var", attributes, true
+	case block.Type == "locals":
+		return "local", block.Body.Attributes, true
+	}
+	return "", nil, false
+}
+
+func scanBlockForSecrets(body *hclsyntax.Body, prefix string, ctx secretScanContext) []SecretFinding {
+	var findings []SecretFinding
+	for name, attr := range body.Attributes {
+		ctx.Attribute = prefix + name
+		findings = append(findings, scanAttributeForSecrets(attr, ctx)...)
+	}
+	for _, nested := range body.Blocks {
+		findings = append(findings, scanBlockForSecrets(nested.Body, prefix+nested.Type+".", ctx)...)
+	}
+	return findings
+}
+
+// scanAttributeForSecrets checks a plain literal value directly and hands
+// every other expression to scanExpressionForSecrets
+func scanAttributeForSecrets(attr *hclsyntax.Attribute, ctx secretScanContext) []SecretFinding {
+	if template, ok := attr.Expr.(*hclsyntax.TemplateExpr); ok && isHeredoc(template, ctx.Source) {
+		return scanExpressionForSecrets(attr.Expr, false, ctx)
+	}
+	value, ok := literalString(attr.Expr)
+	if !ok {
+		return scanExpressionForSecrets(attr.Expr, false, ctx)
+	}
+
+	// Prefer the specific rule, e.g. aws-access-key for access_key = "AKIA..."
+	line := attr.Expr.Range().Start.Line
+	if findings := scanTextForSecrets(value, line, ctx); len(findings) > 0 {
+		return findings
+	}
+	// The scanned name, which for variable defaults is the variable's name
+	name := ctx.Attribute[strings.LastIndex(ctx.Attribute, ".")+1:]
+	if sensitiveAttributePattern.MatchString(name) && strings.TrimSpace(value) != "" {
+		return []SecretFinding{*newSecretFinding(ctx, "sensitive-attribute", line, value)}
+	}
+	return nil
+}
+
+// scanExpressionForSecrets walks an expression; literals are only scanned
+// when they are heredocs or sit inside a secret carrier function call.
+func scanExpressionForSecrets(expr hclsyntax.Expression, inCarrier bool, ctx secretScanContext) []SecretFinding {
+	switch e := expr.(type) {
+	case *hclsyntax.TemplateExpr:
+		if inCarrier || isHeredoc(e, ctx.Source) {
+			return scanTemplateForSecrets(e, ctx)
+		}
+	case *hclsyntax.FunctionCallExpr:
+		carrier := inCarrier || secretCarrierFunctions[e.Name]
+		var findings []SecretFinding
+		for _, arg := range e.Args {
+			findings = append(findings, scanExpressionForSecrets(arg, carrier, ctx)...)
+		}
+		return findings
+	case *hclsyntax.ObjectConsExpr:
+		return scanObjectForSecrets(e, inCarrier, ctx)
+	case *hclsyntax.TupleConsExpr:
+		var findings []SecretFinding
+		for _, item := range e.Exprs {
+			findings = append(findings, scanExpressionForSecrets(item, inCarrier, ctx)...)
+		}
+		return findings
+	}
+	return nil
+}
+
+func scanObjectForSecrets(expr *hclsyntax.ObjectConsExpr, inCarrier bool, ctx secretScanContext) []SecretFinding {
+	var findings []SecretFinding
+	for _, item := range expr.Items {
+		if inCarrier {
+			if finding := checkSensitiveObjectItem(item, ctx); finding != nil {
+				findings = append(findings, *finding)
+				continue
+			}
+		}
+		findings = append(findings, scanExpressionForSecrets(item.ValueExpr, inCarrier, ctx)...)
+	}
+	return findings
+}
+
+func checkSensitiveObjectItem(item hclsyntax.ObjectConsItem, ctx secretScanContext) *SecretFinding {
+	key, ok := literalString(item.KeyExpr)
+	if !ok || !sensitiveKeyPattern.MatchString(key) {
+		return nil
+	}
+	value, ok := literalString(item.ValueExpr)
+	if !ok || strings.TrimSpace(value) == "" {
+		return nil
+	}
+	return newSecretFinding(ctx, "sensitive-key", item.ValueExpr.Range().Start.Line, value)
+}
+
+func scanTemplateForSecrets(expr *hclsyntax.TemplateExpr, ctx secretScanContext) []SecretFinding {
+	var findings []SecretFinding
+	for _, part := range expr.Parts {
+		literal, ok := part.(*hclsyntax.LiteralValueExpr)
+		if !ok || literal.Val.Type() != cty.String || !literal.Val.IsKnown() || literal.Val.IsNull() {
+			continue
+		}
+		findings = append(findings, scanTextForSecrets(literal.Val.AsString(), literal.Range().Start.Line, ctx)...)
+	}
+	return findings
+}
+
+// scanTextForSecrets applies secretRules to text starting at startLine
+func scanTextForSecrets(text string, startLine int, ctx secretScanContext) []SecretFinding {
+	var findings []SecretFinding
+	for _, rule := range secretRules {
+		for _, match := range rule.Pattern.FindAllStringSubmatchIndex(text, -1) {
+			start, end := match[2*rule.Group], match[2*rule.Group+1]
+			line := startLine + strings.Count(text[:match[0]], "\n")
+			findings = append(findings, *newSecretFinding(ctx, rule.ID, line, text[start:end]))
+		}
+	}
+	return findings
+}
+
+func newSecretFinding(ctx secretScanContext, rule string, line int, value string) *SecretFinding {
+	return &SecretFinding{
+		ResourceType: ctx.ResourceType,
+		ResourceName: ctx.ResourceName,
+		Attribute:    ctx.Attribute,
+		File:         ctx.File,
+		Line:         sourceLine(ctx.File, line),
+		Rule:         rule,
+		Match:        redactSecret(value),
+	}
+}
+
+func isHeredoc(expr *hclsyntax.TemplateExpr, source []byte) bool {
+	start := expr.Range().Start.Byte
+	return start+2 <= len(source) && string(source[start:start+2]) == "<<"
+}
+
+func literalString(expr hclsyntax.Expression) (string, bool) {
+	switch e := expr.(type) {
+	case *hclsyntax.ObjectConsKeyExpr:
+		if name := bareIdentifier(e.Wrapped); name != "" {
+			return name, true
+		}
+		return literalString(e.Wrapped)
+	case *hclsyntax.TemplateExpr:
+		if !e.IsStringLiteral() {
+			return "", false
+		}
+	}
+
+	value, diags := expr.Value(nil)
+	if diags.HasErrors() || !value.IsKnown() || value.IsNull() || value.Type() != cty.String {
+		return "", false
+	}
+	return value.AsString(), true
+}
+
+// bareIdentifier returns the bare identifier used as an object key
+func bareIdentifier(expr hclsyntax.Expression) string {
+	traversal, ok := expr.(*hclsyntax.ScopeTraversalExpr)
+	if !ok || len(traversal.Traversal) != 1 {
+		return ""
+	}
+	return traversal.Traversal.RootName()
+}
+
+// redactSecret keeps a short prefix so reviewers can locate the value
+func redactSecret(value string) string {
+	if len(value) <= 8 {
+		return "****"
+	}
+	return value[:4] + "****"
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// ============================================================================
+// SERVE - HTTP dashboard for a JSON report (tf-analyzer serve)
+// ============================================================================
+
+const (
+	// DefaultServeAddr only listens locally; pass --addr :8080 to expose the dashboard
+	DefaultServeAddr = "localhost:8080"
+	// DefaultServeWatchInterval is how often --watch checks the report file for changes
+	DefaultServeWatchInterval = 2 * time.Second
+	// serveShutdownTimeout bounds how long in-flight requests may finish after a signal
+	serveShutdownTimeout = 5 * time.Second
+)
+
+var (
+	serveReport string
+	serveAddr   string
+	serveWatch  bool
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve a JSON report as an HTML dashboard",
+	Long: `
+# Report Server
+
+Serve a JSON report written by "tf-analyzer analyze" over HTTP: the HTML
+report at / and the raw JSON at /api/report. The report is loaded at startup;
+with --watch it is reloaded whenever the file changes.
+
+## Examples
+
+	# Browse the latest report at http://localhost:8080
+	tf-analyzer serve --report terraform-analysis-report.json
+	
+	# Listen on every interface and pick up reports rewritten by a nightly run
+	tf-analyzer serve --report reports/terraform-analysis-report.json --addr :8080 --watch
+	`,
+	Args: cobra.NoArgs,
+	RunE: runServe,
+}
+
+func initializeServeFlags() {
+	serveCmd.Flags().StringVar(&serveReport, "report", "", "path to a JSON report written by analyze (required)")
+	serveCmd.Flags().StringVar(&serveAddr, "addr", DefaultServeAddr, "address to listen on, host:port")
+	serveCmd.Flags().BoolVar(&serveWatch, "watch", false, "reload the report when the file changes")
+	if err := serveCmd.MarkFlagRequired("report"); err != nil {
+		panic(fmt.Sprintf("Failed to mark report flag required: %v", err))
+	}
+}
+
+// reportServer holds the rendered report; reloads swap it under mu
+type reportServer struct {
+	path    string
+	mu      sync.RWMutex
+	json    []byte
+	html    string
+	modTime time.Time
+}
+
+// newReportServer loads the report at path, failing if it is not a JSON report
+func newReportServer(path string) (*reportServer, error) {
+	server := &reportServer{path: path}
+	if err := server.load(); err != nil {
+		return nil, err
+	}
+	return server, nil
+}
+
+func (s *reportServer) load() error {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return fmt.Errorf("failed to read report: %w", err)
+	}
+	content, err := os.ReadFile(s.path)
+	if err != nil {
+		return fmt.Errorf("failed to read report: %w", err)
+	}
+	var report ComprehensiveReport
+	if err := json.Unmarshal(content, &report); err != nil {
+		return fmt.Errorf("failed to parse report %s: %w", s.path, err)
+	}
+	// The JSON report does not record the mandatory tag set, so it is left out
+	html, err := renderHTMLReport(report, nil)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.json, s.html, s.modTime = content, html, info.ModTime()
+	return nil
+}
+
+// reloadIfChanged reloads the report when its modification time changes; a
+// report that fails to load is logged and the previous one keeps being served
+func (s *reportServer) reloadIfChanged(logger *slog.Logger) {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		logger.Warn("Failed to check report for changes", "file", s.path, "error", err)
+		return
+	}
+	s.mu.RLock()
+	unchanged := info.ModTime().Equal(s.modTime)
+	s.mu.RUnlock()
+	if unchanged {
+		return
+	}
+
+	if err := s.load(); err != nil {
+		logger.Warn("Failed to reload report; serving the previous one", "file", s.path, "error", err)
+		return
+	}
+	logger.Info("Report reloaded", "file", s.path)
+}
+
+func (s *reportServer) watch(ctx context.Context, interval time.Duration, logger *slog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.reloadIfChanged(logger)
+		}
+	}
+}
+
+func (s *reportServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, _ *http.Request) {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(s.html))
+	})
+	mux.HandleFunc("GET /api/report", func(w http.ResponseWriter, _ *http.Request) {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(s.json)
+	})
+	return mux
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	logger := setupAnalysisLogger()
+	server, err := newReportServer(serveReport)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if serveWatch {
+		go server.watch(ctx, DefaultServeWatchInterval, logger)
+	}
+
+	httpServer := &http.Server{
+		Addr:              serveAddr,
+		Handler:           server.handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), serveShutdownTimeout)
+		defer cancel()
+		_ = httpServer.Shutdown(shutdownCtx)
+	}()
+
+	logger.Info("Serving report", "file", serveReport, "addr", serveAddr, "watch", serveWatch)
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("report server failed: %w", err)
+	}
+	return nil
+}
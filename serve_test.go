@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReportServer(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	writeReport := func(t *testing.T, path string, repoNames ...string) {
+		t.Helper()
+		reporter := NewReporter()
+		for _, name := range repoNames {
+			reporter.AddResults([]AnalysisResult{{RepoName: name, Organization: "acme", Analysis: RepositoryAnalysis{RepositoryPath: "/clones/acme/" + name}}})
+		}
+		if err := reporter.ExportJSON(path); err != nil {
+			t.Fatalf("Failed to write report: %v", err)
+		}
+	}
+	get := func(t *testing.T, url string) (*http.Response, string) {
+		t.Helper()
+		response, err := http.Get(url)
+		if err != nil {
+			t.Fatalf("Expected a response, got %v", err)
+		}
+		defer func() { _ = response.Body.Close() }()
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			t.Fatalf("Failed to read body: %v", err)
+		}
+		return response, string(body)
+	}
+
+	// Given: a server for a report with one repository
+	reportPath := filepath.Join(t.TempDir(), JSONReportFileName)
+	writeReport(t, reportPath, "network")
+	reportServer, err := newReportServer(reportPath)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	server := httptest.NewServer(reportServer.handler())
+	defer server.Close()
+
+	t.Run("serves the HTML report at /", func(t *testing.T) {
+		response, body := get(t, server.URL+"/")
+
+		if response.StatusCode != http.StatusOK || !strings.HasPrefix(response.Header.Get("Content-Type"), "text/html") {
+			t.Errorf("Expected 200 text/html, got %d %s", response.StatusCode, response.Header.Get("Content-Type"))
+		}
+		if !strings.Contains(body, "<td>network</td>") {
+			t.Errorf("Expected the repository row, got %s", body)
+		}
+	})
+
+	t.Run("serves the raw JSON at /api/report", func(t *testing.T) {
+		response, body := get(t, server.URL+"/api/report")
+
+		if response.StatusCode != http.StatusOK || response.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Expected 200 application/json, got %d %s", response.StatusCode, response.Header.Get("Content-Type"))
+		}
+		var report ComprehensiveReport
+		if err := json.Unmarshal([]byte(body), &report); err != nil || len(report.Repositories) != 1 {
+			t.Errorf("Expected the report with one repository, got %s (%v)", body, err)
+		}
+	})
+
+	t.Run("other paths are not found", func(t *testing.T) {
+		if response, _ := get(t, server.URL+"/missing"); response.StatusCode != http.StatusNotFound {
+			t.Errorf("Expected 404, got %d", response.StatusCode)
+		}
+	})
+
+	t.Run("reloads a changed report and keeps serving after a bad write", func(t *testing.T) {
+		// When: the report is rewritten with a second repository
+		writeReport(t, reportPath, "network", "storage")
+		later := time.Now().Add(time.Minute)
+		if err := os.Chtimes(reportPath, later, later); err != nil {
+			t.Fatalf("Failed to touch report: %v", err)
+		}
+		reportServer.reloadIfChanged(logger)
+
+		// Then: the new repository is served
+		if _, body := get(t, server.URL+"/"); !strings.Contains(body, "<td>storage</td>") {
+			t.Errorf("Expected the reloaded report, got %s", body)
+		}
+
+		// And: an unparseable rewrite leaves the previous report in place
+		if err := os.WriteFile(reportPath, []byte("{"), 0644); err != nil {
+			t.Fatalf("Failed to write report: %v", err)
+		}
+		evenLater := later.Add(time.Minute)
+		if err := os.Chtimes(reportPath, evenLater, evenLater); err != nil {
+			t.Fatalf("Failed to touch report: %v", err)
+		}
+		reportServer.reloadIfChanged(logger)
+		if _, body := get(t, server.URL+"/"); !strings.Contains(body, "<td>storage</td>") {
+			t.Errorf("Expected the previous report to be served, got %s", body)
+		}
+	})
+
+	t.Run("rejects reports that are not JSON", func(t *testing.T) {
+		invalidPath := filepath.Join(t.TempDir(), "report.json")
+		if err := os.WriteFile(invalidPath, []byte("not json"), 0644); err != nil {
+			t.Fatalf("Failed to write report: %v", err)
+		}
+		if _, err := newReportServer(invalidPath); err == nil {
+			t.Error("Expected an error for an invalid report")
+		}
+		if _, err := newReportServer(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+			t.Error("Expected an error for a missing report")
+		}
+	})
+}
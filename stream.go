@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ============================================================================
+// STREAM - JSON Lines output of each repository result as it completes
+// ============================================================================
+
+// StreamRecord is the JSON line written to --stream-output for one repository
+type StreamRecord struct {
+	Organization string              `json:"organization"`
+	Repo         string              `json:"repo"`
+	Error        string              `json:"error,omitempty"`
+	Warnings     []string            `json:"warnings,omitempty"`
+	Analysis     *RepositoryAnalysis `json:"analysis,omitempty"`
+}
+
+// ResultStream appends one line per result. Each line is written with a
+// single unbuffered write, so a killed run keeps every completed repository.
+type ResultStream struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// openResultStream truncates path so each run starts a fresh stream,
+// creating its directory like the reports do
+func openResultStream(path string) (*ResultStream, error) {
+	if err := ensureOutputDirectory(filepath.Dir(path)); err != nil {
+		return nil, fmt.Errorf("failed to open stream output %s: %w", path, err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open stream output %s: %w", path, err)
+	}
+	return &ResultStream{file: file}, nil
+}
+
+func newStreamRecord(result AnalysisResult) StreamRecord {
+	record := StreamRecord{
+		Organization: result.Organization,
+		Repo:         result.RepoName,
+		Warnings:     result.Warnings,
+	}
+	if result.Error != nil {
+		record.Error = result.Error.Error()
+		return record
+	}
+	analysis := result.Analysis
+	record.Analysis = &analysis
+	return record
+}
+
+// Write appends the result; a nil stream discards it
+func (s *ResultStream) Write(result AnalysisResult) error {
+	if s == nil {
+		return nil
+	}
+
+	line, err := json.Marshal(newStreamRecord(result))
+	if err != nil {
+		return fmt.Errorf("failed to marshal stream record: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write stream record: %w", err)
+	}
+	return nil
+}
+
+func (s *ResultStream) Close() error {
+	if s == nil {
+		return nil
+	}
+	return s.file.Close()
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/bitfield/script"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// ============================================================================
+// STRUCTURED EXPORTS - YAML and TOML renderings of the JSON report
+// ============================================================================
+
+// reportDocument converts v into the generic document its JSON encoding
+// describes, so YAML and TOML reuse the json tags and omitempty rules of
+// the report types. Whole numbers stay integers rather than becoming floats.
+func reportDocument(v any) (any, error) {
+	jsonData, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	decoder.UseNumber()
+	var document any
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+	return normalizeJSONNumbers(document), nil
+}
+
+func normalizeJSONNumbers(value any) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		for key, element := range typed {
+			typed[key] = normalizeJSONNumbers(element)
+		}
+	case []any:
+		for i, element := range typed {
+			typed[i] = normalizeJSONNumbers(element)
+		}
+	case json.Number:
+		if integer, err := typed.Int64(); err == nil {
+			return integer
+		}
+		float, _ := typed.Float64()
+		return float
+	}
+	return value
+}
+
+func (r *Reporter) ExportYAML(filename string) error {
+	document, err := reportDocument(r.GenerateReport())
+	if err != nil {
+		return fmt.Errorf("failed to marshal YAML: %w", err)
+	}
+
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(document); err != nil {
+		return fmt.Errorf("failed to marshal YAML: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("failed to marshal YAML: %w", err)
+	}
+
+	if _, err := script.Echo(buffer.String()).WriteFile(filename); err != nil {
+		return fmt.Errorf("failed to write YAML file: %w", err)
+	}
+
+	slog.Info("Comprehensive report exported", "file", filename, "type", "YAML")
+	return nil
+}
+
+// ExportTOML writes the JSON report as TOML. TOML has no null, so fields
+// the JSON report writes as null are omitted.
+func (r *Reporter) ExportTOML(filename string) error {
+	document, err := reportDocument(r.GenerateReport())
+	if err != nil {
+		return fmt.Errorf("failed to marshal TOML: %w", err)
+	}
+
+	tomlData, err := toml.Marshal(document)
+	if err != nil {
+		return fmt.Errorf("failed to marshal TOML: %w", err)
+	}
+
+	if _, err := script.Echo(string(tomlData)).WriteFile(filename); err != nil {
+		return fmt.Errorf("failed to write TOML file: %w", err)
+	}
+
+	slog.Info("Comprehensive report exported", "file", filename, "type", "TOML")
+	return nil
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+func TestStructuredReports(t *testing.T) {
+	// Given: an analyzed repository, a failed repository and its JSON report
+	reporter := NewReporter()
+	region := "us-east-1"
+	backendType := "s3"
+	reporter.AddResults([]AnalysisResult{
+		{RepoName: "network", Organization: "acme", Analysis: RepositoryAnalysis{
+			RepositoryPath: "/clones/acme/network",
+			BackendConfig:  &BackendConfig{Type: &backendType, Region: &region},
+			ResourceAnalysis: ResourceAnalysis{
+				TotalResourceCount: 2,
+				UntaggedResources:  []UntaggedResource{{ResourceType: "aws_vpc", Name: "main", MissingTags: []string{"Owner"}}},
+			},
+		}},
+		{RepoName: "broken", Organization: "acme", Error: os.ErrNotExist},
+	})
+	tempDir := t.TempDir()
+	jsonPath := filepath.Join(tempDir, JSONReportFileName)
+	if err := reporter.ExportJSON(jsonPath); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var expected map[string]any
+	readDocument(t, jsonPath, json.Unmarshal, &expected)
+
+	formats := []struct {
+		format    string
+		fileName  string
+		unmarshal func([]byte, any) error
+	}{
+		{"yaml", YAMLReportFileName, yaml.Unmarshal},
+		{"toml", TOMLReportFileName, toml.Unmarshal},
+	}
+	for _, tt := range formats {
+		t.Run(tt.format, func(t *testing.T) {
+			viper.Reset()
+			defer viper.Reset()
+			viper.Set("output.format", tt.format)
+			viper.Set("output.directory", tempDir)
+
+			// When: reports are generated in the format
+			if err := generateReports(reporter, Config{}); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			// Then: the round-tripped document carries the JSON report's keys and values
+			var actual map[string]any
+			readDocument(t, filepath.Join(tempDir, tt.fileName), tt.unmarshal, &actual)
+			for _, keyPath := range [][]any{
+				{"global_summary", "total_repos_scanned"},
+				{"global_summary", "global_backend_summary", "unique_backend_config_count"},
+				{"repositories", 0, "repository_path"},
+				{"repositories", 0, "organization"},
+				{"repositories", 0, "resource_analysis", "total_resource_count"},
+				{"repositories", 0, "resource_analysis", "untagged_resources", 0, "name"},
+				{"failed_repositories", 0, "repository"},
+				{"failed_repositories", 0, "error_kind"},
+			} {
+				want, got := documentValue(expected, keyPath), documentValue(actual, keyPath)
+				if want == nil || fmt.Sprint(want) != fmt.Sprint(got) {
+					t.Errorf("Expected %v to be %v, got %v", keyPath, want, got)
+				}
+			}
+		})
+	}
+}
+
+func readDocument(t *testing.T, path string, unmarshal func([]byte, any) error, document *map[string]any) {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected report file %s: %v", path, err)
+	}
+	if err := unmarshal(content, document); err != nil {
+		t.Fatalf("Expected %s to parse: %v", path, err)
+	}
+}
+
+// documentValue follows string keys and slice indexes through a decoded document
+func documentValue(document any, keyPath []any) any {
+	for _, key := range keyPath {
+		switch typed := key.(type) {
+		case string:
+			object, ok := document.(map[string]any)
+			if !ok {
+				return nil
+			}
+			document = object[typed]
+		case int:
+			array, ok := document.([]any)
+			if !ok || typed >= len(array) {
+				return nil
+			}
+			document = array[typed]
+		}
+	}
+	return document
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/bitfield/script"
+	"github.com/samber/lo"
+)
+
+// ============================================================================
+// SUMMARY - Compact cross-organization rollup for dashboards
+// ============================================================================
+
+// SummaryCounts are the aggregate counts shared by the run and each organization,
+// also exported per organization as Prometheus gauges
+type SummaryCounts struct {
+	TotalRepos        int `json:"total_repos"`
+	ProcessedRepos    int `json:"processed_repos"` // Analyzed successfully
+	FailedRepos       int `json:"failed_repos"`
+	TotalResources    int `json:"total_resources"`
+	UntaggedResources int `json:"untagged_resources"`
+	UniqueProviders   int `json:"unique_providers"` // Distinct provider sources, not per-repository sums
+}
+
+type OrganizationSummary struct {
+	Organization string `json:"organization"`
+	SummaryCounts
+}
+
+// RunSummary is the --format summary document: totals without per-repository detail
+type RunSummary struct {
+	SummaryCounts
+	Organizations []OrganizationSummary `json:"organizations"`
+}
+
+func successfulResults(results []AnalysisResult) []AnalysisResult {
+	return lo.Filter(results, func(result AnalysisResult, _ int) bool {
+		return result.Error == nil
+	})
+}
+
+func summarizeResults(results []AnalysisResult) SummaryCounts {
+	successful := successfulResults(results)
+	providers := lo.Uniq(lo.FlatMap(successful, func(result AnalysisResult, _ int) []string {
+		return lo.Map(result.Analysis.Providers.ProviderDetails, func(provider ProviderDetail, _ int) string {
+			return provider.Source
+		})
+	}))
+
+	return SummaryCounts{
+		TotalRepos:     len(results),
+		ProcessedRepos: len(successful),
+		FailedRepos:    len(results) - len(successful),
+		TotalResources: lo.SumBy(successful, func(result AnalysisResult) int {
+			return result.Analysis.ResourceAnalysis.TotalResourceCount
+		}),
+		UntaggedResources: lo.SumBy(successful, func(result AnalysisResult) int {
+			return len(result.Analysis.ResourceAnalysis.UntaggedResources)
+		}),
+		UniqueProviders: len(providers),
+	}
+}
+
+// Summary rolls the results up for the whole run and for each organization
+func (r *Reporter) Summary() RunSummary {
+	byOrganization := lo.GroupBy(r.results, func(result AnalysisResult) string {
+		return result.Organization
+	})
+	organizations := lo.Keys(byOrganization)
+	slices.Sort(organizations)
+
+	return RunSummary{
+		SummaryCounts: summarizeResults(r.results),
+		Organizations: lo.Map(organizations, func(organization string, _ int) OrganizationSummary {
+			return OrganizationSummary{Organization: organization, SummaryCounts: summarizeResults(byOrganization[organization])}
+		}),
+	}
+}
+
+func (r *Reporter) ExportSummaryJSON(filename string) error {
+	jsonData, err := json.MarshalIndent(r.Summary(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal summary JSON: %w", err)
+	}
+
+	_, err = script.Echo(string(jsonData)).WriteFile(filename)
+	if err != nil {
+		return fmt.Errorf("failed to write summary JSON file: %w", err)
+	}
+
+	slog.Info("Summary JSON exported", "file", filename)
+	return nil
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestSummaryJSON(t *testing.T) {
+	// Given: two organizations sharing a provider, with one failed repository
+	aws := ProviderDetail{Source: "hashicorp/aws", Version: "~> 5.0"}
+	reporter := NewReporter()
+	reporter.AddResults([]AnalysisResult{
+		{RepoName: "network", Organization: "acme", Analysis: RepositoryAnalysis{
+			RepositoryPath: "/repos/acme/network",
+			Providers:      ProvidersAnalysis{UniqueProviderCount: 2, ProviderDetails: []ProviderDetail{aws, {Source: "hashicorp/random"}}},
+			ResourceAnalysis: ResourceAnalysis{
+				TotalResourceCount: 4,
+				UntaggedResources:  []UntaggedResource{{ResourceType: "aws_vpc", Name: "main"}},
+			},
+		}},
+		{RepoName: "broken", Organization: "acme", Error: os.ErrNotExist},
+		{RepoName: "storage", Organization: "globex", Analysis: RepositoryAnalysis{
+			RepositoryPath: "/repos/globex/storage",
+			Providers:      ProvidersAnalysis{UniqueProviderCount: 1, ProviderDetails: []ProviderDetail{aws}},
+			ResourceAnalysis: ResourceAnalysis{
+				TotalResourceCount: 2,
+				UntaggedResources:  []UntaggedResource{{ResourceType: "aws_s3_bucket", Name: "logs"}, {ResourceType: "aws_s3_bucket", Name: "data"}},
+			},
+		}},
+	})
+	viper.Reset()
+	tempDir := t.TempDir()
+	viper.Set("output.format", "summary")
+	viper.Set("output.directory", tempDir)
+
+	// When: reports are generated with --format summary
+	if err := generateReports(reporter, Config{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Then: only the summary file is written
+	entries, err := os.ReadDir(tempDir)
+	if err != nil || len(entries) != 1 || entries[0].Name() != SummaryJSONFileName {
+		t.Fatalf("Expected only %s, got %v (%v)", SummaryJSONFileName, entries, err)
+	}
+	data, err := os.ReadFile(filepath.Join(tempDir, SummaryJSONFileName))
+	if err != nil {
+		t.Fatalf("Failed to read summary: %v", err)
+	}
+	var summary RunSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		t.Fatalf("Expected valid JSON, got %v", err)
+	}
+
+	// And: its totals match the detailed report, with providers counted once across orgs
+	report := reporter.GenerateReport()
+	detailedResources, detailedUntagged := 0, 0
+	for _, repo := range report.Repositories {
+		detailedResources += repo.ResourceAnalysis.TotalResourceCount
+		detailedUntagged += len(repo.ResourceAnalysis.UntaggedResources)
+	}
+	expected := SummaryCounts{
+		TotalRepos:        3,
+		ProcessedRepos:    report.GlobalSummary.TotalReposScanned,
+		FailedRepos:       1,
+		TotalResources:    detailedResources,
+		UntaggedResources: detailedUntagged,
+		UniqueProviders:   2,
+	}
+	if summary.SummaryCounts != expected {
+		t.Errorf("Expected totals %+v, got %+v", expected, summary.SummaryCounts)
+	}
+
+	expectedOrgs := []OrganizationSummary{
+		{Organization: "acme", SummaryCounts: SummaryCounts{TotalRepos: 2, ProcessedRepos: 1, FailedRepos: 1, TotalResources: 4, UntaggedResources: 1, UniqueProviders: 2}},
+		{Organization: "globex", SummaryCounts: SummaryCounts{TotalRepos: 1, ProcessedRepos: 1, TotalResources: 2, UntaggedResources: 2, UniqueProviders: 1}},
+	}
+	if !reflect.DeepEqual(summary.Organizations, expectedOrgs) {
+		t.Errorf("Expected organization rollups %+v, got %+v", expectedOrgs, summary.Organizations)
+	}
+
+	// And: no per-repository detail is included
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil || raw["repositories"] != nil {
+		t.Errorf("Expected no repositories key in the summary, got %v", raw)
+	}
+}
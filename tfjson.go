@@ -0,0 +1,284 @@
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ============================================================================
+// TERRAFORM JSON - .tf.json files and extra analyzed extensions (--include-ext)
+// ============================================================================
+
+const terraformJSONExtension = ".tf.json"
+
+func isTerraformJSONFile(path string) bool {
+	return strings.HasSuffix(strings.ToLower(path), terraformJSONExtension)
+}
+
+// normalizeIncludeExtensions lower-cases --include-ext values and adds the
+// leading dot, so "tf.json", ".TF.JSON" and ".tf.json" all mean the same
+func normalizeIncludeExtensions(extensions []string) ([]string, error) {
+	normalized := make([]string, 0, len(extensions))
+	for _, extension := range extensions {
+		trimmed := strings.ToLower(strings.TrimSpace(extension))
+		if strings.Trim(trimmed, ".") == "" || strings.ContainsAny(trimmed, `/\`) {
+			return nil, fmt.Errorf("invalid --include-ext value %q: expected a file extension such as .tf.json", extension)
+		}
+		if !strings.HasPrefix(trimmed, ".") {
+			trimmed = "." + trimmed
+		}
+		if !slices.Contains(normalized, trimmed) {
+			normalized = append(normalized, trimmed)
+		}
+	}
+	return normalized, nil
+}
+
+// terraformJSONBlock describes a block type in Terraform's JSON syntax. JSON
+// cannot tell a block from an object-valued attribute, so only the nested
+// block types listed here are decoded as blocks.
+type terraformJSONBlock struct {
+	Labels []string
+	Nested []string
+	// Raw holds attributes whose JSON strings are expressions rather than
+	// templates, such as variable types and moved addresses
+	Raw []string
+}
+
+var terraformJSONBlocks = map[string]terraformJSONBlock{
+	"": {Nested: []string{"terraform", "provider", "resource", "data", "module", "variable", "output", "locals", "moved", "import"}},
+
+	"terraform":          {Nested: []string{"backend", "cloud", "required_providers"}},
+	"backend":            {Labels: []string{"type"}},
+	"cloud":              {},
+	"required_providers": {},
+	"provider":           {Labels: []string{"name"}},
+	"resource":           {Labels: []string{"type", "name"}, Nested: []string{"provisioner", "lifecycle", "connection"}},
+	"data":               {Labels: []string{"type", "name"}, Nested: []string{"lifecycle"}},
+	"provisioner":        {Labels: []string{"type"}, Nested: []string{"connection"}},
+	"lifecycle":          {},
+	"connection":         {},
+	"module":             {Labels: []string{"name"}},
+	"variable":           {Labels: []string{"name"}, Nested: []string{"validation"}, Raw: []string{"type"}},
+	"validation":         {},
+	"output":             {Labels: []string{"name"}},
+	"locals":             {},
+	"moved":              {Raw: []string{"from", "to"}},
+	"import":             {Raw: []string{"to"}},
+}
+
+// nativeJSONHeader starts every native rewrite of a .tf.json file, so the
+// rewrite can be handed to the parsers under the .tf.json name without being
+// converted again; JSON content cannot start with a comment
+const nativeJSONHeader = "# native syntax rewrite of Terraform JSON\n"
+
+func isNativeJSONRewrite(content string) bool {
+	return strings.HasPrefix(content, nativeJSONHeader)
+}
+
+// sourceLine returns line for findings in filename. Lines of a .tf.json
+// file's native rewrite do not exist in the JSON source, so they are 0.
+func sourceLine(filename string, line int) int {
+	if isTerraformJSONFile(filename) {
+		return 0
+	}
+	return line
+}
+
+// terraformJSONToNative parses a .tf.json file with the HCL JSON parser and
+// rewrites it in native syntax, so the hclsyntax-based analyzers read it like
+// any .tf file. Line numbers in the result do not match the JSON source.
+func terraformJSONToNative(content, filename string) (string, hcl.Diagnostics) {
+	file, diags := hclparse.NewParser().ParseJSON([]byte(content), filename)
+	if diags.HasErrors() {
+		return "", diags
+	}
+
+	native := hclwrite.NewEmptyFile()
+	diags = append(diags, writeTerraformJSONBody(file.Body, "", native.Body())...)
+	if diags.HasErrors() {
+		return "", diags
+	}
+	return nativeJSONHeader + string(native.Bytes()), diags
+}
+
+func writeTerraformJSONBody(body hcl.Body, blockType string, out *hclwrite.Body) hcl.Diagnostics {
+	definition := terraformJSONBlocks[blockType]
+	schema := &hcl.BodySchema{}
+	for _, nested := range definition.Nested {
+		schema.Blocks = append(schema.Blocks, hcl.BlockHeaderSchema{Type: nested, LabelNames: terraformJSONBlocks[nested].Labels})
+	}
+
+	content, remain, diags := body.PartialContent(schema)
+	if diags.HasErrors() {
+		return diags
+	}
+
+	// The root holds only blocks; any other top-level keys are not Terraform
+	if blockType != "" {
+		attributes, attrDiags := remain.JustAttributes()
+		diags = append(diags, attrDiags...)
+		if attrDiags.HasErrors() {
+			return diags
+		}
+		diags = append(diags, writeTerraformJSONAttributes(attributes, definition, out)...)
+	}
+
+	for _, block := range content.Blocks {
+		nested := out.AppendNewBlock(block.Type, block.Labels)
+		diags = append(diags, writeTerraformJSONBody(block.Body, block.Type, nested.Body())...)
+	}
+	return diags
+}
+
+func writeTerraformJSONAttributes(attributes hcl.Attributes, definition terraformJSONBlock, out *hclwrite.Body) hcl.Diagnostics {
+	ordered := make([]*hcl.Attribute, 0, len(attributes))
+	for _, attribute := range attributes {
+		ordered = append(ordered, attribute)
+	}
+	slices.SortFunc(ordered, func(a, b *hcl.Attribute) int {
+		return cmp.Compare(a.Range.Start.Byte, b.Range.Start.Byte)
+	})
+
+	var diags hcl.Diagnostics
+	for _, attribute := range ordered {
+		// Without an evaluation context, JSON strings are returned as written
+		value, valueDiags := attribute.Expr.Value(nil)
+		diags = append(diags, valueDiags...)
+		if valueDiags.HasErrors() {
+			continue
+		}
+
+		if slices.Contains(definition.Raw, attribute.Name) && value.Type().Equals(cty.String) && value.IsKnown() && !value.IsNull() {
+			if tokens, ok := rawExpressionTokens(value.AsString(), attribute.Range.Filename); ok {
+				out.SetAttributeRaw(attribute.Name, tokens)
+				continue
+			}
+		}
+		out.SetAttributeRaw(attribute.Name, templateTokensForValue(value))
+	}
+	return diags
+}
+
+// rawExpressionTokens writes an expression string unquoted, provided it parses
+func rawExpressionTokens(expression, filename string) (hclwrite.Tokens, bool) {
+	if _, diags := hclsyntax.ParseExpression([]byte(expression), filepath.Base(filename), hcl.InitialPos); diags.HasErrors() {
+		return nil, false
+	}
+	return hclwrite.Tokens{{Type: hclsyntax.TokenIdent, Bytes: []byte(expression)}}, true
+}
+
+// templateTokensForValue renders a value like hclwrite.TokensForValue, but
+// writes strings through templateLiteral since JSON strings are templates too
+func templateTokensForValue(value cty.Value) hclwrite.Tokens {
+	if !value.IsKnown() || value.IsNull() {
+		return hclwrite.TokensForValue(value)
+	}
+
+	valueType := value.Type()
+	switch {
+	case valueType.Equals(cty.String):
+		return hclwrite.Tokens{
+			{Type: hclsyntax.TokenOQuote, Bytes: []byte(`"`)},
+			{Type: hclsyntax.TokenQuotedLit, Bytes: templateLiteral(value.AsString())},
+			{Type: hclsyntax.TokenCQuote, Bytes: []byte(`"`)},
+		}
+	case valueType.IsTupleType() || valueType.IsListType() || valueType.IsSetType():
+		var elements []hclwrite.Tokens
+		for it := value.ElementIterator(); it.Next(); {
+			_, element := it.Element()
+			elements = append(elements, templateTokensForValue(element))
+		}
+		return hclwrite.TokensForTuple(elements)
+	case valueType.IsObjectType() || valueType.IsMapType():
+		var attributes []hclwrite.ObjectAttrTokens
+		for it := value.ElementIterator(); it.Next(); {
+			key, element := it.Element()
+			name := hclwrite.TokensForValue(key)
+			if hclsyntax.ValidIdentifier(key.AsString()) {
+				name = hclwrite.TokensForIdentifier(key.AsString())
+			}
+			attributes = append(attributes, hclwrite.ObjectAttrTokens{Name: name, Value: templateTokensForValue(element)})
+		}
+		return hclwrite.TokensForObject(attributes)
+	default:
+		return hclwrite.TokensForValue(value)
+	}
+}
+
+// templateLiteral writes a JSON template string as the body of a native
+// quoted template. Literal text is escaped, while "${" and "%{" sequences are
+// copied as written: their quoted arguments, e.g. lookup(var.m, "k"), must
+// stay live. Strings that do not lex as templates are written as literals.
+func templateLiteral(template string) []byte {
+	tokens, diags := hclsyntax.LexTemplate([]byte(template), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		return bytes.ReplaceAll(bytes.ReplaceAll(escapeQuotedLiteral(template), []byte("${"), []byte("$${")), []byte("%{"), []byte("%%{"))
+	}
+
+	var out []byte
+	depth := 0
+	for i, token := range tokens {
+		if token.Type == hclsyntax.TokenEOF {
+			break
+		}
+		// Copy up to the next token so whitespace inside sequences is kept
+		end := len(template)
+		if i+1 < len(tokens) {
+			end = tokens[i+1].Range.Start.Byte
+		}
+		text := template[token.Range.Start.Byte:end]
+
+		switch {
+		case depth == 0 && token.Type == hclsyntax.TokenStringLit:
+			out = append(out, escapeQuotedLiteral(text)...)
+		case token.Type == hclsyntax.TokenTemplateInterp || token.Type == hclsyntax.TokenTemplateControl:
+			depth++
+			out = append(out, text...)
+		case token.Type == hclsyntax.TokenTemplateSeqEnd:
+			depth--
+			out = append(out, text...)
+		default:
+			out = append(out, text...)
+		}
+	}
+	return out
+}
+
+// escapeQuotedLiteral escapes text for a native quoted string, leaving any
+// "$${" and "%%{" template escapes as they are
+func escapeQuotedLiteral(text string) []byte {
+	var out []byte
+	for _, r := range text {
+		switch r {
+		case '\\':
+			out = append(out, `\\`...)
+		case '"':
+			out = append(out, `\"`...)
+		case '\n':
+			out = append(out, `\n`...)
+		case '\r':
+			out = append(out, `\r`...)
+		case '\t':
+			out = append(out, `\t`...)
+		default:
+			if r < 0x20 {
+				out = append(out, fmt.Sprintf(`\u%04x`, r)...)
+				continue
+			}
+			out = utf8.AppendRune(out, r)
+		}
+	}
+	return out
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"io"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/samber/lo"
+)
+
+func TestNormalizeIncludeExtensions(t *testing.T) {
+	extensions, err := normalizeIncludeExtensions([]string{"tf.json", " .TF.JSON ", ".tfmodule"})
+	if err != nil || !reflect.DeepEqual(extensions, []string{".tf.json", ".tfmodule"}) {
+		t.Errorf("Expected [.tf.json .tfmodule], got %v (%v)", extensions, err)
+	}
+	for _, invalid := range []string{"", ".", "modules/x.tf"} {
+		if _, err := normalizeIncludeExtensions([]string{invalid}); err == nil {
+			t.Errorf("Expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestTerraformJSONToNative(t *testing.T) {
+	// Given: a JSON-syntax configuration with blocks, templates and a type expression
+	content := `{
+  "//": "generated by cdktf",
+  "terraform": {
+    "backend": {"s3": {"bucket": "state", "encrypt": true, "dynamodb_table": "locks"}}
+  },
+  "variable": {"subnets": {"type": "list(string)"}},
+  "resource": {
+    "aws_instance": {
+      "web": {"ami": "${var.ami}", "tags": {"Environment": "prod", "Owner": "platform"}},
+      "worker": [{"ami": "ami-123"}]
+    }
+  },
+  "moved": [{"from": "aws_instance.old", "to": "aws_instance.web"}]
+}`
+
+	// When: it is converted to native syntax
+	native, diags := terraformJSONToNative(content, "main.tf.json")
+	if diags.HasErrors() {
+		t.Fatalf("Expected no diagnostics, got %v", diags)
+	}
+
+	// Then: the native-syntax analyzers should read it like a .tf file
+	if backend := parseBackend(native, "main.tf"); backend == nil || backend.Type == nil || *backend.Type != "s3" ||
+		!reflect.DeepEqual(backend.Security, &BackendSecurity{Encrypted: true, StateLocking: true}) {
+		t.Errorf("Expected an encrypted, locked s3 backend, got %+v", backend)
+	}
+	if variables := parseVariables(native, "main.tf"); len(variables) != 1 || variables[0].Type != "list(string)" {
+		t.Errorf("Expected variable subnets of type list(string), got %+v", variables)
+	}
+	if resourceTypes, _ := parseResources(native, "main.tf"); len(resourceTypes) != 1 || resourceTypes[0].Count != 2 {
+		t.Errorf("Expected two aws_instance resources, got %+v", resourceTypes)
+	}
+	if moved := parseMovedBlocks(native, "main.tf"); len(moved) != 1 || moved[0].From != "aws_instance.old" || moved[0].To != "aws_instance.web" {
+		t.Errorf("Expected aws_instance.old -> aws_instance.web, got %+v", moved)
+	}
+
+	if _, diags := terraformJSONToNative(`{"resource": "not-an-object"}`, "bad.tf.json"); !diags.HasErrors() {
+		t.Error("Expected diagnostics for a resource that is not an object")
+	}
+}
+
+func TestTerraformJSONToNativeTemplates(t *testing.T) {
+	// Given: JSON strings whose interpolations hold quoted arguments, next to quoted literal text
+	content := `{
+  "resource": {
+    "aws_s3_bucket": {
+      "logs": {
+        "bucket": "${lookup(var.names, \"logs\")}",
+        "tags": {"Name": "${format(\"%s-logs\", var.env)}", "Note": "say \"hi\" $${literal}"},
+        "policy": ["${toset([\"a\"])}"]
+      }
+    }
+  }
+}`
+
+	// When: it is converted to native syntax
+	native, diags := terraformJSONToNative(content, "main.tf.json")
+	if diags.HasErrors() {
+		t.Fatalf("Expected no diagnostics, got %v", diags)
+	}
+
+	// Then: the interpolations stay live and the rewrite is valid native syntax
+	for _, expected := range []string{
+		`bucket = "${lookup(var.names, "logs")}"`,
+		`"${format("%s-logs", var.env)}"`,
+		`"say \"hi\" $${literal}"`,
+		`["${toset(["a"])}"]`,
+	} {
+		if !strings.Contains(native, expected) {
+			t.Errorf("Expected the rewrite to contain %s, got:\n%s", expected, native)
+		}
+	}
+	if _, _, diags := parseHCLSource(native, "main.tf.json"); diags.HasErrors() {
+		t.Errorf("Expected the rewrite to parse, got %v", diags)
+	}
+	if resourceTypes, _ := parseResources(content, "main.tf.json"); len(resourceTypes) != 1 || resourceTypes[0].Count != 1 {
+		t.Errorf("Expected one aws_s3_bucket resource, got %+v", resourceTypes)
+	}
+}
+
+func TestParseTerraformJSON(t *testing.T) {
+	// Given: a CDKTF-style .tf.json file with a provider, a module and tagged resources
+	content := `{
+  "terraform": {
+    "required_providers": {"aws": {"source": "hashicorp/aws", "version": "~> 5.0"}}
+  },
+  "provider": {"aws": [{"region": "us-east-1"}, {"alias": "west", "region": "us-west-2"}]},
+  "module": {"vpc": {"source": "terraform-aws-modules/vpc/aws", "version": "5.1.0"}},
+  "resource": {
+    "aws_s3_bucket": {
+      "logs": {"bucket": "logs", "tags": {"Environment": "prod", "Owner": "platform", "Project": "core", "CostCenter": "42"}},
+      "data": {"bucket": "data"}
+    }
+  }
+}`
+
+	// When: the extractors parse it directly
+	resourceTypes, untagged := parseResources(content, "cdk.tf.json")
+	providers := parseProviders(content, "cdk.tf.json")
+	modules := parseModules(content, "cdk.tf.json")
+
+	// Then: blocks and attributes should be read as from the equivalent .tf file
+	if len(resourceTypes) != 1 || resourceTypes[0].Type != "aws_s3_bucket" || resourceTypes[0].Count != 2 {
+		t.Errorf("Expected two aws_s3_bucket resources, got %+v", resourceTypes)
+	}
+	if len(untagged) != 1 || untagged[0].Name != "data" {
+		t.Errorf("Expected only aws_s3_bucket.data to be untagged, got %+v", untagged)
+	}
+	providersBySource := make(map[string]ProviderDetail)
+	for _, provider := range providers {
+		providersBySource[provider.Source] = provider
+	}
+	if required := providersBySource["hashicorp/aws"]; required.Version != "~> 5.0" {
+		t.Errorf("Expected required provider hashicorp/aws ~> 5.0, got %+v", providers)
+	}
+	if configured := providersBySource["aws"]; !reflect.DeepEqual(configured.Regions, []string{"us-east-1", "us-west-2"}) || !reflect.DeepEqual(configured.Aliases, []string{"west"}) {
+		t.Errorf("Expected both aws provider blocks with the west alias, got %+v", providers)
+	}
+	if len(modules) != 1 || modules[0].Source != "terraform-aws-modules/vpc/aws" || modules[0].Version != "5.1.0" {
+		t.Errorf("Expected the vpc module at 5.1.0, got %+v", modules)
+	}
+
+	// And: the same content under a .tf name is not valid native syntax
+	if resourceTypes, _ := parseResources(content, "cdk.tf"); len(resourceTypes) != 0 {
+		t.Errorf("Expected JSON content in a .tf file not to parse, got %+v", resourceTypes)
+	}
+}
+
+func TestIncludeExtensionsInAnalysis(t *testing.T) {
+	// Given: a repository with .tf, .tf.json and custom-extension files
+	repoDir := createTempTerraformRepo(t, map[string]string{
+		"main.tf":         `resource "aws_s3_bucket" "data" {}`,
+		"network.tf.json": `{"resource": {"aws_vpc": {"main": {"cidr_block": "10.0.0.0/16"}}}}`,
+		"dns.tfmodule":    `resource "aws_route53_zone" "primary" {}`,
+		"broken.tf.json":  `{"resource": `,
+	})
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	resourceCounts := func(options AnalysisOptions) map[string]int {
+		analysis, err := analyzeRepositoryWithOptions(repoDir, options, logger)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		counts := make(map[string]int)
+		for _, resourceType := range analysis.ResourceAnalysis.ResourceTypes {
+			counts[resourceType.Type] = resourceType.Count
+		}
+		return counts
+	}
+
+	t.Run("only default extensions, including .tf.json, are analyzed without --include-ext", func(t *testing.T) {
+		if counts := resourceCounts(defaultAnalysisOptions()); !reflect.DeepEqual(counts, map[string]int{"aws_s3_bucket": 1, "aws_vpc": 1}) {
+			t.Errorf("Expected aws_s3_bucket and aws_vpc, got %v", counts)
+		}
+	})
+
+	t.Run("included extensions are analyzed", func(t *testing.T) {
+		options := analysisOptionsFromConfig(Config{IncludeExtensions: []string{".tfmodule"}})
+		expected := map[string]int{"aws_s3_bucket": 1, "aws_vpc": 1, "aws_route53_zone": 1}
+		if counts := resourceCounts(options); !reflect.DeepEqual(counts, expected) {
+			t.Errorf("Expected %v, got %v", expected, counts)
+		}
+	})
+
+	t.Run("invalid JSON is a parse error under --validate-only", func(t *testing.T) {
+		options := analysisOptionsFromConfig(Config{ValidateOnly: true})
+		analysis, err := analyzeRepositoryWithOptions(repoDir, options, logger)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(analysis.FileErrors) != 1 || analysis.FileErrors[0].Path != "broken.tf.json" {
+			t.Errorf("Expected a parse error for broken.tf.json, got %+v", analysis.FileErrors)
+		}
+	})
+
+	t.Run("JSON expressions are analyzed and failures are file errors", func(t *testing.T) {
+		// Given: a CDKTF-style file with quoted function arguments, one whose
+		// interpolation is not a valid expression and one with a quoted argument name
+		repoDir := createTempTerraformRepo(t, map[string]string{
+			"cdk.tf.json": `{"resource": {"aws_s3_bucket": {
+  "logs": {"for_each": "${toset([\"a\", \"b\"])}", "bucket": "${lookup(var.names, \"logs\")}", "tags": {"Name": "${format(\"%s-logs\", var.env)}"}},
+  "data": {"bucket": "${join(\"-\", [var.env, \"data\"])}"}
+}}}`,
+			"broken.tf.json": `{"resource": {"aws_vpc": {"main": {"cidr_block": "${lookup(var.cidrs, \"a\") +}"}}}}`,
+			"quoted.tf.json": `{"resource": {"aws_subnet": {"main": {"\"cidr_block\"": "10.0.0.0/24"}}}}`,
+		})
+		for _, validateOnly := range []bool{false, true} {
+			// When: the repository is analyzed or validated
+			analysis, err := analyzeRepositoryWithOptions(repoDir, analysisOptionsFromConfig(Config{ValidateOnly: validateOnly}), logger)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			// Then: the expressions parse and the broken file is a file error, not legacy HCL
+			if len(analysis.LegacyHCLFiles) != 0 {
+				t.Errorf("Expected no legacy HCL files (validate-only %v), got %+v", validateOnly, analysis.LegacyHCLFiles)
+			}
+			errored := lo.Map(analysis.FileErrors, func(fileError FileError, _ int) string { return fileError.Path })
+			if slices.Sort(errored); !reflect.DeepEqual(errored, []string{"broken.tf.json", "quoted.tf.json"}) {
+				t.Errorf("Expected file errors for broken.tf.json and quoted.tf.json (validate-only %v), got %+v", validateOnly, analysis.FileErrors)
+			}
+			if !validateOnly && analysis.ResourceAnalysis.TotalResourceCount != 2 {
+				t.Errorf("Expected the two buckets, got %+v", analysis.ResourceAnalysis.ResourceTypes)
+			}
+		}
+	})
+
+	t.Run("JSON findings carry no line from the native rewrite", func(t *testing.T) {
+		// Given: a moved block and a literal password, in JSON and in native syntax
+		repoDir := createTempTerraformRepo(t, map[string]string{
+			"main.tf":      "\n\nmoved {\n  from = aws_instance.a\n  to   = aws_instance.b\n}\n",
+			"main.tf.json": `{"moved": [{"from": "aws_instance.c", "to": "aws_instance.d"}], "resource": {"aws_db_instance": {"main": {"password": "hunter2hunter2"}}}}`,
+		})
+		options := defaultAnalysisOptions()
+		options.ScanSecrets = true
+
+		// When: the repository is analyzed
+		analysis, err := analyzeRepositoryWithOptions(repoDir, options, logger)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then: the native file keeps its line and the JSON findings report none
+		lines := make(map[string]int)
+		for _, moved := range analysis.MovedAnalysis.MovedBlocks {
+			lines[moved.File] = moved.Line
+		}
+		if !reflect.DeepEqual(lines, map[string]int{"main.tf": 3, "main.tf.json": 0}) {
+			t.Errorf("Expected main.tf:3 and no line for main.tf.json, got %v", lines)
+		}
+		if len(analysis.SecretFindings) != 1 || analysis.SecretFindings[0].File != "main.tf.json" || analysis.SecretFindings[0].Line != 0 {
+			t.Errorf("Expected one main.tf.json secret without a line, got %+v", analysis.SecretFindings)
+		}
+	})
+}
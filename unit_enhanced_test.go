@@ -207,7 +207,7 @@ func TestFindMissingTagsCriticalLogic(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			// WHEN: findMissingTags is called
-			missing := findMissingTags(tc.tags)
+			missing := findMissingTags(tc.tags, defaultMandatoryTags, false)
 			
 			// THEN: result should match expected missing tags
 			if len(missing) != len(tc.expectedMissing) {
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// ============================================================================
+// WEBHOOK - POSTing the run summary on completion (--webhook-url)
+// ============================================================================
+
+// DefaultWebhookTimeout bounds the whole webhook request, including reading the response
+const DefaultWebhookTimeout = 10 * time.Second
+
+// parseWebhookHeaders turns repeated --webhook-header "Name: Value" values into request headers
+func parseWebhookHeaders(values []string) (http.Header, error) {
+	headers := make(http.Header)
+	for _, value := range values {
+		name, headerValue, found := strings.Cut(value, ":")
+		name = strings.TrimSpace(name)
+		if !found || name == "" || strings.ContainsAny(name, " \t") {
+			return nil, fmt.Errorf("invalid --webhook-header %q: expected \"Name: Value\"", value)
+		}
+		headers.Add(name, strings.TrimSpace(headerValue))
+	}
+	return headers, nil
+}
+
+// validateWebhookConfig checks the webhook URL and headers before any analysis runs
+func validateWebhookConfig(config Config) error {
+	if config.WebhookURL == "" {
+		return nil
+	}
+	parsed, err := url.Parse(config.WebhookURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("--webhook-url must be an http or https URL, got %q", config.WebhookURL)
+	}
+	if config.WebhookTimeout < 0 {
+		return fmt.Errorf("--webhook-timeout must not be negative, got %v", config.WebhookTimeout)
+	}
+	_, err = parseWebhookHeaders(config.WebhookHeaders)
+	return err
+}
+
+// maskWebhookHeaders hides header values, which usually carry credentials, for --print-config
+func maskWebhookHeaders(values []string) []string {
+	return lo.Map(values, func(value string, _ int) string {
+		name, headerValue, _ := strings.Cut(value, ":")
+		return name + ": " + maskToken(strings.TrimSpace(headerValue))
+	})
+}
+
+// postSummaryWebhook sends the --format summary document to config.WebhookURL
+func postSummaryWebhook(ctx context.Context, reporter *Reporter, config Config) error {
+	payload, err := json.Marshal(reporter.Summary())
+	if err != nil {
+		return fmt.Errorf("failed to marshal summary JSON: %w", err)
+	}
+	headers, err := parseWebhookHeaders(config.WebhookHeaders)
+	if err != nil {
+		return err
+	}
+
+	timeout := config.WebhookTimeout
+	if timeout == 0 {
+		timeout = DefaultWebhookTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, config.WebhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	request.Header = headers
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("webhook returned %s", response.Status)
+	}
+	return nil
+}
+
+// notifyWebhook posts the summary when --webhook-url is set; a failed
+// delivery is logged as a warning and never fails the run
+func notifyWebhook(reporter *Reporter, config Config, logger *slog.Logger) {
+	if config.WebhookURL == "" {
+		return
+	}
+	if err := postSummaryWebhook(context.Background(), reporter, config); err != nil {
+		logger.Warn("Failed to deliver summary webhook", "error", err)
+		return
+	}
+	logger.Info("Summary webhook delivered")
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPostSummaryWebhook(t *testing.T) {
+	reporter := NewReporter()
+	reporter.AddResults([]AnalysisResult{
+		{RepoName: "network", Organization: "acme", Analysis: RepositoryAnalysis{
+			ResourceAnalysis: ResourceAnalysis{TotalResourceCount: 3},
+		}},
+	})
+
+	t.Run("posts the summary JSON with the configured headers", func(t *testing.T) {
+		// Given: a server recording the request it receives
+		var received struct {
+			method  string
+			headers http.Header
+			summary RunSummary
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received.method = r.Method
+			received.headers = r.Header.Clone()
+			if err := json.NewDecoder(r.Body).Decode(&received.summary); err != nil {
+				t.Errorf("Expected a JSON body, got %v", err)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+		config := Config{
+			WebhookURL:     server.URL,
+			WebhookHeaders: []string{"Authorization: Bearer secret", "X-Run: nightly"},
+		}
+
+		// When: the webhook is posted
+		if err := postSummaryWebhook(context.Background(), reporter, config); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Then: the payload matches the summary report and the headers are sent
+		if received.method != http.MethodPost {
+			t.Errorf("Expected POST, got %s", received.method)
+		}
+		if !reflect.DeepEqual(received.summary, reporter.Summary()) {
+			t.Errorf("Expected summary %+v, got %+v", reporter.Summary(), received.summary)
+		}
+		for name, expected := range map[string]string{"Authorization": "Bearer secret", "X-Run": "nightly", "Content-Type": "application/json"} {
+			if got := received.headers.Get(name); got != expected {
+				t.Errorf("Expected %s %q, got %q", name, expected, got)
+			}
+		}
+	})
+
+	t.Run("failed deliveries are warnings", func(t *testing.T) {
+		// Given: a server that rejects the request and a slow server
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer failing.Close()
+		slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(200 * time.Millisecond):
+			}
+		}))
+		defer slow.Close()
+
+		if err := postSummaryWebhook(context.Background(), reporter, Config{WebhookURL: failing.URL}); err == nil || !strings.Contains(err.Error(), "500") {
+			t.Errorf("Expected an error naming the 500 status, got %v", err)
+		}
+		if err := postSummaryWebhook(context.Background(), reporter, Config{WebhookURL: slow.URL, WebhookTimeout: 20 * time.Millisecond}); err == nil {
+			t.Error("Expected the webhook timeout to be applied")
+		}
+
+		// When: notifyWebhook delivers to the failing server
+		var logs bytes.Buffer
+		notifyWebhook(reporter, Config{WebhookURL: failing.URL}, slog.New(slog.NewTextHandler(&logs, nil)))
+
+		// Then: a warning is logged
+		if !strings.Contains(logs.String(), "level=WARN") {
+			t.Errorf("Expected a warning, got %q", logs.String())
+		}
+	})
+
+	t.Run("nothing is sent without a URL", func(t *testing.T) {
+		notifyWebhook(reporter, Config{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	})
+}
+
+func TestWebhookConfig(t *testing.T) {
+	headers, err := parseWebhookHeaders([]string{"Authorization: Bearer a:b", " X-Team :platform"})
+	if err != nil || headers.Get("Authorization") != "Bearer a:b" || headers.Get("X-Team") != "platform" {
+		t.Errorf("Expected both headers, got %v (%v)", headers, err)
+	}
+	for _, invalid := range []string{"Authorization", ": value", "Bad Name: value"} {
+		if _, err := parseWebhookHeaders([]string{invalid}); err == nil {
+			t.Errorf("Expected an error for header %q", invalid)
+		}
+	}
+
+	for _, invalid := range []string{"ftp://example.com/hook", "example.com/hook", "https://"} {
+		if err := validateWebhookConfig(Config{WebhookURL: invalid}); err == nil {
+			t.Errorf("Expected an error for URL %q", invalid)
+		}
+	}
+	if err := validateWebhookConfig(Config{WebhookURL: "https://example.com/hook", WebhookHeaders: []string{"X-Run: nightly"}}); err != nil {
+		t.Errorf("Expected a valid webhook config, got %v", err)
+	}
+
+	if masked := maskWebhookHeaders([]string{"Authorization: Bearer secret-token"}); strings.Contains(masked[0], "secret-token") || !strings.HasPrefix(masked[0], "Authorization: ") {
+		t.Errorf("Expected the header value to be masked, got %v", masked)
+	}
+}